@@ -16,10 +16,14 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"internal/apiclient"
 	"internal/cmd"
 	"os"
+	"os/signal"
+	"syscall"
 )
 
 // https://goreleaser.com/cookbooks/using-main.version/?h=ldflags
@@ -34,7 +38,18 @@ func main() {
 	apiclient.SetBuildParams(version, commit, date)
 	rootCmd.Version = fmt.Sprintf("%s date: %s [commit: %.7s]", version, date, commit)
 
-	if err := rootCmd.Execute(); err != nil {
+	// cancel in-flight requests and pollers on the first interrupt,
+	// a second interrupt terminates immediately
+	ctx, cancel := context.WithCancelCause(context.Background())
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		signal.Stop(signals)
+		cancel(errors.New("interrupted, in-flight requests were cancelled"))
+	}()
+
+	if err := cmd.ExecuteContext(ctx); err != nil {
 		os.Exit(1)
 	}
 }
@@ -18,7 +18,6 @@ import (
 	"archive/tar"
 	"bytes"
 	"compress/gzip"
-	"context"
 	"encoding/json"
 	"fmt"
 	"internal/clilog"
@@ -118,8 +117,8 @@ func ClearEntityPayloadList() {
 	entityPayloadList = entityPayloadList[:0]
 }
 
-func ExtractTgz(gcsURL string) (folder string, err error) {
-	ctx := context.Background()
+func (c *Client) ExtractTgz(gcsURL string) (folder string, err error) {
+	ctx := c.GetContext()
 
 	folder, err = os.MkdirTemp("", "integration")
 	if err != nil {
@@ -129,7 +128,7 @@ func ExtractTgz(gcsURL string) (folder string, err error) {
 	// Parse the GCS URL
 	parsedURL, err := url.Parse(gcsURL)
 	if err != nil {
-		return "", fmt.Errorf("Error parsing GCS URL:", err)
+		return "", fmt.Errorf("Error parsing GCS URL: %w", err)
 	}
 	if parsedURL.Scheme != "gs" {
 		return "", fmt.Errorf("Invalid GCS URL scheme. Should be 'gs://'")
@@ -142,7 +141,7 @@ func ExtractTgz(gcsURL string) (folder string, err error) {
 	// Create a Google Cloud Storage client
 	client, err := storage.NewClient(ctx)
 	if err != nil {
-		return "", fmt.Errorf("Error creating GCS client:", err)
+		return "", fmt.Errorf("Error creating GCS client: %w", err)
 	}
 	defer client.Close()
 
@@ -153,33 +152,33 @@ func ExtractTgz(gcsURL string) (folder string, err error) {
 	// Create a reader to stream the object's content
 	reader, err := object.NewReader(ctx)
 	if err != nil {
-		return "", fmt.Errorf("Error creating object reader:", err)
+		return "", fmt.Errorf("Error creating object reader: %w", err)
 	}
 	defer reader.Close()
 
 	// Create the local file to save the download
 	localFile, err := os.Create(path.Join(folder, fileName))
 	if err != nil {
-		return "", fmt.Errorf("Error creating local file:", err)
+		return "", fmt.Errorf("Error creating local file: %w", err)
 	}
 	defer localFile.Close()
 
 	// Download the object and save it to the local file
 	if _, err := io.Copy(localFile, reader); err != nil {
-		return "", fmt.Errorf("Error downloading object:", err)
+		return "", fmt.Errorf("Error downloading object: %w", err)
 	}
 
 	// Open the .tgz file
 	file, err := os.Open(path.Join(folder, fileName))
 	if err != nil {
-		return "", fmt.Errorf("Error opening file:", err)
+		return "", fmt.Errorf("Error opening file: %w", err)
 	}
 	defer file.Close() // Ensure file closure
 
 	// Create a gzip reader
 	gzipReader, err := gzip.NewReader(file)
 	if err != nil {
-		return "", fmt.Errorf("Error creating gzip reader:", err)
+		return "", fmt.Errorf("Error creating gzip reader: %w", err)
 	}
 	defer gzipReader.Close() // Ensure closure
 
@@ -193,7 +192,7 @@ func ExtractTgz(gcsURL string) (folder string, err error) {
 			break // End of archive
 		}
 		if err != nil {
-			return "", fmt.Errorf("Error reading tar entry:", err)
+			return "", fmt.Errorf("Error reading tar entry: %w", err)
 		}
 		if strings.Contains(header.Name, "..") {
 			continue
@@ -204,19 +203,19 @@ func ExtractTgz(gcsURL string) (folder string, err error) {
 		case tar.TypeDir:
 			// Create directory
 			if err := os.Mkdir(path.Join(folder, header.Name), 0o755); err != nil {
-				return "", fmt.Errorf("Error creating directory:", err)
+				return "", fmt.Errorf("Error creating directory: %w", err)
 			}
 		case tar.TypeReg:
 			// Create output file
 			outFile, err := os.Create(path.Join(folder, header.Name))
 			if err != nil {
-				return "", fmt.Errorf("Error creating file:", err)
+				return "", fmt.Errorf("Error creating file: %w", err)
 			}
 			defer outFile.Close()
 
 			// Copy contents from the tar to the output file
 			if _, err := io.Copy(outFile, tarReader); err != nil {
-				return "", fmt.Errorf("Error writing file:", err)
+				return "", fmt.Errorf("Error writing file: %w", err)
 			}
 		default:
 			return "", fmt.Errorf("Unsupported type: %b in %s\n", header.Typeflag, header.Name)
@@ -225,7 +224,7 @@ func ExtractTgz(gcsURL string) (folder string, err error) {
 	return folder, nil
 }
 
-func GetCloudDeployGCSLocations(cloudDeployProjectId string, cloudDeployLocation string,
+func (c *Client) GetCloudDeployGCSLocations(cloudDeployProjectId string, cloudDeployLocation string,
 	pipeline string, release string) (skaffoldConfigUri string, err error) {
 	type cloudDeployRelease struct {
 		SkaffoldConfigUri string `json:"skaffoldConfigUri"`
@@ -246,13 +245,10 @@ func GetCloudDeployGCSLocations(cloudDeployProjectId string, cloudDeployLocation
 		cloudDeployProjectId, cloudDeployLocation, pipeline, release)
 	u, _ := url.Parse(cloudDeployURL)
 
-	ClientPrintHttpResponse.Set(false)
-
-	respBody, err := HttpClient(u.String())
+	respBody, err := c.WithoutOutput().HttpClient(u.String())
 	if err != nil {
 		return "", err
 	}
-	defer ClientPrintHttpResponse.Set(GetCmdPrintHttpResponseSetting())
 
 	err = json.Unmarshal(respBody, &r)
 	if err != nil {
@@ -262,11 +258,11 @@ func GetCloudDeployGCSLocations(cloudDeployProjectId string, cloudDeployLocation
 	return r.SkaffoldConfigUri, nil
 }
 
-func WriteResultsFile(deployOutputGCS string, status string) (err error) {
+func (c *Client) WriteResultsFile(deployOutputGCS string, status string) (err error) {
 	contents := fmt.Sprintf("{\"resultStatus\": \"%s\"}", status)
 	filename := "results.json"
 
-	err = writeGCSFile(deployOutputGCS, filename, contents)
+	err = c.writeGCSFile(deployOutputGCS, filename, contents)
 	if err != nil {
 		return err
 	}
@@ -277,7 +273,7 @@ func parseGCSURI(gcsURI string) (bucketName, objectPath string, err error) {
 	// Parse the GCS URL
 	parsedURL, err := url.Parse(gcsURI)
 	if err != nil {
-		return "", "", fmt.Errorf("Error parsing GCS URL:", err)
+		return "", "", fmt.Errorf("Error parsing GCS URL: %w", err)
 	}
 	if parsedURL.Scheme != "gs" {
 		return "", "", fmt.Errorf("Invalid GCS URL scheme. Should be 'gs://'")
@@ -295,7 +291,7 @@ func parseGCSURI(gcsURI string) (bucketName, objectPath string, err error) {
 	return parts[0], parts[1], nil
 }
 
-func WriteManifest(deployOutputGCS string, version string) (err error) {
+func (c *Client) WriteManifest(deployOutputGCS string, version string) (err error) {
 	manifestFile := "manifest.txt"
 	resultsFile := "results.json"
 
@@ -303,12 +299,12 @@ func WriteManifest(deployOutputGCS string, version string) (err error) {
 	// do not use path.Join. This will caseu gs:// to be written as gs:/ and failed the release.
 	resultContents := fmt.Sprintf(`{"resultStatus": "SUCCEEDED", "manifestFile": "%s"}`, deployOutputGCS+"/"+manifestFile)
 
-	err = writeGCSFile(deployOutputGCS, manifestFile, manifestContents)
+	err = c.writeGCSFile(deployOutputGCS, manifestFile, manifestContents)
 	if err != nil {
 		return err
 	}
 
-	err = writeGCSFile(deployOutputGCS, resultsFile, resultContents)
+	err = c.writeGCSFile(deployOutputGCS, resultsFile, resultContents)
 	if err != nil {
 		return err
 	}
@@ -316,8 +312,8 @@ func WriteManifest(deployOutputGCS string, version string) (err error) {
 	return nil
 }
 
-func writeGCSFile(deployOutputGCS string, fileName string, contents string) (err error) {
-	ctx := context.Background()
+func (c *Client) writeGCSFile(deployOutputGCS string, fileName string, contents string) (err error) {
+	ctx := c.GetContext()
 	client, err := storage.NewClient(ctx)
 	if err != nil {
 		return fmt.Errorf("storage.NewClient: %v", err)
@@ -0,0 +1,262 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"internal/clilog"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// redacted replaces secrets in cassettes
+const redacted = "REDACTED"
+
+// interaction is a request and its response stored in a cassette file
+type interaction struct {
+	Request  recordedRequest  `json:"request"`
+	Response recordedResponse `json:"response"`
+}
+
+type recordedRequest struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+type recordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// cassette records interactions to a folder or replays them from it
+type cassette struct {
+	folder       string
+	replay       bool
+	count        int
+	interactions map[string][]interaction // replayed interactions by method and url
+	sync.Mutex
+}
+
+// secret headers are not recorded
+var secretHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "X-Goog-Api-Key", "Proxy-Authorization"}
+
+// SetRecordFolder records every request and response of the client in cassette files in the folder
+func (c *Client) SetRecordFolder(folder string) error {
+	if err := os.MkdirAll(folder, 0o755); err != nil {
+		return err
+	}
+	c.cassette = &cassette{folder: folder}
+	return nil
+}
+
+// SetReplayFolder replays the responses recorded in the folder instead of sending requests
+func (c *Client) SetReplayFolder(folder string) error {
+	cassette, err := readCassette(folder)
+	if err != nil {
+		return err
+	}
+	c.cassette = cassette
+	return nil
+}
+
+// IsReplay returns true when responses are replayed from cassette files
+func (c *Client) IsReplay() bool {
+	return c.cassette != nil && c.cassette.replay
+}
+
+// readCassette reads the interactions recorded in the folder
+func readCassette(folder string) (*cassette, error) {
+	c := &cassette{folder: folder, replay: true, interactions: make(map[string][]interaction)}
+
+	files, err := filepath.Glob(filepath.Join(folder, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no cassette files found in %s", folder)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		i := interaction{}
+		if err = json.Unmarshal(content, &i); err != nil {
+			return nil, fmt.Errorf("invalid cassette file %s: %w", file, err)
+		}
+		key := i.Request.Method + " " + i.Request.URL
+		c.interactions[key] = append(c.interactions[key], i)
+	}
+	return c, nil
+}
+
+// replayResponse returns the next recorded response for the request
+func (c *cassette) replayResponse(req *http.Request) (*http.Response, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	key := req.Method + " " + sanitizeURL(req.URL)
+	recorded := c.interactions[key]
+	if len(recorded) == 0 {
+		return nil, fmt.Errorf("no recorded response for %s in %s", key, c.folder)
+	}
+	i := recorded[0]
+	c.interactions[key] = recorded[1:]
+	clilog.Debug.Printf("Replaying response for %s\n", key)
+
+	return &http.Response{
+		Status:     fmt.Sprintf("%d %s", i.Response.StatusCode, http.StatusText(i.Response.StatusCode)),
+		StatusCode: i.Response.StatusCode,
+		Header:     i.Response.Headers,
+		Body:       io.NopCloser(strings.NewReader(i.Response.Body)),
+		Request:    req,
+	}, nil
+}
+
+// record writes the sanitized request and response to the next cassette file.
+// The response body is read and replaced so the caller can still read it
+func (c *cassette) record(req *http.Request, resp *http.Response) error {
+	var requestBody []byte
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return err
+		}
+		if requestBody, err = io.ReadAll(body); err != nil {
+			return err
+		}
+	}
+	responseBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+	if err != nil {
+		return err
+	}
+
+	i := interaction{
+		Request: recordedRequest{
+			Method:  req.Method,
+			URL:     sanitizeURL(req.URL),
+			Headers: sanitizeHeaders(req.Header),
+			Body:    sanitizeBody(requestBody),
+		},
+		Response: recordedResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    sanitizeHeaders(resp.Header),
+			Body:       sanitizeBody(responseBody),
+		},
+	}
+	content, err := json.MarshalIndent(i, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	c.count++
+	name := fmt.Sprintf("%04d-%s-%s.json", c.count, strings.ToLower(req.Method), fileName(req.URL.Path))
+	clilog.Debug.Printf("Recording %s %s in %s\n", req.Method, req.URL.Path, name)
+	return os.WriteFile(filepath.Join(c.folder, name), content, 0o644)
+}
+
+func sanitizeURL(u *url.URL) string {
+	sanitized := *u
+	q := sanitized.Query()
+	for key := range q {
+		if clilog.IsSecretKey(key) {
+			q.Set(key, redacted)
+		}
+	}
+	sanitized.RawQuery = q.Encode()
+	return sanitized.String()
+}
+
+func sanitizeHeaders(headers http.Header) http.Header {
+	sanitized := headers.Clone()
+	for _, header := range secretHeaders {
+		sanitized.Del(header)
+	}
+	if len(sanitized) == 0 {
+		return nil
+	}
+	return sanitized
+}
+
+// sanitizeBody redacts the string values of secret fields in json payloads. Bodies without
+// secrets are recorded as they are; numbers are decoded as json.Number to keep their precision
+func sanitizeBody(body []byte) string {
+	var payload interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if len(body) == 0 || decoder.Decode(&payload) != nil || decoder.More() {
+		return string(body)
+	}
+	if !sanitizeValue(payload) {
+		return string(body)
+	}
+	buf := bytes.Buffer{}
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(payload); err != nil {
+		return string(body)
+	}
+	return string(bytes.TrimSpace(buf.Bytes()))
+}
+
+// sanitizeValue redacts the secret fields of the value and returns true if any was found
+func sanitizeValue(value interface{}) (found bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if _, ok := child.(string); ok && clilog.IsSecretKey(key) {
+				v[key] = redacted
+				found = true
+				continue
+			}
+			found = sanitizeValue(child) || found
+		}
+	case []interface{}:
+		for _, child := range v {
+			found = sanitizeValue(child) || found
+		}
+	}
+	return found
+}
+
+// fileName converts a url path to a file name
+func fileName(urlPath string) string {
+	name := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' {
+			return r
+		}
+		return '_'
+	}, strings.Trim(urlPath, "/"))
+	if len(name) > 100 {
+		name = name[len(name)-100:]
+	}
+	return name
+}
@@ -0,0 +1,155 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"bytes"
+	"internal/clilog"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordReplay(t *testing.T) {
+	clilog.Init(false, false, true, true)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name": "ac", "decryptedCredential": {"usernameAndPassword": {"password": "s3cret"}}, "nextPageToken": "2"}`))
+	}))
+	folder := t.TempDir()
+	session := NewClient(IntegrationClientOptions{})
+	client := &RateLimitedHTTPClient{client: http.DefaultClient, session: session}
+
+	if err := session.SetRecordFolder(folder); err != nil {
+		t.Fatalf("SetRecordFolder failed: %v", err)
+	}
+	req, _ := http.NewRequest(http.MethodPost, server.URL+"/v1/authConfigs?access_token=abc", bytes.NewBufferString(`{"clientSecret": "s3cret"}`))
+	req.Header.Set("Authorization", "Bearer abc")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !strings.Contains(string(body), "s3cret") {
+		t.Errorf("expected the caller to read the original response, got %s", string(body))
+	}
+	server.Close()
+
+	files, _ := filepath.Glob(filepath.Join(folder, "*.json"))
+	if len(files) != 1 {
+		t.Fatalf("expected one cassette file, got %v", files)
+	}
+	content, _ := os.ReadFile(files[0])
+	for _, secret := range []string{"s3cret", "Bearer", "abc"} {
+		if strings.Contains(string(content), secret) {
+			t.Errorf("cassette contains %s: %s", secret, string(content))
+		}
+	}
+	if !strings.Contains(string(content), `\"nextPageToken\":\"2\"`) {
+		t.Errorf("expected page tokens to be kept: %s", string(content))
+	}
+
+	// replay without the server
+	if err = session.SetReplayFolder(folder); err != nil {
+		t.Fatalf("SetReplayFolder failed: %v", err)
+	}
+	req, _ = http.NewRequest(http.MethodPost, server.URL+"/v1/authConfigs?access_token=xyz", nil)
+	if resp, err = client.Do(req); err != nil {
+		t.Fatalf("replay failed: %v", err)
+	}
+	body, _ = io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), `"name":"ac"`) {
+		t.Errorf("unexpected replayed response %d %s", resp.StatusCode, string(body))
+	}
+	if _, err = client.Do(req); err == nil {
+		t.Errorf("expected an error when the recorded responses are used up")
+	}
+}
+
+func TestRecordAuthConfig(t *testing.T) {
+	clilog.Init(false, false, true, true)
+
+	authConfig := `{
+		"displayName": "ssh",
+		"decryptedCredential": {
+			"credentialType": "SSH_PUBLIC_KEY",
+			"authToken": {"type": "Basic", "token": "dXNlcjpwYXNz"},
+			"clientCertificate": {"sslCertificate": "cert", "encryptedPrivateKey": "key", "passphrase": "changeit"},
+			"sshPublicKey": {"username": "admin", "sshClientCert": "ssh-cert", "sshClientCertPass": "ssh-pass", "certType": "RSA"},
+			"jwt": {"jwtHeader": "{}", "jwtPayload": "{}", "secret": "jwt-secret", "jwt": "jwt-token"}
+		}
+	}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(authConfig))
+	}))
+	defer server.Close()
+
+	folder := t.TempDir()
+	session := NewClient(IntegrationClientOptions{})
+	client := &RateLimitedHTTPClient{client: http.DefaultClient, session: session}
+	if err := session.SetRecordFolder(folder); err != nil {
+		t.Fatalf("SetRecordFolder failed: %v", err)
+	}
+	req, _ := http.NewRequest(http.MethodPost, server.URL+"/v1/authConfigs", bytes.NewBufferString(authConfig))
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	resp.Body.Close()
+
+	files, _ := filepath.Glob(filepath.Join(folder, "*.json"))
+	if len(files) != 1 {
+		t.Fatalf("expected one cassette file, got %v", files)
+	}
+	content, _ := os.ReadFile(files[0])
+	for _, secret := range []string{"dXNlcjpwYXNz", "key", "changeit", "ssh-cert", "ssh-pass", "jwt-secret", "jwt-token"} {
+		if strings.Contains(string(content), `\"`+secret+`\"`) {
+			t.Errorf("cassette contains %s: %s", secret, string(content))
+		}
+	}
+	for _, public := range []string{"ssh", "SSH_PUBLIC_KEY", "admin", "cert", "RSA"} {
+		if !strings.Contains(string(content), `\"`+public+`\"`) {
+			t.Errorf("expected the cassette to contain %s: %s", public, string(content))
+		}
+	}
+}
+
+func TestSanitizeBody(t *testing.T) {
+	tests := []struct {
+		body     string
+		expected string
+	}{
+		// bodies without secrets are recorded unchanged
+		{`{"z": 1, "executionId": 9007199254740993, "url": "https://a.com?b=1&c=<d>"}`,
+			`{"z": 1, "executionId": 9007199254740993, "url": "https://a.com?b=1&c=<d>"}`},
+		{`not json`, `not json`},
+		{`{"a": 1} {"b": 2}`, `{"a": 1} {"b": 2}`},
+		// numbers and html characters are kept when a secret is redacted
+		{`{"password": "s3cret", "size": 9007199254740993, "html": "<b>"}`,
+			`{"html":"<b>","password":"REDACTED","size":9007199254740993}`},
+	}
+	for _, test := range tests {
+		if actual := sanitizeBody([]byte(test.body)); actual != test.expected {
+			t.Errorf("sanitizeBody(%s) = %s, expected %s", test.body, actual, test.expected)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"context"
+	"sync"
+)
+
+// Client holds the settings of a session with the Integration and Connectors APIs:
+// the project, region, token, API environment, rate and print settings.
+// The client packages take a Client so that sessions for different projects can run
+// concurrently. The With methods return copies, they never change the client they are
+// called on, so a Client can be shared by goroutines once it is set up
+type Client struct {
+	options           IntegrationClientOptions
+	rate              Rate
+	apiEndpoint       string // replaces the scheme and host of the control plane APIs, for example with a fake server
+	printHttpResponse bool
+	query             *Query // applied to the responses before they are printed
+	ctx               context.Context
+	token             *accessToken // shared by the copies of the client
+
+	// the rate limits and the cassette apply to every request of the client and its copies
+	limiters *rateLimiters
+	cassette *cassette
+	baseURLs *baseURLs
+}
+
+// accessToken is the OAuth access token of a client and its copies
+type accessToken struct {
+	value string
+	sync.Mutex
+}
+
+var defaultClient = struct {
+	client *Client
+	sync.Mutex
+}{client: newClient()}
+
+func newClient() *Client {
+	return &Client{
+		options: IntegrationClientOptions{
+			MaxAttempts:    DefaultMaxAttempts,
+			MaxElapsedTime: DefaultMaxElapsedTime,
+		},
+		printHttpResponse: true,
+		ctx:               context.Background(),
+		token:             &accessToken{},
+		limiters:          newRateLimiters(),
+		baseURLs:          &baseURLs{services: map[string]service{}},
+	}
+}
+
+// NewClient returns a client configured only from the options, the preferences file is not read
+func NewClient(o IntegrationClientOptions) *Client {
+	c := newClient()
+	maxAttempts, maxElapsedTime := c.options.MaxAttempts, c.options.MaxElapsedTime
+	c.options = o
+	c.options.Token = ""
+	c.SetIntegrationToken(o.Token)
+	c.SetAPI(o.Api)
+	if o.MaxAttempts <= 0 {
+		c.options.MaxAttempts = maxAttempts
+	}
+	if o.MaxElapsedTime <= 0 {
+		c.options.MaxElapsedTime = maxElapsedTime
+	}
+	return c
+}
+
+// DefaultClient returns the client configured by the command line flags and the preferences file
+func DefaultClient() *Client {
+	defaultClient.Lock()
+	defer defaultClient.Unlock()
+	return defaultClient.client
+}
+
+func setDefaultClient(c *Client) {
+	defaultClient.Lock()
+	defer defaultClient.Unlock()
+	defaultClient.client = c
+}
+
+// copy returns a shallow copy of the client that shares the access token
+func (c *Client) copy() *Client {
+	clone := *c
+	return &clone
+}
+
+// WithoutOutput returns a copy of the client that does not print responses
+func (c *Client) WithoutOutput() *Client {
+	clone := c.copy()
+	clone.printHttpResponse = false
+	return clone
+}
+
+// WithProjectID returns a copy of the client for another project
+func (c *Client) WithProjectID(projectID string) *Client {
+	clone := c.copy()
+	clone.options.ProjectID = projectID
+	return clone
+}
+
+// WithRegion returns a copy of the client for another region
+func (c *Client) WithRegion(region string) *Client {
+	clone := c.copy()
+	clone.options.Region = region
+	return clone
+}
+
+// WithExportToFile returns a copy of the client that writes responses to files in the folder
+func (c *Client) WithExportToFile(folder string) *Client {
+	clone := c.copy()
+	clone.options.ExportToFile = folder
+	return clone
+}
+
+// WithContext returns a copy of the client that sends requests with the context
+func (c *Client) WithContext(ctx context.Context) *Client {
+	clone := c.copy()
+	clone.ctx = ctx
+	return clone
+}
@@ -0,0 +1,58 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestClientCopies(t *testing.T) {
+	c := NewClient(IntegrationClientOptions{ProjectID: "p1", Region: "us-west1", Token: "token"})
+
+	quiet := c.WithoutOutput()
+	if quiet.GetPrintHttpResponse() || !c.GetPrintHttpResponse() {
+		t.Errorf("expected only the copy to stop printing responses")
+	}
+
+	other := c.WithProjectID("p2").WithRegion("us-east1")
+	if !strings.Contains(other.GetBaseIntegrationURL(), "projects/p2/locations/us-east1") {
+		t.Errorf("unexpected url for the copy: %s", other.GetBaseIntegrationURL())
+	}
+	if !strings.Contains(c.GetBaseIntegrationURL(), "projects/p1/locations/us-west1") {
+		t.Errorf("unexpected url for the client: %s", c.GetBaseIntegrationURL())
+	}
+
+	// copies share the access token
+	other.SetIntegrationToken("refreshed")
+	if c.GetIntegrationToken() != "refreshed" {
+		t.Errorf("expected the token to be shared, got %s", c.GetIntegrationToken())
+	}
+
+	// copies can be made concurrently
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = c.WithoutOutput().WithExportToFile(t.TempDir()).GetExportToFile()
+		}()
+	}
+	wg.Wait()
+	if c.GetExportToFile() != "" {
+		t.Errorf("expected the client to be unchanged, got %s", c.GetExportToFile())
+	}
+}
@@ -31,14 +31,25 @@ const (
 )
 
 type integrationCLI struct {
-	Token     string `json:"token,omitempty"`
-	LastCheck string `json:"lastCheck,omitempty"`
-	Project   string `json:"defaultProject,omitempty"`
-	Region    string `json:"region,omitempty"`
-	ProxyUrl  string `json:"proxyUrl,omitempty"`
-	Nocheck   bool   `json:"nocheck,omitempty" default:"false"`
-	Api       API    `json:"api,omitempty" default:"prod"`
-	BasicInfo string `json:"basicInfo,omitempty" default:"false"`
+	Token                string `json:"token,omitempty"`
+	LastCheck            string `json:"lastCheck,omitempty"`
+	Project              string `json:"defaultProject,omitempty"`
+	Region               string `json:"region,omitempty"`
+	ProxyUrl             string `json:"proxyUrl,omitempty"`
+	Nocheck              bool   `json:"nocheck,omitempty" default:"false"`
+	Api                  API    `json:"api,omitempty" default:"prod"`
+	BasicInfo            string `json:"basicInfo,omitempty" default:"false"`
+	MaxAttempts          int    `json:"maxAttempts,omitempty"`
+	MaxElapsedTime       string `json:"maxElapsedTime,omitempty"`
+	IntegrationRateLimit int    `json:"integrationRateLimit,omitempty"`
+	ConnectorsRateLimit  int    `json:"connectorsRateLimit,omitempty"`
+	CABundle             string `json:"caBundle,omitempty"`
+	ClientCert           string `json:"clientCert,omitempty"`
+	ClientKey            string `json:"clientKey,omitempty"`
+	TLSServerName        string `json:"tlsServerName,omitempty"`
+
+	Endpoints    Endpoints            `json:"endpoints,omitempty"`
+	EndpointSets map[string]Endpoints `json:"endpointSets,omitempty"`
 }
 
 func readPreferencesFile() (cliPref *integrationCLI, err error) {
@@ -67,7 +78,8 @@ func readPreferencesFile() (cliPref *integrationCLI, err error) {
 	}
 
 	if cliPref.Api != "" {
-		if cliPref.Api != PROD && cliPref.Api != STAGING && cliPref.Api != AUTOPUSH {
+		if cliPref.Api != PROD && cliPref.Api != STAGING && cliPref.Api != AUTOPUSH &&
+			!endpointSetName.MatchString(string(cliPref.Api)) {
 			return cliPref, fmt.Errorf("invalid api settings in configuration file")
 		}
 	}
@@ -88,8 +100,8 @@ func DeletePreferencesFile() (err error) {
 	return os.Remove(path.Join(usr.HomeDir, integrationcliPath, integrationcliFile))
 }
 
-func writeToken(token string) (err error) {
-	if IsSkipCache() {
+func (c *Client) writeToken(token string) (err error) {
+	if c.IsSkipCache() {
 		return nil
 	}
 
@@ -185,6 +197,60 @@ func SetAPIPref(a API) (err error) {
 	return writePerferencesFile(data)
 }
 
+func SetRetryPref(maxAttempts int, maxElapsedTime string) (err error) {
+	if maxAttempts == 0 && maxElapsedTime == "" {
+		return nil
+	}
+	if maxElapsedTime != "" {
+		if _, err = time.ParseDuration(maxElapsedTime); err != nil {
+			return fmt.Errorf("invalid max elapsed time: %w", err)
+		}
+	}
+
+	clilog.Debug.Printf("Retry max attempts: %d, max elapsed time: %s\n", maxAttempts, maxElapsedTime)
+
+	cliPref, err := readPreferencesFile()
+	if maxAttempts != 0 {
+		cliPref.MaxAttempts = maxAttempts
+	}
+	if maxElapsedTime != "" {
+		cliPref.MaxElapsedTime = maxElapsedTime
+	}
+	data, err := json.Marshal(&cliPref)
+	if err != nil {
+		clilog.Debug.Printf("Error marshalling: %v\n", err)
+		return err
+	}
+	clilog.Debug.Println("Writing ", string(data))
+	return writePerferencesFile(data)
+}
+
+func SetRateLimitPref(integrationRateLimit int, connectorsRateLimit int) (err error) {
+	if integrationRateLimit == 0 && connectorsRateLimit == 0 {
+		return nil
+	}
+	if integrationRateLimit < 0 || connectorsRateLimit < 0 {
+		return fmt.Errorf("rate limits must be a positive number of requests per minute")
+	}
+
+	clilog.Debug.Printf("Rate limits integrations: %d, connectors: %d\n", integrationRateLimit, connectorsRateLimit)
+
+	cliPref, err := readPreferencesFile()
+	if integrationRateLimit != 0 {
+		cliPref.IntegrationRateLimit = integrationRateLimit
+	}
+	if connectorsRateLimit != 0 {
+		cliPref.ConnectorsRateLimit = connectorsRateLimit
+	}
+	data, err := json.Marshal(&cliPref)
+	if err != nil {
+		clilog.Debug.Printf("Error marshalling: %v\n", err)
+		return err
+	}
+	clilog.Debug.Println("Writing ", string(data))
+	return writePerferencesFile(data)
+}
+
 func TestAndUpdateLastCheck() (updated bool, err error) {
 	currentTime := time.Now()
 	currentDate := currentTime.Format("01-02-2006")
@@ -243,6 +309,87 @@ func SetProxy(url string) (err error) {
 	return writePerferencesFile(data)
 }
 
+// SetEndpointsPref sets the endpoints of the control plane APIs, or the endpoints of the named
+// set which is selected with the api. Empty values keep the current endpoints
+func SetEndpointsPref(set string, e Endpoints) (err error) {
+	if e == (Endpoints{}) {
+		return nil
+	}
+	if err = e.Validate(); err != nil {
+		return err
+	}
+	if set != "" && (!endpointSetName.MatchString(set) || set == string(PROD) ||
+		set == string(STAGING) || set == string(AUTOPUSH)) {
+		return fmt.Errorf("invalid endpoint set name %s", set)
+	}
+
+	cliPref, err := readPreferencesFile()
+	current := cliPref.Endpoints
+	if set != "" {
+		current = cliPref.EndpointSets[set]
+	}
+	if e.Integrations != "" {
+		current.Integrations = e.Integrations
+	}
+	if e.Connectors != "" {
+		current.Connectors = e.Connectors
+	}
+	if e.CustomConnectors != "" {
+		current.CustomConnectors = e.CustomConnectors
+	}
+	if e.TLSServerName != "" {
+		current.TLSServerName = e.TLSServerName
+	}
+	if set == "" {
+		cliPref.Endpoints = current
+	} else {
+		if cliPref.EndpointSets == nil {
+			cliPref.EndpointSets = map[string]Endpoints{}
+		}
+		cliPref.EndpointSets[set] = current
+	}
+
+	data, err := json.Marshal(&cliPref)
+	if err != nil {
+		clilog.Debug.Printf("Error marshalling: %v\n", err)
+		return err
+	}
+	clilog.Debug.Println("Writing ", string(data))
+	return writePerferencesFile(data)
+}
+
+// SetTLSPref sets the CA bundle, the client certificate and key, and the TLS server name
+// used to connect to the control plane. Empty values keep the current preference
+func SetTLSPref(caBundle string, clientCert string, clientKey string, tlsServerName string) (err error) {
+	if caBundle == "" && clientCert == "" && clientKey == "" && tlsServerName == "" {
+		return nil
+	}
+
+	cliPref, err := readPreferencesFile()
+	if caBundle != "" {
+		cliPref.CABundle = caBundle
+	}
+	if clientCert != "" {
+		cliPref.ClientCert = clientCert
+	}
+	if clientKey != "" {
+		cliPref.ClientKey = clientKey
+	}
+	if tlsServerName != "" {
+		cliPref.TLSServerName = tlsServerName
+	}
+	if (cliPref.ClientCert == "") != (cliPref.ClientKey == "") {
+		return fmt.Errorf("client-cert and client-key must be set together")
+	}
+	data, err := json.Marshal(&cliPref)
+	if err != nil {
+		clilog.Debug.Printf("Error marshalling: %v\n", err)
+		return err
+	}
+	clilog.Debug.Println("Writing ", string(data))
+	return writePerferencesFile(data)
+}
+
 func SetDefaultRegion(region string) (err error) {
 	if region == "" {
 		return nil
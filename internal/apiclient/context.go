@@ -0,0 +1,52 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"context"
+	"time"
+)
+
+// SetContext sets the context used by requests and pollers. Cancelling it, for example on
+// SIGINT or when the timeout expires, cancels in-flight requests
+func (c *Client) SetContext(ctx context.Context) {
+	c.ctx = ctx
+}
+
+// GetContext returns the context used by requests and pollers
+func (c *Client) GetContext() context.Context {
+	return c.ctx
+}
+
+// ContextErr returns the reason the context was cancelled, or nil if it is still active
+func (c *Client) ContextErr() error {
+	if c.ctx.Err() == nil {
+		return nil
+	}
+	return context.Cause(c.ctx)
+}
+
+// Sleep pauses for the duration and returns early with an error if the context is cancelled
+func (c *Client) Sleep(duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-c.ctx.Done():
+		return c.ContextErr()
+	}
+}
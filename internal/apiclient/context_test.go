@@ -0,0 +1,45 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSleep(t *testing.T) {
+	c := NewClient(IntegrationClientOptions{})
+
+	if err := c.Sleep(time.Millisecond); err != nil {
+		t.Fatalf("Sleep failed: %v", err)
+	}
+
+	cause := errors.New("interrupted")
+	ctx, cancel := context.WithCancelCause(context.Background())
+	c.SetContext(ctx)
+	time.AfterFunc(10*time.Millisecond, func() { cancel(cause) })
+
+	if err := c.Sleep(time.Minute); !errors.Is(err, cause) {
+		t.Errorf("expected Sleep to return the cancel cause, got %v", err)
+	}
+
+	stop := c.Every(time.Minute, func(time.Time) bool { return true })
+	<-stop
+	if err := c.ContextErr(); !errors.Is(err, cause) {
+		t.Errorf("expected ContextErr to return the cancel cause, got %v", err)
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"context"
+	"time"
+)
+
+// The functions in this file configure the default client from the command line flags.
+// They are not safe for concurrent use, code that runs concurrently takes a *Client
+
+// SetRegion sets the region of the default client
+func SetRegion(region string) error {
+	return DefaultClient().SetRegion(region)
+}
+
+// GetRegion gets the region of the default client
+func GetRegion() string {
+	return DefaultClient().GetRegion()
+}
+
+// SetProjectID sets the project id of the default client
+func SetProjectID(projectID string) error {
+	return DefaultClient().SetProjectID(projectID)
+}
+
+// GetProjectID gets the project id of the default client
+func GetProjectID() string {
+	return DefaultClient().GetProjectID()
+}
+
+// SetIntegrationToken sets the access token of the default client
+func SetIntegrationToken(token string) {
+	DefaultClient().SetIntegrationToken(token)
+}
+
+// GetIntegrationToken gets the access token of the default client
+func GetIntegrationToken() string {
+	return DefaultClient().GetIntegrationToken()
+}
+
+// SetServiceAccount sets the service account of the default client
+func SetServiceAccount(serviceAccount string) {
+	DefaultClient().SetServiceAccount(serviceAccount)
+}
+
+// SetAccessToken reads the access token of the default client from the cache or generates it
+func SetAccessToken() error {
+	return DefaultClient().SetAccessToken()
+}
+
+// GetDefaultAccessToken sets the access token of the default client from the application default credentials
+func GetDefaultAccessToken() error {
+	return DefaultClient().GetDefaultAccessToken()
+}
+
+// GetMetadataAccessToken sets the access token of the default client from the metadata server
+func GetMetadataAccessToken() error {
+	return DefaultClient().GetMetadataAccessToken()
+}
+
+// SetAPI sets the API environment of the default client
+func SetAPI(a API) {
+	DefaultClient().SetAPI(a)
+}
+
+// SetRate sets the rate limits of the default client
+func SetRate(r Rate) {
+	DefaultClient().SetRate(r)
+}
+
+// SetRecordFolder records the requests of the default client in the folder
+func SetRecordFolder(folder string) error {
+	return DefaultClient().SetRecordFolder(folder)
+}
+
+// SetReplayFolder replays the responses of the default client from the folder
+func SetReplayFolder(folder string) error {
+	return DefaultClient().SetReplayFolder(folder)
+}
+
+// CheckAPI checks the api and the endpoints of the default client
+func CheckAPI() error {
+	return DefaultClient().CheckAPI()
+}
+
+// SetAPIEndpoint overrides the scheme and host of the base URLs of the default client
+func SetAPIEndpoint(endpoint string) {
+	DefaultClient().SetAPIEndpoint(endpoint)
+}
+
+// GetAPIEndpoint
+func GetAPIEndpoint() string {
+	return DefaultClient().GetAPIEndpoint()
+}
+
+// SetOutput sets the format of the responses printed by the default client
+func SetOutput(o Output) {
+	DefaultClient().SetOutput(o)
+}
+
+// GetOutput
+func GetOutput() Output {
+	return DefaultClient().GetOutput()
+}
+
+// SetQuery sets the query applied to the responses printed by the default client
+func SetQuery(expression string) error {
+	return DefaultClient().SetQuery(expression)
+}
+
+// SetMaxAttempts sets the max attempts of the default client
+func SetMaxAttempts(maxAttempts int) {
+	DefaultClient().SetMaxAttempts(maxAttempts)
+}
+
+// SetMaxElapsedTime sets the max elapsed time of the default client
+func SetMaxElapsedTime(maxElapsedTime time.Duration) {
+	DefaultClient().SetMaxElapsedTime(maxElapsedTime)
+}
+
+// SetContext sets the context of the default client
+func SetContext(ctx context.Context) {
+	DefaultClient().SetContext(ctx)
+}
+
+// GetContext gets the context of the default client
+func GetContext() context.Context {
+	return DefaultClient().GetContext()
+}
+
+// GetCmdPrintHttpResponseSetting
+func GetCmdPrintHttpResponseSetting() bool {
+	return DefaultClient().GetPrintHttpResponse()
+}
+
+// PrettyPrint prints formatted json unless the command disabled printing responses
+func PrettyPrint(body []byte) error {
+	return DefaultClient().PrettyPrint(body)
+}
+
+// DebugEnabled
+func DebugEnabled() bool {
+	return DefaultClient().DebugEnabled()
+}
+
+// GetPrintOutput
+func GetPrintOutput() bool {
+	return DefaultClient().GetPrintOutput()
+}
+
+// GetNoOutput
+func GetNoOutput() bool {
+	return DefaultClient().GetNoOutput()
+}
+
+// GetSuppressWarning
+func GetSuppressWarning() bool {
+	return DefaultClient().GetSuppressWarning()
+}
+
+// SetMetadataToken
+func SetMetadataToken(b bool) {
+	DefaultClient().SetMetadataToken(b)
+}
+
+// GetMetadataToken
+func GetMetadataToken() bool {
+	return DefaultClient().GetMetadataToken()
+}
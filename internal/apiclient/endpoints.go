@@ -0,0 +1,187 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Endpoints replace the scheme and host of the control plane APIs, for example with a
+// Private Service Connect endpoint, a VPC-SC restricted hostname or a local emulator.
+// An endpoint can contain a path prefix and the {region} placeholder
+type Endpoints struct {
+	Integrations     string `json:"integrations,omitempty"`
+	Connectors       string `json:"connectors,omitempty"`
+	CustomConnectors string `json:"customConnectors,omitempty"` // default is the connectors endpoint
+	TLSServerName    string `json:"tlsServerName,omitempty"`    // default is the tls server name of the client
+}
+
+// environment variables that override the endpoints in the preferences
+const (
+	IntegrationsEndpointEnv     = "INTEGRATIONCLI_INTEGRATIONS_ENDPOINT"
+	ConnectorsEndpointEnv       = "INTEGRATIONCLI_CONNECTORS_ENDPOINT"
+	CustomConnectorsEndpointEnv = "INTEGRATIONCLI_CUSTOM_CONNECTORS_ENDPOINT"
+)
+
+type service uint8
+
+const (
+	integrationsService service = iota
+	connectorsService
+	customConnectorsService
+)
+
+// String returns the name of the API of the service
+func (s service) String() string {
+	if s == integrationsService {
+		return "integrations"
+	}
+	return "connectors"
+}
+
+// baseURLs tag the base URLs returned by a client and its copies with their service, so that
+// requests are rate limited, traced and verified by service whatever the endpoint
+type baseURLs struct {
+	services map[string]service
+	sync.Mutex
+}
+
+func (b *baseURLs) tag(baseURL string, s service) {
+	b.Lock()
+	defer b.Unlock()
+	b.services[strings.TrimSuffix(baseURL, "/")] = s
+}
+
+// serviceOf returns the service of the longest base URL the url starts with
+func (c *Client) serviceOf(u *url.URL) (s service, ok bool) {
+	target := u.Scheme + "://" + u.Host + u.EscapedPath()
+	longest := 0
+	c.baseURLs.Lock()
+	defer c.baseURLs.Unlock()
+	for baseURL, service := range c.baseURLs.services {
+		if len(baseURL) > longest && (target == baseURL || strings.HasPrefix(target, baseURL+"/")) {
+			s, ok, longest = service, true, len(baseURL)
+		}
+	}
+	return s, ok
+}
+
+var endpointSetName = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// SetEndpoints sets the endpoints used when the api is not an endpoint set
+func (c *Client) SetEndpoints(e Endpoints) {
+	c.options.Endpoints = e
+}
+
+// GetEndpoints returns the endpoints of the client, the environment variables take precedence
+// over the endpoint set selected by the api, which takes precedence over the default endpoints
+func (c *Client) GetEndpoints() Endpoints {
+	e := c.options.Endpoints
+	if set, ok := c.options.EndpointSets[string(c.options.Api)]; ok {
+		e = set
+	}
+	if v := os.Getenv(IntegrationsEndpointEnv); v != "" {
+		e.Integrations = v
+	}
+	if v := os.Getenv(ConnectorsEndpointEnv); v != "" {
+		e.Connectors = v
+	}
+	if v := os.Getenv(CustomConnectorsEndpointEnv); v != "" {
+		e.CustomConnectors = v
+	}
+	if e.CustomConnectors == "" {
+		e.CustomConnectors = e.Connectors
+	}
+	if e.TLSServerName == "" {
+		e.TLSServerName = c.options.TLSServerName
+	}
+	return e
+}
+
+// CheckAPI returns an error when the api is neither prod, staging, autopush nor the name
+// of an endpoint set in the preferences, or when an endpoint is not a valid url
+func (c *Client) CheckAPI() error {
+	switch a := c.options.Api; a {
+	case "", PROD, STAGING, AUTOPUSH:
+	default:
+		if _, ok := c.options.EndpointSets[string(a)]; !ok {
+			names := append([]string{string(PROD), string(STAGING), string(AUTOPUSH)}, c.endpointSetNames()...)
+			return fmt.Errorf("unknown api %s, must be one of %s", a, strings.Join(names, ", "))
+		}
+	}
+	return c.GetEndpoints().Validate()
+}
+
+func (c *Client) endpointSetNames() []string {
+	names := make([]string, 0, len(c.options.EndpointSets))
+	for name := range c.options.EndpointSets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Validate returns an error when an endpoint is not an absolute url
+func (e Endpoints) Validate() error {
+	for name, endpoint := range map[string]string{
+		"integrations": e.Integrations, "connectors": e.Connectors, "custom connectors": e.CustomConnectors,
+	} {
+		if endpoint == "" {
+			continue
+		}
+		u, err := url.Parse(strings.ReplaceAll(endpoint, "{region}", "region"))
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("invalid %s endpoint %s, it must include the scheme and host", name, endpoint)
+		}
+	}
+	return nil
+}
+
+// withEndpoint returns the base URL of the service with the scheme, host and path prefix of its
+// endpoint, and with the scheme and host of the API endpoint override
+func (c *Client) withEndpoint(s service, region string, baseURL string) string {
+	var endpoint string
+	switch e := c.GetEndpoints(); s {
+	case integrationsService:
+		endpoint = e.Integrations
+	case connectorsService:
+		endpoint = e.Connectors
+	case customConnectorsService:
+		endpoint = e.CustomConnectors
+	}
+	if endpoint != "" {
+		if e, err := url.Parse(strings.ReplaceAll(endpoint, "{region}", region)); err == nil {
+			if u, err := url.Parse(baseURL); err == nil {
+				u.Scheme = e.Scheme
+				u.Host = e.Host
+				u.Path = path.Join("/", e.Path, u.Path) + "/"
+				if !strings.HasSuffix(baseURL, "/") {
+					u.Path = strings.TrimSuffix(u.Path, "/")
+				}
+				baseURL = u.String()
+			}
+		}
+	}
+	baseURL = c.withAPIEndpoint(baseURL)
+	c.baseURLs.tag(baseURL, s)
+	return baseURL
+}
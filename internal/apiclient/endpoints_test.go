@@ -0,0 +1,118 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEndpoints(t *testing.T) {
+	o := IntegrationClientOptions{
+		ProjectID: "project",
+		Region:    "us-west1",
+		Endpoints: Endpoints{
+			Integrations: "https://integrations-{region}.p.googleapis.com",
+			Connectors:   "http://localhost:8080/emulator",
+		},
+		EndpointSets: map[string]Endpoints{
+			"vpcsc": {Integrations: "https://restricted.googleapis.com"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		api      API
+		env      map[string]string
+		actual   func(c *Client) string
+		expected string
+	}{
+		{
+			"integrations endpoint with region", PROD, nil, (*Client).GetBaseIntegrationURL,
+			"https://integrations-us-west1.p.googleapis.com/v1/projects/project/locations/us-west1/",
+		},
+		{
+			"connectors endpoint with path prefix", PROD, nil, (*Client).GetBaseConnectorURL,
+			"http://localhost:8080/emulator/v1/projects/project/locations/us-west1/connections",
+		},
+		{
+			"custom connectors default to the connectors endpoint", PROD, nil, (*Client).GetBaseCustomConnectorURL,
+			"http://localhost:8080/emulator/v1/projects/project/locations/global/customConnectors",
+		},
+		{
+			"environment variable", PROD, map[string]string{IntegrationsEndpointEnv: "https://env.example.com"},
+			(*Client).GetBaseIntegrationURL, "https://env.example.com/v1/projects/project/locations/us-west1/",
+		},
+		{
+			"endpoint set", "vpcsc", nil, (*Client).GetBaseIntegrationURL,
+			"https://restricted.googleapis.com/v1/projects/project/locations/us-west1/",
+		},
+		{
+			"endpoint set without a connectors endpoint", "vpcsc", nil, (*Client).GetBaseConnectorURL,
+			"https://connectors.googleapis.com/v1/projects/project/locations/us-west1/connections",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for k, v := range test.env {
+				t.Setenv(k, v)
+			}
+			o.Api = test.api
+			c := NewClient(o)
+			if err := c.CheckAPI(); err != nil {
+				t.Fatalf("CheckAPI failed: %v", err)
+			}
+			if actual := test.actual(c); actual != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, actual)
+			}
+		})
+	}
+
+	o.Api = "unknown"
+	if err := NewClient(o).CheckAPI(); err == nil {
+		t.Errorf("expected an error for an unknown endpoint set")
+	}
+	o.Api = PROD
+	o.Endpoints = Endpoints{Integrations: "integrations.p.googleapis.com"}
+	if err := NewClient(o).CheckAPI(); err == nil {
+		t.Errorf("expected an error for an endpoint without a scheme")
+	}
+}
+
+func TestEndpointSetPreference(t *testing.T) {
+	cliPref := &integrationCLI{}
+	if err := json.Unmarshal([]byte(`{
+		"defaultProject": "project", "region": "us-west1", "api": "vpcsc",
+		"endpointSets": {"vpcsc": {"integrations": "https://restricted.googleapis.com"}}
+	}`), cliPref); err != nil {
+		t.Fatalf("unable to parse the preferences: %v", err)
+	}
+
+	// the api of the preferences is kept when the flag is not set
+	c := newIntegrationClient(IntegrationClientOptions{}, cliPref)
+	if err := c.CheckAPI(); err != nil {
+		t.Fatalf("CheckAPI failed: %v", err)
+	}
+	expected := "https://restricted.googleapis.com/v1/projects/project/locations/us-west1/"
+	if actual := c.GetBaseIntegrationURL(); actual != expected {
+		t.Errorf("expected %s, got %s", expected, actual)
+	}
+
+	c = newIntegrationClient(IntegrationClientOptions{Api: PROD}, cliPref)
+	expected = "https://us-west1-integrations.googleapis.com/v1/projects/project/locations/us-west1/"
+	if actual := c.GetBaseIntegrationURL(); actual != expected {
+		t.Errorf("expected the flag to override the preferences, got %s", actual)
+	}
+}
@@ -16,35 +16,22 @@ package apiclient
 
 import (
 	"bytes"
-	"context"
 	"encoding/json"
 	"errors"
 	"internal/clilog"
 	"io"
 	"net/http"
-	"net/url"
 	"time"
-
-	"golang.org/x/time/rate"
 )
 
-// RateLimitedHttpClient
+// RateLimitedHttpClient limits requests by the API of the target host
 type RateLimitedHTTPClient struct {
-	client      *http.Client
-	Ratelimiter *rate.Limiter
+	client  *http.Client
+	session *Client // retry and rate settings
 }
 
-// allow 6 every 1 second (360 per min, limit is 480 per min)
-var integrationAPIRateLimit = rate.NewLimiter(rate.Every(time.Second), 6)
-
-// allow 1 every 1 second (60 per min, limit is 120 per min)
-var connectorsAPIRateLimit = rate.NewLimiter(rate.Every(time.Second), 1)
-
-// disable rate limit
-var noAPIRateLimit = rate.NewLimiter(rate.Inf, 1)
-
 // HttpClient method is used to GET,POST,PUT or DELETE JSON data
-func HttpClient(params ...string) (respBody []byte, err error) {
+func (c *Client) HttpClient(params ...string) (respBody []byte, err error) {
 	// The first parameter is url. If only one parameter is sent, assume GET
 	// The second parameter is the payload. The two parameters are sent, assume POST
 	// THe third parameter is the method. If three parameters are sent, assume method in param
@@ -52,13 +39,13 @@ func HttpClient(params ...string) (respBody []byte, err error) {
 	var req *http.Request
 	contentType := "application/json"
 
-	client, err := getHttpClient()
+	client, err := c.getHttpClient()
 	if err != nil {
 		return nil, err
 	}
 
 	clilog.Debug.Println("Connecting to: ", params[0])
-	ctx := context.Background()
+	ctx := c.GetContext()
 
 	switch paramLen := len(params); paramLen {
 	case 1:
@@ -73,11 +60,11 @@ func HttpClient(params ...string) (respBody []byte, err error) {
 		}
 		req, err = http.NewRequestWithContext(ctx, http.MethodPost, params[0], bytes.NewBuffer([]byte(params[1])))
 	case 3:
-		if req, err = getRequest(params); err != nil {
+		if req, err = c.getRequest(params); err != nil {
 			return nil, err
 		}
 	case 4:
-		if req, err = getRequest(params); err != nil {
+		if req, err = c.getRequest(params); err != nil {
 			return nil, err
 		}
 		contentType = params[3]
@@ -90,7 +77,7 @@ func HttpClient(params ...string) (respBody []byte, err error) {
 		return nil, err
 	}
 
-	req, err = setAuthHeader(req)
+	req, err = c.setAuthHeader(req)
 	if err != nil {
 		return nil, err
 	}
@@ -104,24 +91,35 @@ func HttpClient(params ...string) (respBody []byte, err error) {
 
 	resp, err := client.Do(req)
 	if err != nil {
+		if ctxErr := c.ContextErr(); ctxErr != nil {
+			return nil, ctxErr
+		}
 		clilog.Error.Println("error connecting: ", err)
 		return nil, err
 	}
 
-	return handleResponse(resp)
+	return c.handleResponse(resp)
 }
 
-// PrettyPrint method prints formatted json
-func PrettyPrint(body []byte) error {
-	if GetCmdPrintHttpResponseSetting() && ClientPrintHttpResponse.Get() {
-		var prettyJSON bytes.Buffer
-		err := json.Indent(&prettyJSON, body, "", "\t")
+// PrettyPrint method prints the response in the output format after applying the query,
+// unless printing responses is disabled for the client
+func (c *Client) PrettyPrint(body []byte) error {
+	if c.printHttpResponse {
+		response := body
+		if c.query != nil {
+			var err error
+			if response, err = c.query.Apply(body); err != nil {
+				clilog.Error.Println("error applying query: ", err)
+				return err
+			}
+		}
+		output, err := FormatOutput(response, c.GetOutput())
 		if err != nil {
 			clilog.Error.Println("error parsing response: ", err)
 			return err
 		}
 
-		clilog.HTTPResponse.Println(prettyJSON.String())
+		clilog.HTTPResponse.Println(string(output))
 	}
 	clilog.Debug.Println(string(body))
 	return nil
@@ -137,8 +135,8 @@ func PrettifyJson(body []byte) (prettyJson []byte, err error) {
 	return prettyJSON.Bytes(), err
 }
 
-func getRequest(params []string) (req *http.Request, err error) {
-	ctx := context.Background()
+func (c *Client) getRequest(params []string) (req *http.Request, err error) {
+	ctx := c.GetContext()
 	if params[2] == "DELETE" {
 		clilog.Debug.Println("Method: DELETE")
 		req, err = http.NewRequestWithContext(ctx, http.MethodDelete, params[0], nil)
@@ -160,69 +158,124 @@ func getRequest(params []string) (req *http.Request, err error) {
 	return req, err
 }
 
-func setAuthHeader(req *http.Request) (*http.Request, error) {
-	if GetIntegrationToken() == "" {
-		if err := SetAccessToken(); err != nil {
+func (c *Client) setAuthHeader(req *http.Request) (*http.Request, error) {
+	if c.GetIntegrationToken() == "" {
+		if err := c.SetAccessToken(); err != nil {
 			return nil, err
 		}
 	}
-	clilog.Debug.Println("Setting token : ", GetIntegrationToken())
-	req.Header.Add("Authorization", "Bearer "+GetIntegrationToken())
+	clilog.Debug.Println("Setting the access token in the Authorization header")
+	req.Header.Add("Authorization", "Bearer "+c.GetIntegrationToken())
 	return req, nil
 }
 
-// Do the HTTP request
+// Do the HTTP request. Transient errors are retried with exponential backoff
+// until the max attempts or the max elapsed time is reached
 func (c *RateLimitedHTTPClient) Do(req *http.Request) (*http.Response, error) {
-	ctx := context.Background()
-	// Wait until the rate is below Apigee limits
-	err := c.Ratelimiter.Wait(ctx)
-	if err != nil {
-		return nil, err
+	if c.session.IsReplay() {
+		return c.session.cassette.replayResponse(req)
 	}
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, err
+
+	s, ok := c.session.serviceOf(req.URL)
+	api := req.URL.Host
+	if ok {
+		api = s.String()
 	}
-	return resp, nil
-}
+	ctx, span := startRequestSpan(req, api)
+	req = req.WithContext(ctx)
+	start := time.Now()
+	ratelimiter := c.session.limiters.get(c.session.GetRate(), s, ok)
 
-func getHttpClient() (client *RateLimitedHTTPClient, err error) {
-	var apiRateLimit *rate.Limiter
+	for attempt := 1; ; attempt++ {
+		// Wait until the rate is below the API limits
+		_, endWait := StartSpan(ctx, "rate limiter wait", "")
+		err := ratelimiter.Wait(ctx)
+		endWait(err)
+		if err != nil {
+			endRequestSpan(span, nil, attempt, err)
+			return nil, err
+		}
+		if attempt > 1 && req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				endRequestSpan(span, nil, attempt, err)
+				return nil, err
+			}
+		}
 
-	switch r := GetRate(); r {
-	case IntegrationAPI:
-		apiRateLimit = integrationAPIRateLimit
-	case ConnectorsAPI:
-		apiRateLimit = connectorsAPIRateLimit
-	case None:
-		apiRateLimit = noAPIRateLimit
-	default:
-		apiRateLimit = noAPIRateLimit
+		sent := time.Now()
+		resp, err := c.send(req)
+		var retry bool
+		if err != nil {
+			retry = isRetryableError(req.Method, err)
+		} else {
+			clilog.HTTPStatus(req.Method, req.URL.String(), resp.StatusCode, time.Since(sent))
+			ratelimiter.adapt(resp.StatusCode)
+			retry = isRetryableStatus(req.Method, resp.StatusCode)
+		}
+		if !retry || attempt >= c.session.GetMaxAttempts() {
+			endRequestSpan(span, resp, attempt, err)
+			return resp, err
+		}
+
+		backoff := getBackoff(attempt, resp)
+		if time.Since(start)+backoff > c.session.GetMaxElapsedTime() {
+			endRequestSpan(span, resp, attempt, err)
+			return resp, err
+		}
+		if err != nil {
+			clilog.Debug.Printf("attempt %d of %s %s failed: %v, retrying in %s\n",
+				attempt, req.Method, req.URL, err, backoff)
+		} else {
+			clilog.Debug.Printf("attempt %d of %s %s failed with status code %d, retrying in %s\n",
+				attempt, req.Method, req.URL, resp.StatusCode, backoff)
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			endRequestSpan(span, nil, attempt, ctx.Err())
+			return nil, ctx.Err()
+		}
 	}
+}
 
-	if GetProxyURL() != "" {
-		if proxyUrl, err := url.Parse(GetProxyURL()); err != nil {
-			integrationCLIAPIClient := &RateLimitedHTTPClient{
-				client: &http.Client{
-					Transport: &http.Transport{
-						Proxy: http.ProxyURL(proxyUrl),
-					},
-				},
-				Ratelimiter: apiRateLimit,
-			}
-			return integrationCLIAPIClient, err
+// send sends the request and records the response when recording is enabled
+func (c *RateLimitedHTTPClient) send(req *http.Request) (*http.Response, error) {
+	resp, err := c.client.Do(req)
+	if err == nil && c.session.cassette != nil {
+		if recordErr := c.session.cassette.record(req, resp); recordErr != nil {
+			clilog.Warning.Printf("unable to record %s %s: %v\n", req.Method, req.URL.Path, recordErr)
 		}
+	}
+	return resp, err
+}
+
+func (c *Client) getHttpClient() (client *RateLimitedHTTPClient, err error) {
+	httpClient, err := c.newHTTPClient(true)
+	if err != nil {
 		return nil, err
-	} else {
-		integrationCLIAPIClient := &RateLimitedHTTPClient{
-			client:      http.DefaultClient,
-			Ratelimiter: apiRateLimit,
-		}
-		return integrationCLIAPIClient, nil
 	}
+	return &RateLimitedHTTPClient{
+		client:  httpClient,
+		session: c,
+	}, nil
+}
+
+// newHTTPClient returns an http client with the proxy and TLS options of the client. The TLS
+// server name of the endpoints is only verified for the control plane, not for the Google OAuth endpoints
+func (c *Client) newHTTPClient(controlPlane bool) (*http.Client, error) {
+	transport, err := c.getTransport("")
+	if err != nil {
+		return nil, err
+	}
+	if !controlPlane {
+		return &http.Client{Transport: transport}, nil
+	}
+	return &http.Client{Transport: &endpointTransport{client: c, transport: transport}}, nil
 }
 
-func handleResponse(resp *http.Response) (respBody []byte, err error) {
+func (c *Client) handleResponse(resp *http.Response) (respBody []byte, err error) {
 	if resp != nil {
 		defer resp.Body.Close()
 	}
@@ -237,7 +290,7 @@ func handleResponse(resp *http.Response) (respBody []byte, err error) {
 		clilog.Error.Printf("error in response: %v\n", err)
 		return nil, err
 	} else if resp.StatusCode > 399 {
-		if GetConflictsAsErrors() && resp.StatusCode == http.StatusConflict {
+		if c.GetConflictsAsErrors() && resp.StatusCode == http.StatusConflict {
 			clilog.Warning.Printf("entity already exists, ignoring conflict")
 			return respBody, nil
 		}
@@ -246,7 +299,7 @@ func handleResponse(resp *http.Response) (respBody []byte, err error) {
 		return nil, errors.New(getErrorMessage(resp.StatusCode) + ": " + string(respBody))
 	}
 
-	return respBody, PrettyPrint(respBody)
+	return respBody, c.PrettyPrint(respBody)
 }
 
 func getErrorMessage(statusCode int) string {
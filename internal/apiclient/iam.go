@@ -52,7 +52,7 @@ type setIamPolicy struct {
 	Policy iamPolicy `json:"policy,omitempty"`
 }
 
-func iamServiceAccountExists(iamname string) (code int, err error) {
+func (c *Client) iamServiceAccountExists(iamname string) (code int, err error) {
 	var resp *http.Response
 	var req *http.Request
 
@@ -65,7 +65,7 @@ func iamServiceAccountExists(iamname string) (code int, err error) {
 	getendpoint := fmt.Sprintf("https://iam.googleapis.com/v1/projects/%s/serviceAccounts/%s", projectid, iamname)
 	contentType := "application/json"
 
-	client, err := getHttpClient()
+	client, err := c.getHttpClient()
 	if err != nil {
 		clilog.Error.Println(err)
 		return -1, err
@@ -75,13 +75,13 @@ func iamServiceAccountExists(iamname string) (code int, err error) {
 		return 200, nil
 	}
 
-	req, err = http.NewRequest("GET", getendpoint, nil)
+	req, err = http.NewRequestWithContext(c.GetContext(), http.MethodGet, getendpoint, nil)
 	if err != nil {
 		clilog.Error.Println("error in client: ", err)
 		return -1, err
 	}
 
-	req, err = setAuthHeader(req)
+	req, err = c.setAuthHeader(req)
 	if err != nil {
 		clilog.Error.Println(err)
 		return -1, err
@@ -117,13 +117,11 @@ func iamServiceAccountExists(iamname string) (code int, err error) {
 }
 
 // setIAMPermission set permissions for a member
-func setIAMPermission(endpoint string, name string, memberName string, role string, memberType string) (err error) {
+func (c *Client) setIAMPermission(endpoint string, name string, memberName string, role string, memberType string) (err error) {
 	u, _ := url.Parse(endpoint)
 	u.Path = path.Join(u.Path, name+":getIamPolicy")
 
-	ClientPrintHttpResponse.Set(false)
-	getIamPolicyBody, err := HttpClient(u.String())
-	ClientPrintHttpResponse.Set(GetCmdPrintHttpResponseSetting())
+	getIamPolicyBody, err := c.WithoutOutput().HttpClient(u.String())
 	if err != nil {
 		clilog.Error.Println(err)
 		return err
@@ -166,24 +164,20 @@ func setIAMPermission(endpoint string, name string, memberName string, role stri
 		return err
 	}
 
-	ClientPrintHttpResponse.Set(false)
-	_, err = HttpClient(u.String(), string(setIamPolicyBody))
-	ClientPrintHttpResponse.Set(GetCmdPrintHttpResponseSetting())
+	_, err = c.WithoutOutput().HttpClient(u.String(), string(setIamPolicyBody))
 
 	return err
 }
 
 // setProjectIAMPermission
-func setProjectIAMPermission(project string, memberName string, role string) (err error) {
+func (c *Client) setProjectIAMPermission(project string, memberName string, role string) (err error) {
 	getendpoint := fmt.Sprintf("https://cloudresourcemanager.googleapis.com/v1/projects/%s:getIamPolicy", project)
 	setendpoint := fmt.Sprintf("https://cloudresourcemanager.googleapis.com/v1/projects/%s:setIamPolicy", project)
 
 	// this method treats errors as info since this is not a blocking problem
 
-	ClientPrintHttpResponse.Set(false)
-
 	// Get the current IAM policies for the project
-	respBody, err := HttpClient(getendpoint, "")
+	respBody, err := c.WithoutOutput().HttpClient(getendpoint, "")
 	if err != nil {
 		clilog.Debug.Printf("error getting IAM policies for the project %s: %v", project, err)
 		return err
@@ -230,18 +224,17 @@ func setProjectIAMPermission(project string, memberName string, role string) (er
 		return err
 	}
 
-	_, err = HttpClient(setendpoint, string(policyRequestBody))
+	_, err = c.WithoutOutput().HttpClient(setendpoint, string(policyRequestBody))
 	if err != nil {
 		clilog.Debug.Printf("error setting IAM policies for the project %s: %v", project, err)
 		return err
 	}
 
-	ClientPrintHttpResponse.Set(GetCmdPrintHttpResponseSetting())
 	return nil
 }
 
 // CreateServiceAccount
-func CreateServiceAccount(iamname string) (err error) {
+func (c *Client) CreateServiceAccount(iamname string) (err error) {
 	var statusCode int
 
 	projectid, displayname, err := getNameAndProject(iamname)
@@ -249,7 +242,7 @@ func CreateServiceAccount(iamname string) (err error) {
 		return err
 	}
 
-	if statusCode, err = iamServiceAccountExists(iamname); err != nil {
+	if statusCode, err = c.iamServiceAccountExists(iamname); err != nil {
 		return err
 	}
 
@@ -262,9 +255,7 @@ func CreateServiceAccount(iamname string) (err error) {
 		iamPayload = append(iamPayload, "\"accountId\":\""+displayname+"\"")
 		iamPayload = append(iamPayload, "\"serviceAccount\": {\"displayName\": \""+displayname+"\"}")
 		payload := "{" + strings.Join(iamPayload, ",") + "}"
-		ClientPrintHttpResponse.Set(false)
-		defer ClientPrintHttpResponse.Set(GetCmdPrintHttpResponseSetting())
-		if _, err = HttpClient(createendpoint, payload); err != nil {
+		if _, err = c.WithoutOutput().HttpClient(createendpoint, payload); err != nil {
 			clilog.Error.Println(err)
 			return err
 		}
@@ -275,7 +266,7 @@ func CreateServiceAccount(iamname string) (err error) {
 }
 
 // SetConnectorIAMPermission set permissions for a member on a connection
-func SetConnectorIAMPermission(name string, memberName string, iamRole string, memberType string) (err error) {
+func (c *Client) SetConnectorIAMPermission(name string, memberName string, iamRole string, memberType string) (err error) {
 	var role string
 
 	switch iamRole {
@@ -294,40 +285,37 @@ func SetConnectorIAMPermission(name string, memberName string, iamRole string, m
 		role = iamRole
 	}
 
-	return setIAMPermission(GetBaseConnectorURL(), name, memberName, role, memberType)
+	return c.setIAMPermission(c.GetBaseConnectorURL(), name, memberName, role, memberType)
 }
 
 // SetPubSubIAMPermission set permissions for a SA on a topic
-func SetPubSubIAMPermission(project string, topic string, memberName string) (err error) {
+func (c *Client) SetPubSubIAMPermission(project string, topic string, memberName string) (err error) {
 	endpoint := fmt.Sprintf("https://pubsub.googleapis.com/v1/projects/%s/topics", project)
 	const memberType = "serviceAccount"
 	const role = "roles/pubsub.publisher"
-	return setIAMPermission(endpoint, topic, memberName, role, memberType)
+	return c.setIAMPermission(endpoint, topic, memberName, role, memberType)
 }
 
 // SetSecretManagerIAMPermission set permissions for a SA on a secret
-func SetSecretManagerIAMPermission(project string, secretName string, memberName string) (err error) {
+func (c *Client) SetSecretManagerIAMPermission(project string, secretName string, memberName string) (err error) {
 	endpoint := fmt.Sprintf("https://secretmanager.googleapis.com/v1/projects/%s/secrets", project)
 	const memberType = "serviceAccount"
 	const role1 = "roles/secretmanager.secretAccessor"
 	const role2 = "roles/secretmanager.viewer"
-	if err = setIAMPermission(endpoint, secretName, memberName, role1, memberType); err != nil {
+	if err = c.setIAMPermission(endpoint, secretName, memberName, role1, memberType); err != nil {
 		return err
 	}
-	return setIAMPermission(endpoint, secretName, memberName, role2, memberType)
+	return c.setIAMPermission(endpoint, secretName, memberName, role2, memberType)
 }
 
 // SetBigQueryIAMPermission
-func SetBigQueryIAMPermission(project string, datasetid string, memberName string) (err error) {
+func (c *Client) SetBigQueryIAMPermission(project string, datasetid string, memberName string) (err error) {
 	endpoint := fmt.Sprintf("https://bigquery.googleapis.com/bigquery/v2/projects/%s/datasets/%s", project, datasetid)
 	const role = "WRITER"
 	var content []byte
 
-	defer ClientPrintHttpResponse.Set(GetCmdPrintHttpResponseSetting())
-	ClientPrintHttpResponse.Set(false)
-
 	// first fetch the information
-	respBody, err := HttpClient(endpoint)
+	respBody, err := c.WithoutOutput().HttpClient(endpoint)
 	if err != nil {
 		return err
 	}
@@ -362,7 +350,7 @@ func SetBigQueryIAMPermission(project string, datasetid string, memberName strin
 	}
 
 	// patch the update
-	if _, err = HttpClient(endpoint, string(content), "PATCH"); err != nil {
+	if _, err = c.WithoutOutput().HttpClient(endpoint, string(content), "PATCH"); err != nil {
 		return err
 	}
 
@@ -370,29 +358,29 @@ func SetBigQueryIAMPermission(project string, datasetid string, memberName strin
 }
 
 // SetCloudStorageIAMPermission
-func SetCloudStorageIAMPermission(project string, memberName string) (err error) {
+func (c *Client) SetCloudStorageIAMPermission(project string, memberName string) (err error) {
 	// the connector currently requires storage.buckets.list. other built-in roles didn't have this permission
 	const role = "roles/storage.admin"
 
-	return setProjectIAMPermission(project, memberName, role)
+	return c.setProjectIAMPermission(project, memberName, role)
 }
 
 // SetCloudSQLIAMPermission
-func SetCloudSQLIAMPermission(project string, memberName string) (err error) {
+func (c *Client) SetCloudSQLIAMPermission(project string, memberName string) (err error) {
 	const role = "roles/cloudsql.editor"
-	return setProjectIAMPermission(project, memberName, role)
+	return c.setProjectIAMPermission(project, memberName, role)
 }
 
 // SetCloudSpannerIAMPermission
-func SetCloudSpannerIAMPermission(project string, memberName string) (err error) {
+func (c *Client) SetCloudSpannerIAMPermission(project string, memberName string) (err error) {
 	const role = "roles/spanner.databaseUser"
-	return setProjectIAMPermission(project, memberName, role)
+	return c.setProjectIAMPermission(project, memberName, role)
 }
 
 // SetIntegrationInvokerPermission
-func SetIntegrationInvokerPermission(project string, memberName string) (err error) {
+func (c *Client) SetIntegrationInvokerPermission(project string, memberName string) (err error) {
 	const role = "roles/integrations.integrationInvoker"
-	return setProjectIAMPermission(project, memberName, role)
+	return c.setProjectIAMPermission(project, memberName, role)
 }
 
 func getNameAndProject(iamFullName string) (projectid string, name string, err error) {
@@ -415,14 +403,12 @@ func getNameAndProject(iamFullName string) (projectid string, name string, err e
 }
 
 // GetDefaultServiceAccount
-func GetComputeEngineDefaultServiceAccount(projectId string) (serviceAccount string, err error) {
+func (c *Client) GetComputeEngineDefaultServiceAccount(projectId string) (serviceAccount string, err error) {
 	getendpoint := fmt.Sprintf("https://cloudresourcemanager.googleapis.com/v3/projects/%s", projectId)
 
 	// Get the project number
-	ClientPrintHttpResponse.Set(false)
-	defer ClientPrintHttpResponse.Set(GetCmdPrintHttpResponseSetting())
 
-	respBody, err := HttpClient(getendpoint)
+	respBody, err := c.WithoutOutput().HttpClient(getendpoint)
 	if err != nil {
 		clilog.Debug.Printf("error getting details for the project %s: %v", projectId, err)
 		return serviceAccount, err
@@ -18,18 +18,24 @@ import (
 	"time"
 )
 
-func Every(duration time.Duration, work func(time.Time) bool) chan bool {
+// Every runs work at every interval until it returns false or the context is cancelled.
+// Callers should check ContextErr after the stop channel is signalled
+func (c *Client) Every(duration time.Duration, work func(time.Time) bool) chan bool {
 	ticker := time.NewTicker(duration)
 	stop := make(chan bool, 1)
+	done := c.GetContext().Done()
 
 	go func() {
+		defer ticker.Stop()
 		for {
 			select {
 			case time := <-ticker.C:
 				if !work(time) {
 					stop <- true
+					return
 				}
-			case <-stop:
+			case <-done:
+				stop <- true
 				return
 			}
 		}
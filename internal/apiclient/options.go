@@ -17,9 +17,10 @@ package apiclient
 import (
 	"fmt"
 	"internal/clilog"
+	"net/url"
 	"os"
 	"strings"
-	"sync"
+	"time"
 )
 
 // BaseURL is the Integration control plane endpoint
@@ -52,24 +53,34 @@ const (
 
 // IntegrationClientOptions is the base struct to hold all command arguments
 type IntegrationClientOptions struct {
-	Api                API    // integrationcli can switch between prod, autopush and staging
-	Region             string // Integration region
-	Token              string // Google OAuth access token
-	ServiceAccount     string // Google service account json
-	ProjectID          string // GCP Project ID
-	DebugLog           bool   // Enable debug logs
-	TokenCheck         bool   // skip checking access token expiry
-	SkipCache          bool   // skip writing access token to file
-	PrintOutput        bool   // prints output from http calls
-	NoOutput           bool   // Disable all statements to stdout
-	SuppressWarnings   bool   // Disable printing of warnings to stdout
-	ProxyUrl           string // use a proxy url
-	MetadataToken      bool   // use metadata outh2 token
-	ExportToFile       string // determine of the contents should be written to file
-	ConflictsAreErrors bool   // treat statusconflict as an error
-}
-
-var options *IntegrationClientOptions
+	Api                API           // integrationcli can switch between prod, autopush and staging
+	Region             string        // Integration region
+	Token              string        // Google OAuth access token
+	ServiceAccount     string        // Google service account json
+	ProjectID          string        // GCP Project ID
+	DebugLog           bool          // Enable debug logs
+	TokenCheck         bool          // skip checking access token expiry
+	SkipCache          bool          // skip writing access token to file
+	PrintOutput        bool          // prints output from http calls
+	NoOutput           bool          // Disable all statements to stdout
+	SuppressWarnings   bool          // Disable printing of warnings to stdout
+	ProxyUrl           string        // use a proxy url
+	MetadataToken      bool          // use metadata outh2 token
+	ExportToFile       string        // determine of the contents should be written to file
+	ConflictsAreErrors bool          // treat statusconflict as an error
+	MaxAttempts        int           // max attempts for requests that fail with transient errors
+	MaxElapsedTime     time.Duration // max time spent retrying a request
+	Output             Output        // format of the printed responses, default is json
+	CABundle           string        // file with additional CA certificates to trust
+	ClientCert         string        // file with the client certificate presented for mTLS
+	ClientKey          string        // file with the private key of the client certificate
+	TLSServerName      string        // server name verified in the certificates of custom endpoints
+
+	// endpoints of the control plane APIs, default is the Google APIs. The endpoint sets are
+	// selected by their name with the api
+	Endpoints    Endpoints
+	EndpointSets map[string]Endpoints
+}
 
 type Rate uint8
 
@@ -77,6 +88,7 @@ const (
 	None Rate = iota
 	IntegrationAPI
 	ConnectorsAPI
+	Automatic // choose the limit by the API of each request
 )
 
 type API string
@@ -87,83 +99,110 @@ const (
 	AUTOPUSH API = "autopush"
 )
 
-var apiRate Rate
-
-var cmdPrintHttpResponses = true
-
-type clientPrintHttpResponse struct {
-	enable bool
-	sync.Mutex
-}
-
-var ClientPrintHttpResponse = &clientPrintHttpResponse{enable: true}
-
 var cliVersion, cliCommitSha, cliBuildDate string
 
-// NewIntegrationClient sets up options to invoke Integration APIs
+// NewIntegrationClient sets up the options of the default client used by the commands.
+// The preferences file provides the defaults for the options that are not set
 func NewIntegrationClient(o IntegrationClientOptions) {
-	if options == nil {
-		options = new(IntegrationClientOptions)
-	}
-
-	options.TokenCheck = o.TokenCheck
-	options.SkipCache = o.SkipCache
-	options.DebugLog = o.DebugLog
-	options.PrintOutput = o.PrintOutput
-	options.NoOutput = o.NoOutput
-	options.SuppressWarnings = o.SuppressWarnings
-
 	// initialize logs
-	clilog.Init(options.DebugLog, options.PrintOutput, options.NoOutput, options.SuppressWarnings)
+	clilog.Init(o.DebugLog, o.PrintOutput, o.NoOutput, o.SuppressWarnings)
 
 	cliPref, err := readPreferencesFile()
 	if err != nil {
 		clilog.Debug.Println(err)
 	}
+	setDefaultClient(newIntegrationClient(o, cliPref))
+}
+
+// newIntegrationClient returns a client with the options, the preferences are used
+// for the options that are not set
+func newIntegrationClient(o IntegrationClientOptions, cliPref *integrationCLI) *Client {
+	c := newClient()
+	c.options.TokenCheck = o.TokenCheck
+	c.options.SkipCache = o.SkipCache
+	c.options.DebugLog = o.DebugLog
+	c.options.PrintOutput = o.PrintOutput
+	c.options.NoOutput = o.NoOutput
+	c.options.SuppressWarnings = o.SuppressWarnings
+	c.options.Output = o.Output
 
 	if cliPref != nil {
-		options.ProjectID = cliPref.Project
-		options.Region = cliPref.Region
-		options.ProxyUrl = cliPref.ProxyUrl
-		options.Token = cliPref.Token
-		options.TokenCheck = cliPref.Nocheck
+		c.options.ProjectID = cliPref.Project
+		c.options.Region = cliPref.Region
+		c.options.ProxyUrl = cliPref.ProxyUrl
+		c.options.CABundle = cliPref.CABundle
+		c.options.ClientCert = cliPref.ClientCert
+		c.options.ClientKey = cliPref.ClientKey
+		c.options.TLSServerName = cliPref.TLSServerName
+		c.options.Endpoints = cliPref.Endpoints
+		c.options.EndpointSets = cliPref.EndpointSets
+		c.SetIntegrationToken(cliPref.Token)
+		c.options.TokenCheck = cliPref.Nocheck
 		if cliPref.Api != "" {
-			options.Api = cliPref.Api
+			c.options.Api = cliPref.Api
+		}
+		if cliPref.MaxAttempts > 0 {
+			c.options.MaxAttempts = cliPref.MaxAttempts
 		}
+		if maxElapsedTime, err := time.ParseDuration(cliPref.MaxElapsedTime); err == nil && maxElapsedTime > 0 {
+			c.options.MaxElapsedTime = maxElapsedTime
+		}
+		c.SetRateLimits(cliPref.IntegrationRateLimit, cliPref.ConnectorsRateLimit)
 	}
 
 	if o.Region != "" {
-		options.Region = o.Region
+		c.options.Region = o.Region
 	}
 	if o.Token != "" {
-		options.Token = o.Token
+		c.SetIntegrationToken(o.Token)
 	}
 	if o.ServiceAccount != "" {
-		options.ServiceAccount = o.ServiceAccount
+		c.options.ServiceAccount = o.ServiceAccount
 	}
 	if o.ProjectID != "" {
-		options.ProjectID = o.ProjectID
+		c.options.ProjectID = o.ProjectID
 	}
 	if o.ExportToFile != "" {
-		options.ExportToFile = o.ExportToFile
+		c.options.ExportToFile = o.ExportToFile
+	}
+	if o.Api != "" {
+		c.options.Api = o.Api
+	}
+	if o.MaxAttempts > 0 {
+		c.options.MaxAttempts = o.MaxAttempts
+	}
+	if o.MaxElapsedTime > 0 {
+		c.options.MaxElapsedTime = o.MaxElapsedTime
+	}
+	if o.CABundle != "" {
+		c.options.CABundle = o.CABundle
+	}
+	if o.ClientCert != "" {
+		c.options.ClientCert = o.ClientCert
 	}
-	if o.Api == "" {
-		options.Api = o.Api
+	if o.ClientKey != "" {
+		c.options.ClientKey = o.ClientKey
+	}
+	if o.TLSServerName != "" {
+		c.options.TLSServerName = o.TLSServerName
 	}
 
-	options.ConflictsAreErrors = true
+	c.options.ConflictsAreErrors = true
+	return c
 }
 
 func (a *API) String() string {
 	return string(*a)
 }
 
+// Set accepts the built-in apis and the names of endpoint sets, which are checked
+// against the preferences when the command runs
 func (a *API) Set(r string) error {
-	switch r {
-	case "prod", "staging", "autopush":
+	switch {
+	case r == "prod", r == "staging", r == "autopush", endpointSetName.MatchString(r):
 		*a = API(r)
 	default:
-		return fmt.Errorf("must be one of %s,%s or %s", PROD, STAGING, AUTOPUSH)
+		return fmt.Errorf("must be one of %s,%s,%s or the name of an endpoint set", PROD, STAGING, AUTOPUSH)
 	}
 	return nil
 }
@@ -173,260 +212,275 @@ func (a *API) Type() string {
 }
 
 // SetRegion sets the org variable
-func SetRegion(region string) (err error) {
+func (c *Client) SetRegion(region string) (err error) {
 	if region == "" {
-		if GetRegion() == "" {
+		if c.GetRegion() == "" {
 			return fmt.Errorf("region was not set in preferences or supplied in the command")
 		}
 		return nil
 	}
-	options.Region = region
+	c.options.Region = region
 	return nil
 }
 
 // GetRegion gets the org variable
-func GetRegion() string {
-	return options.Region
+func (c *Client) GetRegion() string {
+	return c.options.Region
 }
 
 // SetIntegrationToken sets the access token for use with Integration API calls
-func SetIntegrationToken(token string) {
-	options.Token = token
+func (c *Client) SetIntegrationToken(token string) {
+	c.token.Lock()
+	defer c.token.Unlock()
+	c.token.value = token
+	clilog.AddSecret(token)
 }
 
 // GetIntegrationToken get the access token value in client opts (does not generate it)
-func GetIntegrationToken() string {
-	return options.Token
+func (c *Client) GetIntegrationToken() string {
+	c.token.Lock()
+	defer c.token.Unlock()
+	return c.token.value
 }
 
 // SetProjectID sets the project id
-func SetProjectID(projectID string) (err error) {
+func (c *Client) SetProjectID(projectID string) (err error) {
 	if projectID == "" {
-		if GetProjectID() == "" {
+		if c.GetProjectID() == "" {
 			return fmt.Errorf("projectId was not set in preferences or supplied in the command")
 		}
 		return nil
 	}
-	options.ProjectID = projectID
+	c.options.ProjectID = projectID
 	return nil
 }
 
 // GetProjectID gets the project id
-func GetProjectID() string {
-	return options.ProjectID
+func (c *Client) GetProjectID() string {
+	return c.options.ProjectID
 }
 
 // SetServiceAccount
-func SetServiceAccount(serviceAccount string) {
-	options.ServiceAccount = serviceAccount
+func (c *Client) SetServiceAccount(serviceAccount string) {
+	c.options.ServiceAccount = serviceAccount
 }
 
 // GetServiceAccount
-func GetServiceAccount() string {
-	if options.ServiceAccount == "" {
+func (c *Client) GetServiceAccount() string {
+	if c.options.ServiceAccount == "" {
 		envVar := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")
 		if envVar != "" {
-			options.ServiceAccount = envVar
+			c.options.ServiceAccount = envVar
 		}
 	}
-	return options.ServiceAccount
+	return c.options.ServiceAccount
 }
 
 // TokenCheckEnabled
-func TokenCheckEnabled() bool {
-	return options.TokenCheck
+func (c *Client) TokenCheckEnabled() bool {
+	return c.options.TokenCheck
 }
 
 // IsSkipCache
-func IsSkipCache() bool {
-	return options.SkipCache
+func (c *Client) IsSkipCache() bool {
+	return c.options.SkipCache
 }
 
 // DebugEnabled
-func DebugEnabled() bool {
-	return options.DebugLog
+func (c *Client) DebugEnabled() bool {
+	return c.options.DebugLog
 }
 
 // PrintOutput
-func SetPrintOutput(output bool) {
-	options.PrintOutput = output
+func (c *Client) SetPrintOutput(output bool) {
+	c.options.PrintOutput = output
 }
 
 // GetPrintOutput
-func GetPrintOutput() bool {
-	return options.PrintOutput
+func (c *Client) GetPrintOutput() bool {
+	return c.options.PrintOutput
 }
 
-// DisableCmdPrintHttpResponse
-func DisableCmdPrintHttpResponse() {
-	cmdPrintHttpResponses = false
+// SetPrintHttpResponse enables or disables printing the responses of the client
+func (c *Client) SetPrintHttpResponse(b bool) {
+	c.printHttpResponse = b
 }
 
-// EnableCmdPrintHttpResponse
-func EnableCmdPrintHttpResponse() {
-	cmdPrintHttpResponses = true
+// GetPrintHttpResponse returns true if the responses of the client are printed
+func (c *Client) GetPrintHttpResponse() bool {
+	return c.printHttpResponse
 }
 
-// GetCmdPrintHttpResponseSetting
-func GetCmdPrintHttpResponseSetting() bool {
-	return cmdPrintHttpResponses
+// GetProxyURL
+func (c *Client) GetProxyURL() string {
+	return c.options.ProxyUrl
 }
 
-// SetClientPrintHttpResponse
-func (c *clientPrintHttpResponse) Set(b bool) {
-	c.Lock()
-	defer c.Unlock()
-	c.enable = b
+// SetProxyURL
+func (c *Client) SetProxyURL(proxyurl string) {
+	c.options.ProxyUrl = proxyurl
 }
 
-// GetPrintHttpResponseSetting
-func (c *clientPrintHttpResponse) Get() bool {
-	c.Lock()
-	defer c.Unlock()
-	return c.enable
+// SetAPIEndpoint overrides the scheme and host of the integrations and connectors base URLs.
+// An empty endpoint restores the Google APIs
+func (c *Client) SetAPIEndpoint(endpoint string) {
+	c.apiEndpoint = endpoint
 }
 
-// GetProxyURL
-func GetProxyURL() string {
-	return options.ProxyUrl
+// GetAPIEndpoint
+func (c *Client) GetAPIEndpoint() string {
+	return c.apiEndpoint
 }
 
-// SetProxyURL
-func SetProxyURL(proxyurl string) {
-	options.ProxyUrl = proxyurl
+// withAPIEndpoint returns the base URL with the scheme and host of the API endpoint override
+func (c *Client) withAPIEndpoint(baseURL string) string {
+	if c.apiEndpoint == "" {
+		return baseURL
+	}
+	endpoint, err := url.Parse(c.apiEndpoint)
+	if err != nil {
+		return baseURL
+	}
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return baseURL
+	}
+	u.Scheme = endpoint.Scheme
+	u.Host = endpoint.Host
+	return u.String()
 }
 
 // GetBaseIntegrationURL
-func GetBaseIntegrationURL() (integrationUrl string) {
-	if options.ProjectID == "" || options.Region == "" {
+func (c *Client) GetBaseIntegrationURL() (integrationUrl string) {
+	if c.options.ProjectID == "" || c.options.Region == "" {
 		return ""
 	}
-	switch options.Api {
+	switch c.options.Api {
 	case PROD:
-		return fmt.Sprintf(appIntegrationBaseURL, GetRegion(), GetProjectID(), GetRegion())
+		return c.withEndpoint(integrationsService, c.GetRegion(), fmt.Sprintf(appIntegrationBaseURL, c.GetRegion(), c.GetProjectID(), c.GetRegion()))
 	case STAGING:
 		// the url for staging is like:
 		// https://stagingqualuswest1-integrations.sandbox.googleapis.com/v1/projects/-/locations/us-west1/integrations
-		return fmt.Sprintf(appIntegrationStagingBaseURL, strings.Replace(GetRegion(), "-", "", -1), GetProjectID(), GetRegion())
+		return c.withEndpoint(integrationsService, c.GetRegion(), fmt.Sprintf(appIntegrationStagingBaseURL, strings.Replace(c.GetRegion(), "-", "", -1), c.GetProjectID(), c.GetRegion()))
 	case AUTOPUSH:
 		// the url for autopush is like:
 		// https://autopushqualuswest1-integrations.sandbox.googleapis.com/v1/projects/-/locations/us-west1/integrations
-		return fmt.Sprintf(appIntegrationAutoPushBaseURL, strings.Replace(GetRegion(), "-", "", -1), GetProjectID(), GetRegion())
+		return c.withEndpoint(integrationsService, c.GetRegion(), fmt.Sprintf(appIntegrationAutoPushBaseURL, strings.Replace(c.GetRegion(), "-", "", -1), c.GetProjectID(), c.GetRegion()))
 	default:
-		return fmt.Sprintf(appIntegrationBaseURL, GetRegion(), GetProjectID(), GetRegion())
+		return c.withEndpoint(integrationsService, c.GetRegion(), fmt.Sprintf(appIntegrationBaseURL, c.GetRegion(), c.GetProjectID(), c.GetRegion()))
 	}
 }
 
 // GetBaseConnectorURL
-func GetBaseConnectorURL() (connectorUrl string) {
-	if options.ProjectID == "" || options.Region == "" {
+func (c *Client) GetBaseConnectorURL() (connectorUrl string) {
+	if c.options.ProjectID == "" || c.options.Region == "" {
 		return ""
 	}
-	switch options.Api {
+	switch c.options.Api {
 	case PROD:
-		return fmt.Sprintf(connectorBaseURL, GetProjectID(), GetRegion())
+		return c.withEndpoint(connectorsService, c.GetRegion(), fmt.Sprintf(connectorBaseURL, c.GetProjectID(), c.GetRegion()))
 	case STAGING:
-		return fmt.Sprintf(connectorStagingBaseURL, GetProjectID(), GetRegion())
+		return c.withEndpoint(connectorsService, c.GetRegion(), fmt.Sprintf(connectorStagingBaseURL, c.GetProjectID(), c.GetRegion()))
 	case AUTOPUSH:
-		return fmt.Sprintf(connectorAutoPushBaseURL, GetProjectID(), GetRegion())
+		return c.withEndpoint(connectorsService, c.GetRegion(), fmt.Sprintf(connectorAutoPushBaseURL, c.GetProjectID(), c.GetRegion()))
 	default:
-		return fmt.Sprintf(connectorBaseURL, GetProjectID(), GetRegion())
+		return c.withEndpoint(connectorsService, c.GetRegion(), fmt.Sprintf(connectorBaseURL, c.GetProjectID(), c.GetRegion()))
 	}
 }
 
 // GetBaseCustomConnectorURL
-func GetBaseCustomConnectorURL() (connectorUrl string) {
-	if options.ProjectID == "" || options.Region == "" {
+func (c *Client) GetBaseCustomConnectorURL() (connectorUrl string) {
+	if c.options.ProjectID == "" || c.options.Region == "" {
 		return ""
 	}
-	switch options.Api {
+	switch c.options.Api {
 	case PROD:
-		return fmt.Sprintf(customConnectorBaseURL, GetProjectID())
+		return c.withEndpoint(customConnectorsService, c.GetRegion(), fmt.Sprintf(customConnectorBaseURL, c.GetProjectID()))
 	case STAGING:
-		return fmt.Sprintf(customConnectorStagingBaseURL, GetProjectID())
+		return c.withEndpoint(customConnectorsService, c.GetRegion(), fmt.Sprintf(customConnectorStagingBaseURL, c.GetProjectID()))
 	case AUTOPUSH:
-		return fmt.Sprintf(customConnectorAutoPushBaseURL, GetProjectID())
+		return c.withEndpoint(customConnectorsService, c.GetRegion(), fmt.Sprintf(customConnectorAutoPushBaseURL, c.GetProjectID()))
 	default:
-		return fmt.Sprintf(customConnectorBaseURL, GetProjectID())
+		return c.withEndpoint(customConnectorsService, c.GetRegion(), fmt.Sprintf(customConnectorBaseURL, c.GetProjectID()))
 	}
 }
 
 // GetBaseConnectorURLWithRegion
-func GetBaseConnectorURLWithRegion(region string) (connectorUrl string) {
-	if options.ProjectID == "" || region == "" {
+func (c *Client) GetBaseConnectorURLWithRegion(region string) (connectorUrl string) {
+	if c.options.ProjectID == "" || region == "" {
 		return ""
 	}
-	switch options.Api {
+	switch c.options.Api {
 	case PROD:
-		return fmt.Sprintf(connectorBaseURL, GetProjectID(), region)
+		return c.withEndpoint(connectorsService, region, fmt.Sprintf(connectorBaseURL, c.GetProjectID(), region))
 	case STAGING:
-		return fmt.Sprintf(connectorStagingBaseURL, GetProjectID(), region)
+		return c.withEndpoint(connectorsService, region, fmt.Sprintf(connectorStagingBaseURL, c.GetProjectID(), region))
 	case AUTOPUSH:
-		return fmt.Sprintf(connectorAutoPushBaseURL, GetProjectID(), region)
+		return c.withEndpoint(connectorsService, region, fmt.Sprintf(connectorAutoPushBaseURL, c.GetProjectID(), region))
 	default:
-		return fmt.Sprintf(connectorBaseURL, GetProjectID(), region)
+		return c.withEndpoint(connectorsService, region, fmt.Sprintf(connectorBaseURL, c.GetProjectID(), region))
 	}
 }
 
 // GetBaseConnectorOperationsURL
-func GetBaseConnectorOperationsrURL() (connectorUrl string) {
-	if options.ProjectID == "" || options.Region == "" {
+func (c *Client) GetBaseConnectorOperationsrURL() (connectorUrl string) {
+	if c.options.ProjectID == "" || c.options.Region == "" {
 		return ""
 	}
-	switch options.Api {
+	switch c.options.Api {
 	case PROD:
-		return fmt.Sprintf(connectorOperationsBaseURL, GetProjectID(), GetRegion())
+		return c.withEndpoint(connectorsService, c.GetRegion(), fmt.Sprintf(connectorOperationsBaseURL, c.GetProjectID(), c.GetRegion()))
 	case STAGING:
-		return fmt.Sprintf(connectorOperationsStagingBaseURL, GetProjectID(), GetRegion())
+		return c.withEndpoint(connectorsService, c.GetRegion(), fmt.Sprintf(connectorOperationsStagingBaseURL, c.GetProjectID(), c.GetRegion()))
 	case AUTOPUSH:
-		return fmt.Sprintf(connectorOperationsAutoPushBaseURL, GetProjectID(), GetRegion())
+		return c.withEndpoint(connectorsService, c.GetRegion(), fmt.Sprintf(connectorOperationsAutoPushBaseURL, c.GetProjectID(), c.GetRegion()))
 	default:
-		return fmt.Sprintf(connectorOperationsBaseURL, GetProjectID(), GetRegion())
+		return c.withEndpoint(connectorsService, c.GetRegion(), fmt.Sprintf(connectorOperationsBaseURL, c.GetProjectID(), c.GetRegion()))
 	}
 }
 
 // GetBaseConnectorEndpointAttachURL
-func GetBaseConnectorEndpointAttachURL() (connectorUrl string) {
-	if options.ProjectID == "" || options.Region == "" {
+func (c *Client) GetBaseConnectorEndpointAttachURL() (connectorUrl string) {
+	if c.options.ProjectID == "" || c.options.Region == "" {
 		return ""
 	}
-	switch options.Api {
+	switch c.options.Api {
 	case PROD:
-		return fmt.Sprintf(connectorEndpointAttachURL, GetProjectID(), GetRegion())
+		return c.withEndpoint(connectorsService, c.GetRegion(), fmt.Sprintf(connectorEndpointAttachURL, c.GetProjectID(), c.GetRegion()))
 	case STAGING:
-		return fmt.Sprintf(connectorEndpointAttachStagingURL, GetProjectID(), GetRegion())
+		return c.withEndpoint(connectorsService, c.GetRegion(), fmt.Sprintf(connectorEndpointAttachStagingURL, c.GetProjectID(), c.GetRegion()))
 	case AUTOPUSH:
-		return fmt.Sprintf(connectorEndpointAttachAutoPushURL, GetProjectID(), GetRegion())
+		return c.withEndpoint(connectorsService, c.GetRegion(), fmt.Sprintf(connectorEndpointAttachAutoPushURL, c.GetProjectID(), c.GetRegion()))
 	default:
-		return fmt.Sprintf(connectorEndpointAttachURL, GetProjectID(), GetRegion())
+		return c.withEndpoint(connectorsService, c.GetRegion(), fmt.Sprintf(connectorEndpointAttachURL, c.GetProjectID(), c.GetRegion()))
 	}
 }
 
 // GetBaseConnectorZonesURL
-func GetBaseConnectorZonesURL() (connectorUrl string) {
-	if options.ProjectID == "" || options.Region == "" {
+func (c *Client) GetBaseConnectorZonesURL() (connectorUrl string) {
+	if c.options.ProjectID == "" || c.options.Region == "" {
 		return ""
 	}
-	switch options.Api {
+	switch c.options.Api {
 	case PROD:
-		return fmt.Sprintf(connectorZonesURL, GetProjectID())
+		return c.withEndpoint(connectorsService, c.GetRegion(), fmt.Sprintf(connectorZonesURL, c.GetProjectID()))
 	case STAGING:
-		return fmt.Sprintf(connectorZonesAutoPushURL, GetProjectID())
+		return c.withEndpoint(connectorsService, c.GetRegion(), fmt.Sprintf(connectorZonesAutoPushURL, c.GetProjectID()))
 	case AUTOPUSH:
-		return fmt.Sprintf(connectorZonesAutoPushURL, GetProjectID())
+		return c.withEndpoint(connectorsService, c.GetRegion(), fmt.Sprintf(connectorZonesAutoPushURL, c.GetProjectID()))
 	default:
-		return fmt.Sprintf(connectorZonesURL, GetProjectID())
+		return c.withEndpoint(connectorsService, c.GetRegion(), fmt.Sprintf(connectorZonesURL, c.GetProjectID()))
 	}
 }
 
 // SetExportToFile
-func SetExportToFile(exportToFile string) {
-	options.ExportToFile = exportToFile
+func (c *Client) SetExportToFile(exportToFile string) {
+	c.options.ExportToFile = exportToFile
 }
 
 // GetExportToFile
-func GetExportToFile() string {
-	return options.ExportToFile
+func (c *Client) GetExportToFile() string {
+	return c.options.ExportToFile
 }
 
 // DryRun
@@ -439,63 +493,114 @@ func DryRun() bool {
 }
 
 // SetNoOutput
-func SetNoOutput(b bool) {
-	options.NoOutput = b
+func (c *Client) SetNoOutput(b bool) {
+	c.options.NoOutput = b
 }
 
 // GetNoOutput
-func GetNoOutput() bool {
-	return options.NoOutput
+func (c *Client) GetNoOutput() bool {
+	return c.options.NoOutput
 }
 
 // GetSuppressWarning
-func GetSuppressWarning() bool {
-	return options.SuppressWarnings
+func (c *Client) GetSuppressWarning() bool {
+	return c.options.SuppressWarnings
 }
 
 // SetConflictsAsErrors
-func SetConflictsAsErrors(b bool) {
-	options.ConflictsAreErrors = b
+func (c *Client) SetConflictsAsErrors(b bool) {
+	c.options.ConflictsAreErrors = b
 }
 
 // GetConflictsAsErrors
-func GetConflictsAsErrors() bool {
-	return options.ConflictsAreErrors
+func (c *Client) GetConflictsAsErrors() bool {
+	return c.options.ConflictsAreErrors
+}
+
+// SetOutput sets the format of the printed responses
+func (c *Client) SetOutput(o Output) {
+	c.options.Output = o
+}
+
+// GetOutput
+func (c *Client) GetOutput() Output {
+	if c.options.Output == "" {
+		return JSON
+	}
+	return c.options.Output
+}
+
+// SetQuery compiles the JMESPath expression applied to the responses before they are printed
+func (c *Client) SetQuery(expression string) (err error) {
+	if expression == "" {
+		c.query = nil
+		return nil
+	}
+	c.query, err = CompileQuery(expression)
+	return err
+}
+
+// GetQuery
+func (c *Client) GetQuery() *Query {
+	return c.query
+}
+
+// SetMaxAttempts
+func (c *Client) SetMaxAttempts(maxAttempts int) {
+	c.options.MaxAttempts = maxAttempts
+}
+
+// GetMaxAttempts
+func (c *Client) GetMaxAttempts() int {
+	if c.options.MaxAttempts < 1 {
+		return 1
+	}
+	return c.options.MaxAttempts
+}
+
+// SetMaxElapsedTime
+func (c *Client) SetMaxElapsedTime(maxElapsedTime time.Duration) {
+	c.options.MaxElapsedTime = maxElapsedTime
+}
+
+// GetMaxElapsedTime
+func (c *Client) GetMaxElapsedTime() time.Duration {
+	return c.options.MaxElapsedTime
 }
 
 // SetRate
-func SetRate(r Rate) {
-	apiRate = r
+func (c *Client) SetRate(r Rate) {
+	c.rate = r
 }
 
 // GetRate
-func GetRate() Rate {
-	return apiRate
+func (c *Client) GetRate() Rate {
+	return c.rate
 }
 
 // SetAPI
-func SetAPI(a API) {
+func (c *Client) SetAPI(a API) {
 	// prod is the default
 	if a == "" {
-		options.Api = PROD
+		c.options.Api = PROD
 	} else {
-		options.Api = a
+		c.options.Api = a
 	}
 }
 
 // GetAPI
-func GetAPI() API {
-	return options.Api
+func (c *Client) GetAPI() API {
+	return c.options.Api
 }
 
 // GetMetadataToken
-func GetMetadataToken() bool {
-	return options.MetadataToken
+func (c *Client) GetMetadataToken() bool {
+	return c.options.MetadataToken
 }
 
 // SetMetadataToken
-func SetMetadataToken(b bool) {
-	options.MetadataToken = b
+func (c *Client) SetMetadataToken(b bool) {
+	c.options.MetadataToken = b
 }
 
 func SetBuildParams(version string, commit string, date string) {
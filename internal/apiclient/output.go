@@ -0,0 +1,301 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Output is the format responses are printed in
+type Output string
+
+const (
+	JSON  Output = "json"
+	YAML  Output = "yaml"
+	TABLE Output = "table"
+	CSV   Output = "csv"
+)
+
+func (o *Output) String() string {
+	return string(*o)
+}
+
+func (o *Output) Set(r string) error {
+	switch r {
+	case "json", "yaml", "table", "csv":
+		*o = Output(r)
+	default:
+		return fmt.Errorf("must be one of %s, %s, %s or %s", JSON, YAML, TABLE, CSV)
+	}
+	return nil
+}
+
+func (o *Output) Type() string {
+	return "output"
+}
+
+// column is a column of a table, the value is read from the first path that is set in a row
+type column struct {
+	header string
+	paths  []string // dotted paths of the fields
+}
+
+// defaultColumns are the columns of the lists returned by the APIs, by the name of the list field
+var defaultColumns = map[string][]column{
+	"integrations": {
+		{"NAME", []string{"name"}},
+		{"ACTIVE", []string{"active"}},
+		{"DESCRIPTION", []string{"description"}},
+		{"UPDATE_TIME", []string{"updateTime"}},
+	},
+	"integrationVersions": {
+		{"VERSION", []string{"name", "version"}},
+		{"SNAPSHOT", []string{"snapshotNumber"}},
+		{"STATE", []string{"state"}},
+		{"USER_LABEL", []string{"userLabel"}},
+		{"UPDATE_TIME", []string{"updateTime"}},
+	},
+	"executions": {
+		{"EXECUTION", []string{"name"}},
+		{"TRIGGER", []string{"trigger", "triggerId"}},
+		{"STATE", []string{"executionDetails.state", "eventExecutionDetails.eventExecutionState"}},
+		{"METHOD", []string{"executionMethod"}},
+		{"CREATE_TIME", []string{"createTime"}},
+	},
+	"connections": {
+		{"NAME", []string{"name"}},
+		{"CONNECTOR_VERSION", []string{"connectorVersion"}},
+		{"STATE", []string{"status.state"}},
+		{"CREATE_TIME", []string{"createTime"}},
+	},
+	"authConfigs": {
+		{"NAME", []string{"name"}},
+		{"DISPLAY_NAME", []string{"displayName"}},
+		{"STATE", []string{"state"}},
+		{"CREDENTIAL_TYPE", []string{"credentialType"}},
+		{"CREATE_TIME", []string{"createTime"}},
+	},
+	"testCases": {
+		{"NAME", []string{"name"}},
+		{"DISPLAY_NAME", []string{"displayName"}},
+		{"TRIGGER", []string{"triggerId"}},
+		{"UPDATE_TIME", []string{"updateTime"}},
+	},
+	"operations": {
+		{"NAME", []string{"name"}},
+		{"DONE", []string{"done"}},
+		{"VERB", []string{"metadata.verb"}},
+		{"TARGET", []string{"metadata.target"}},
+		{"CREATE_TIME", []string{"metadata.createTime"}},
+	},
+}
+
+// FormatOutput formats a json response in the output format
+func FormatOutput(body []byte, output Output) ([]byte, error) {
+	switch output {
+	case YAML:
+		return yaml.JSONToYAML(body)
+	case TABLE, CSV:
+		var v interface{}
+		if err := json.Unmarshal(body, &v); err != nil {
+			return nil, err
+		}
+		headers, rows := tabulate(v)
+		if output == CSV {
+			return writeCSV(headers, rows)
+		}
+		return writeTable(headers, rows)
+	default:
+		var prettyJSON bytes.Buffer
+		if err := json.Indent(&prettyJSON, body, "", "\t"); err != nil {
+			return nil, err
+		}
+		return prettyJSON.Bytes(), nil
+	}
+}
+
+// tabulate returns the rows of a list response with the default columns of the list, or
+// with the fields of the first item for other lists. Other responses are returned as a row
+// for each field, values selected by a query as a single column
+func tabulate(v interface{}) (headers []string, rows [][]string) {
+	items, listName, isList := listItems(v)
+	if isList && len(items) > 0 && !isObject(items[0]) {
+		headers = []string{"VALUE"}
+		for _, item := range items {
+			fields := map[string]string{}
+			flatten("", item, fields)
+			rows = append(rows, []string{fields[""]})
+		}
+		return headers, rows
+	}
+	if !isList && !isObject(v) {
+		fields := map[string]string{}
+		flatten("", v, fields)
+		return []string{"VALUE"}, [][]string{{fields[""]}}
+	}
+	if !isList {
+		fields := map[string]string{}
+		flatten("", v, fields)
+		headers = []string{"FIELD", "VALUE"}
+		for _, key := range sortedKeys(fields) {
+			rows = append(rows, []string{key, fields[key]})
+		}
+		return headers, rows
+	}
+
+	columns, ok := defaultColumns[listName]
+	if !ok {
+		columns = itemColumns(items)
+	}
+	for _, c := range columns {
+		headers = append(headers, c.header)
+	}
+	for _, item := range items {
+		fields := map[string]string{}
+		flatten("", item, fields)
+		row := make([]string, len(columns))
+		for i, c := range columns {
+			for _, p := range c.paths {
+				if value, found := fields[p]; found {
+					if p == "name" {
+						value = value[strings.LastIndex(value, "/")+1:]
+					}
+					row[i] = value
+					break
+				}
+			}
+		}
+		rows = append(rows, row)
+	}
+	return headers, rows
+}
+
+func isObject(v interface{}) bool {
+	_, ok := v.(map[string]interface{})
+	return ok
+}
+
+// listItems returns the items of a json array or of the first array field of objects in a list
+// response, for example integrationVersions in {"integrationVersions": [...], "nextPageToken": ""}
+func listItems(v interface{}) (items []interface{}, listName string, isList bool) {
+	switch t := v.(type) {
+	case []interface{}:
+		return t, "", true
+	case map[string]interface{}:
+		if len(t) == 0 {
+			return nil, "", true
+		}
+		for _, key := range sortedKeys(t) {
+			if a, ok := t[key].([]interface{}); ok && key != "unreachable" {
+				if len(a) > 0 {
+					if _, ok = a[0].(map[string]interface{}); !ok {
+						continue
+					}
+				}
+				for k := range t {
+					if _, ok = t[k].([]interface{}); !ok && k != "nextPageToken" {
+						return nil, "", false
+					}
+				}
+				return a, key, true
+			}
+		}
+	}
+	return nil, "", false
+}
+
+// itemColumns returns a column for each field of the first item of a list, the name first
+func itemColumns(items []interface{}) (columns []column) {
+	if len(items) == 0 {
+		return nil
+	}
+	fields := map[string]string{}
+	flatten("", items[0], fields)
+	if _, ok := fields["name"]; ok {
+		columns = append(columns, column{"NAME", []string{"name"}})
+	}
+	for _, key := range sortedKeys(fields) {
+		if key != "name" {
+			columns = append(columns, column{strings.ToUpper(key), []string{key}})
+		}
+	}
+	return columns
+}
+
+// flatten stores the scalar fields of a json value by their dotted path, arrays are stored as json
+func flatten(prefix string, v interface{}, fields map[string]string) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for key, value := range t {
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+			flatten(key, value, fields)
+		}
+	case []interface{}:
+		b, _ := json.Marshal(t)
+		fields[prefix] = string(b)
+	case nil:
+		fields[prefix] = ""
+	default:
+		fields[prefix] = fmt.Sprint(t)
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func writeTable(headers []string, rows [][]string) ([]byte, error) {
+	var b bytes.Buffer
+	if len(headers) == 0 {
+		return b.Bytes(), nil
+	}
+	w := tabwriter.NewWriter(&b, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	err := w.Flush()
+	return bytes.TrimSuffix(b.Bytes(), []byte("\n")), err
+}
+
+func writeCSV(headers []string, rows [][]string) ([]byte, error) {
+	var b bytes.Buffer
+	if len(headers) == 0 {
+		return b.Bytes(), nil
+	}
+	w := csv.NewWriter(&b)
+	if err := w.Write(headers); err != nil {
+		return nil, err
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(b.Bytes(), []byte("\n")), nil
+}
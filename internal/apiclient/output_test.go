@@ -0,0 +1,84 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"strings"
+	"testing"
+)
+
+const versionsResponse = `{
+	"integrationVersions": [
+		{"name": "projects/p/locations/r/integrations/sample/versions/v1", "snapshotNumber": "2", "state": "ACTIVE", "userLabel": "prod"},
+		{"name": "projects/p/locations/r/integrations/sample/versions/v2", "snapshotNumber": "1", "state": "DRAFT"}
+	],
+	"nextPageToken": "token"
+}`
+
+func TestFormatOutput(t *testing.T) {
+	table, err := FormatOutput([]byte(versionsResponse), TABLE)
+	if err != nil {
+		t.Fatalf("FormatOutput failed: %v", err)
+	}
+	lines := strings.Split(string(table), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected a header and 2 rows, got %q", string(table))
+	}
+	if fields := strings.Fields(lines[0]); strings.Join(fields, ",") != "VERSION,SNAPSHOT,STATE,USER_LABEL,UPDATE_TIME" {
+		t.Errorf("unexpected header %q", lines[0])
+	}
+	if fields := strings.Fields(lines[1]); strings.Join(fields, ",") != "v1,2,ACTIVE,prod" {
+		t.Errorf("unexpected row %q", lines[1])
+	}
+
+	csv, err := FormatOutput([]byte(versionsResponse), CSV)
+	if err != nil {
+		t.Fatalf("FormatOutput failed: %v", err)
+	}
+	if !strings.HasPrefix(string(csv), "VERSION,SNAPSHOT,STATE,USER_LABEL,UPDATE_TIME\nv1,2,ACTIVE,prod,\n") {
+		t.Errorf("unexpected csv %q", string(csv))
+	}
+
+	yaml, err := FormatOutput([]byte(versionsResponse), YAML)
+	if err != nil {
+		t.Fatalf("FormatOutput failed: %v", err)
+	}
+	if !strings.Contains(string(yaml), "nextPageToken: token") {
+		t.Errorf("unexpected yaml %q", string(yaml))
+	}
+
+	// other responses are printed as fields
+	table, err = FormatOutput([]byte(`{"name": "sample", "trigger": {"id": "api_trigger/sample"}}`), TABLE)
+	if err != nil {
+		t.Fatalf("FormatOutput failed: %v", err)
+	}
+	if !strings.Contains(string(table), "trigger.id   api_trigger/sample") {
+		t.Errorf("unexpected table %q", string(table))
+	}
+
+	// lists without default columns use the fields of the first item
+	csv, err = FormatOutput([]byte(`{"sfdcInstances": [{"name": "a/b", "displayName": "one"}]}`), CSV)
+	if err != nil {
+		t.Fatalf("FormatOutput failed: %v", err)
+	}
+	if string(csv) != "NAME,DISPLAYNAME\nb,one" {
+		t.Errorf("unexpected csv %q", string(csv))
+	}
+
+	var o Output
+	if err = o.Set("xml"); err == nil {
+		t.Errorf("expected an error for an unknown output format")
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"internal/clilog"
+)
+
+// ListPage requests the page of a list that starts at the page token, the token of
+// the first page is empty
+type ListPage func(client *Client, pageToken string) (respBody []byte, err error)
+
+// SizedListPage requests a page of a list with the page size, -1 is the default page size of the API
+type SizedListPage func(client *Client, pageToken string, pageSize int) (respBody []byte, err error)
+
+// errLastPage stops the pagination without an error
+var errLastPage = errors.New("last page")
+
+type listResponse struct {
+	NextPageToken string `json:"nextPageToken,omitempty"`
+}
+
+// ForEachPage requests every page of a list and calls handlePage with each of them.
+// The pages are requested without printing them
+func (c *Client) ForEachPage(list ListPage, handlePage func(respBody []byte) error) error {
+	client := c.WithoutOutput()
+	pageToken := ""
+	for page := 1; ; page++ {
+		clilog.Debug.Printf("Requesting page %d\n", page)
+		respBody, err := list(client, pageToken)
+		if err != nil {
+			return err
+		}
+		if err = handlePage(respBody); err != nil {
+			if errors.Is(err, errLastPage) {
+				return nil
+			}
+			return err
+		}
+
+		l := listResponse{}
+		if err = json.Unmarshal(respBody, &l); err != nil {
+			return err
+		}
+		if l.NextPageToken == "" {
+			return nil
+		}
+		// a token that does not change would request the same page forever
+		if l.NextPageToken == pageToken {
+			return fmt.Errorf("the page token %s was returned twice", pageToken)
+		}
+		pageToken = l.NextPageToken
+	}
+}
+
+// ListAll requests the pages of a list, merges the items of every page into one response
+// and prints it. The pages are kept in memory until the last one is received, so that the
+// query and the output format apply to the whole list. With a limit greater than 0, no more
+// than limit items are returned and the size of a page is capped at the items still missing
+func (c *Client) ListAll(list SizedListPage, pageSize int, limit int) (respBody []byte, err error) {
+	merged := map[string]interface{}{}
+	items := map[string][]json.RawMessage{}
+
+	// nextPageSize requests no more items than are missing to reach the limit
+	nextPageSize := func() int {
+		if limit <= 0 {
+			return pageSize
+		}
+		listed := 0
+		for _, l := range items {
+			listed = max(listed, len(l))
+		}
+		if remaining := limit - listed; pageSize <= 0 || remaining < pageSize {
+			return remaining
+		}
+		return pageSize
+	}
+
+	err = c.ForEachPage(func(client *Client, pageToken string) ([]byte, error) {
+		return list(client, pageToken, nextPageSize())
+	}, func(respBody []byte) error {
+		fields := map[string]json.RawMessage{}
+		if err := json.Unmarshal(respBody, &fields); err != nil {
+			return err
+		}
+		lastPage := false
+		for name, value := range fields {
+			if name == "nextPageToken" {
+				continue
+			}
+			// lists are merged, other fields are kept from the first page
+			var l []json.RawMessage
+			if err := json.Unmarshal(value, &l); err == nil {
+				items[name] = append(items[name], l...)
+				if limit > 0 && len(items[name]) >= limit {
+					items[name] = items[name][:limit]
+					lastPage = true
+				}
+			} else if _, ok := merged[name]; !ok {
+				merged[name] = value
+			}
+		}
+		if lastPage {
+			return errLastPage
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for name, l := range items {
+		merged[name] = l
+	}
+
+	// like the APIs, the merged response does not escape html characters
+	buf := bytes.Buffer{}
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err = encoder.Encode(merged); err != nil {
+		return nil, err
+	}
+	respBody = bytes.TrimSpace(buf.Bytes())
+	return respBody, c.PrettyPrint(respBody)
+}
@@ -0,0 +1,94 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"internal/clilog"
+	"reflect"
+	"testing"
+)
+
+func TestListAll(t *testing.T) {
+	clilog.Init(false, false, true, true)
+
+	pages := map[string]string{
+		"":  `{"integrations":[{"name":"a"},{"name":"b"}],"nextPageToken":"2"}`,
+		"2": `{"integrations":[{"name":"c<d"}],"nextPageToken":"3"}`,
+		"3": `{"integrations":[{"name":"e"}]}`,
+	}
+	var requested []string
+	var pageSizes []int
+	list := func(client *Client, pageToken string, pageSize int) ([]byte, error) {
+		if client.GetPrintHttpResponse() {
+			t.Errorf("expected the pages to be requested without output")
+		}
+		requested = append(requested, pageToken)
+		pageSizes = append(pageSizes, pageSize)
+		page, ok := pages[pageToken]
+		if !ok {
+			return nil, fmt.Errorf("unexpected page token %s", pageToken)
+		}
+		return []byte(page), nil
+	}
+
+	// the size of a page is capped at the items missing to reach the limit
+	tests := []struct {
+		pageSize  int
+		limit     int
+		expected  string
+		requested int
+		pageSizes []int
+	}{
+		{2, 0, `{"integrations":[{"name":"a"},{"name":"b"},{"name":"c<d"},{"name":"e"}]}`, 3, []int{2, 2, 2}},
+		{2, 2, `{"integrations":[{"name":"a"},{"name":"b"}]}`, 1, []int{2}},
+		{2, 3, `{"integrations":[{"name":"a"},{"name":"b"},{"name":"c<d"}]}`, 2, []int{2, 1}},
+		{-1, 3, `{"integrations":[{"name":"a"},{"name":"b"},{"name":"c<d"}]}`, 2, []int{3, 1}},
+	}
+	for _, test := range tests {
+		requested, pageSizes = nil, nil
+		respBody, err := NewClient(IntegrationClientOptions{}).ListAll(list, test.pageSize, test.limit)
+		if err != nil {
+			t.Fatalf("ListAll with limit %d failed: %v", test.limit, err)
+		}
+		if string(respBody) != test.expected {
+			t.Errorf("ListAll with limit %d = %s, expected %s", test.limit, respBody, test.expected)
+		}
+		if len(requested) != test.requested {
+			t.Errorf("ListAll with limit %d requested %d pages, expected %d", test.limit, len(requested), test.requested)
+		}
+		if !reflect.DeepEqual(pageSizes, test.pageSizes) {
+			t.Errorf("ListAll with page size %d and limit %d requested the page sizes %v, expected %v",
+				test.pageSize, test.limit, pageSizes, test.pageSizes)
+		}
+	}
+
+	// an empty list is returned as {}
+	respBody, err := NewClient(IntegrationClientOptions{}).ListAll(func(client *Client, pageToken string, pageSize int) ([]byte, error) {
+		return []byte(`{}`), nil
+	}, -1, 0)
+	if err != nil || string(respBody) != `{}` {
+		t.Errorf("ListAll of an empty list = %s, %v", respBody, err)
+	}
+
+	// a repeated token would never end
+	err = NewClient(IntegrationClientOptions{}).ForEachPage(func(client *Client, pageToken string) ([]byte, error) {
+		return json.Marshal(map[string]string{"nextPageToken": "same"})
+	}, func(respBody []byte) error { return nil })
+	if err == nil {
+		t.Errorf("expected an error for a repeated page token")
+	}
+}
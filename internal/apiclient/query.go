@@ -0,0 +1,55 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/jmespath/go-jmespath"
+)
+
+// Query is a compiled JMESPath expression (https://jmespath.org/specification.html)
+type Query struct {
+	expression string
+	jmespath   *jmespath.JMESPath
+}
+
+// CompileQuery parses a JMESPath expression, for example integrationVersions[?state=='ACTIVE'].name
+func CompileQuery(expression string) (*Query, error) {
+	jp, err := jmespath.Compile(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query %q: %w", expression, err)
+	}
+	return &Query{expression: expression, jmespath: jp}, nil
+}
+
+// String returns the expression of the query
+func (q *Query) String() string {
+	return q.expression
+}
+
+// Apply runs the query on a json document and returns the result as json
+func (q *Query) Apply(body []byte) ([]byte, error) {
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, err
+	}
+	result, err := q.jmespath.Search(data)
+	if err != nil {
+		return nil, fmt.Errorf("query %q: %w", q.expression, err)
+	}
+	return json.Marshal(result)
+}
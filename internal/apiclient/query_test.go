@@ -0,0 +1,91 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"testing"
+)
+
+const executionsResponse = `{
+	"executions": [
+		{"name": "e1", "executionDetails": {"state": "SUCCEEDED"}, "attempts": 1, "labels": ["a", "b"]},
+		{"name": "e2", "executionDetails": {"state": "FAILED"}, "attempts": 3, "labels": ["c"]},
+		{"name": "e3", "executionDetails": {"state": "SUCCEEDED"}, "attempts": 2}
+	],
+	"nextPageToken": "token"
+}`
+
+func TestQuery(t *testing.T) {
+	tests := []struct {
+		expression string
+		expected   string
+	}{
+		{"nextPageToken", `"token"`},
+		{"executions[0].name", `"e1"`},
+		{"executions[-1].name", `"e3"`},
+		{"executions[*].name", `["e1","e2","e3"]`},
+		{"executions[].executionDetails.state", `["SUCCEEDED","FAILED","SUCCEEDED"]`},
+		{"executions[?executionDetails.state=='FAILED'].name", `["e2"]`},
+		{"executions[?executionDetails.state != 'FAILED' && attempts > `1`].name", `["e3"]`},
+		{"executions[?!labels].name", `["e3"]`},
+		{"executions[*].labels[]", `["a","b","c"]`},
+		{"executions[1:].name", `["e2","e3"]`},
+		{"executions[::-1].name", `["e3","e2","e1"]`},
+		{"executions[*].[name, attempts]", `[["e1",1],["e2",3],["e3",2]]`},
+		{"executions[?attempts >= `2`].{id: name, state: executionDetails.state}", `[{"id":"e2","state":"FAILED"},{"id":"e3","state":"SUCCEEDED"}]`},
+		{"executions[*].name | [0]", `"e1"`},
+		{"length(executions)", `3`},
+		{"join(', ', sort(executions[*].name))", `"e1, e2, e3"`},
+		{"executions[?labels && contains(labels, 'c')].name", `["e2"]`},
+		{"executions[?starts_with(name, 'e')] | length(@)", `3`},
+		{"sort(keys(executions[0]))", `["attempts","executionDetails","labels","name"]`},
+		{"missing.field", `null`},
+		{"missing || nextPageToken", `"token"`},
+		{`"nextPageToken"`, `"token"`},
+	}
+	for _, test := range tests {
+		q, err := CompileQuery(test.expression)
+		if err != nil {
+			t.Errorf("CompileQuery(%s) failed: %v", test.expression, err)
+			continue
+		}
+		result, err := q.Apply([]byte(executionsResponse))
+		if err != nil {
+			t.Errorf("Apply(%s) failed: %v", test.expression, err)
+			continue
+		}
+		if string(result) != test.expected {
+			t.Errorf("Apply(%s) = %s, expected %s", test.expression, string(result), test.expected)
+		}
+	}
+
+	for _, expression := range []string{"executions[", "executions[?state=='a'", "a.", "a b", "'unterminated"} {
+		if _, err := CompileQuery(expression); err == nil {
+			t.Errorf("expected an error for %s", expression)
+		}
+	}
+
+	// functions are checked when the query is applied
+	for _, expression := range []string{"unknown(executions)", "length(`1`)", "join(', ', executions)"} {
+		q, err := CompileQuery(expression)
+		if err != nil {
+			t.Errorf("CompileQuery(%s) failed: %v", expression, err)
+			continue
+		}
+		if _, err = q.Apply([]byte(executionsResponse)); err == nil {
+			t.Errorf("expected an error for %s", expression)
+		}
+	}
+}
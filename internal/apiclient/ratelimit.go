@@ -0,0 +1,143 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"context"
+	"internal/clilog"
+	"net/http"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// default quotas in requests per minute
+const (
+	// 360 per min, limit is 480 per min
+	DefaultIntegrationRateLimit = 360
+	// 60 per min, limit is 120 per min
+	DefaultConnectorsRateLimit = 60
+)
+
+// apiRateLimiter limits the requests to one API. The limit is lowered when the API
+// responds with 429 and recovers towards the quota with every successful response
+type apiRateLimiter struct {
+	name    string
+	limiter *rate.Limiter
+	quota   rate.Limit
+	sync.Mutex
+}
+
+// rateLimiters hold the rate limiters of a client and its copies, so that concurrent
+// commands and copies of the client share the quota of each API
+type rateLimiters struct {
+	integrations *apiRateLimiter
+	connectors   *apiRateLimiter
+}
+
+// noAPIRateLimit never waits and never adapts, it can be shared by every client
+var noAPIRateLimit = &apiRateLimiter{name: "none", limiter: rate.NewLimiter(rate.Inf, 1), quota: rate.Inf}
+
+func newRateLimiters() *rateLimiters {
+	return &rateLimiters{
+		integrations: newAPIRateLimiter("integrations", DefaultIntegrationRateLimit),
+		connectors:   newAPIRateLimiter("connectors", DefaultConnectorsRateLimit),
+	}
+}
+
+func newAPIRateLimiter(name string, requestsPerMinute int) *apiRateLimiter {
+	l := &apiRateLimiter{name: name, limiter: rate.NewLimiter(rate.Inf, 1)}
+	l.setQuota(requestsPerMinute)
+	return l
+}
+
+// setQuota sets the requests per minute, the burst is the requests per second
+func (l *apiRateLimiter) setQuota(requestsPerMinute int) {
+	if requestsPerMinute <= 0 {
+		return
+	}
+	l.Lock()
+	defer l.Unlock()
+	l.quota = rate.Limit(float64(requestsPerMinute) / 60)
+	burst := requestsPerMinute / 60
+	if burst < 1 {
+		burst = 1
+	}
+	l.limiter.SetLimit(l.quota)
+	l.limiter.SetBurst(burst)
+}
+
+// Wait blocks until the request is allowed
+func (l *apiRateLimiter) Wait(ctx context.Context) error {
+	return l.limiter.Wait(ctx)
+}
+
+// adapt lowers the limit on 429 and raises it again on success
+func (l *apiRateLimiter) adapt(statusCode int) {
+	l.Lock()
+	defer l.Unlock()
+	if l.quota == rate.Inf {
+		return
+	}
+
+	current := l.limiter.Limit()
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		limit := current / 2
+		if limit < l.quota/16 {
+			limit = l.quota / 16
+		}
+		if limit != current {
+			clilog.Debug.Printf("%s API returned 429, lowering rate limit to %.2f requests per second\n", l.name, float64(limit))
+			l.limiter.SetLimit(limit)
+		}
+	case statusCode < 400 && current < l.quota:
+		limit := current + l.quota/20
+		if limit > l.quota {
+			limit = l.quota
+		}
+		l.limiter.SetLimit(limit)
+		if limit == l.quota {
+			clilog.Debug.Printf("%s API rate limit restored to %.2f requests per second\n", l.name, float64(limit))
+		}
+	}
+}
+
+// get returns the rate limiter for the service of a request, ok is false for the requests
+// that are not sent to the integrations and connectors APIs
+func (l *rateLimiters) get(r Rate, s service, ok bool) *apiRateLimiter {
+	switch r {
+	case IntegrationAPI:
+		return l.integrations
+	case ConnectorsAPI:
+		return l.connectors
+	case Automatic:
+		if !ok {
+			return noAPIRateLimit
+		}
+		if s == integrationsService {
+			return l.integrations
+		}
+		return l.connectors
+	}
+	return noAPIRateLimit
+}
+
+// SetRateLimits sets the quotas in requests per minute for the integrations and connectors APIs.
+// Values less than or equal to zero keep the current quota
+func (c *Client) SetRateLimits(integrationRequestsPerMinute int, connectorsRequestsPerMinute int) {
+	c.limiters.integrations.setQuota(integrationRequestsPerMinute)
+	c.limiters.connectors.setQuota(connectorsRequestsPerMinute)
+}
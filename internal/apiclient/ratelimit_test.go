@@ -0,0 +1,73 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"internal/clilog"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestGetRateLimiter(t *testing.T) {
+	// custom endpoints do not name the API in their hostname
+	c := NewClient(IntegrationClientOptions{
+		ProjectID: "project",
+		Region:    "us-west1",
+		Endpoints: Endpoints{Integrations: "https://psc-one.example.com", Connectors: "https://psc-two.example.com/prefix"},
+	})
+	tests := []struct {
+		rate     Rate
+		target   string
+		expected *apiRateLimiter
+	}{
+		{Automatic, c.GetBaseIntegrationURL() + "integrations/sample", c.limiters.integrations},
+		{Automatic, c.GetBaseConnectorURL() + "/sample", c.limiters.connectors},
+		{Automatic, c.GetBaseCustomConnectorURL(), c.limiters.connectors},
+		{Automatic, "https://iam.googleapis.com/v1/projects/project", noAPIRateLimit},
+		{Automatic, "https://psc-two.example.com/other", noAPIRateLimit},
+		{IntegrationAPI, "https://iam.googleapis.com/v1/projects/project", c.limiters.integrations},
+		{None, c.GetBaseConnectorURL(), noAPIRateLimit},
+	}
+	for _, test := range tests {
+		u, _ := url.Parse(test.target)
+		s, ok := c.serviceOf(u)
+		if l := c.limiters.get(test.rate, s, ok); l != test.expected {
+			t.Errorf("expected the %s rate limiter for %s, got %s", test.expected.name, test.target, l.name)
+		}
+	}
+}
+
+func TestAdaptRateLimiter(t *testing.T) {
+	clilog.Init(false, false, true, true)
+
+	l := newAPIRateLimiter("test", 600)
+	l.adapt(http.StatusTooManyRequests)
+	if limit := l.limiter.Limit(); limit != l.quota/2 {
+		t.Errorf("expected the limit to be halved after 429, got %.2f", float64(limit))
+	}
+	for i := 0; i < 100; i++ {
+		l.adapt(http.StatusTooManyRequests)
+	}
+	if limit := l.limiter.Limit(); limit != l.quota/16 {
+		t.Errorf("expected the limit to stop at a sixteenth of the quota, got %.2f", float64(limit))
+	}
+	for i := 0; i < 100; i++ {
+		l.adapt(http.StatusOK)
+	}
+	if limit := l.limiter.Limit(); limit != l.quota {
+		t.Errorf("expected the limit to recover to the quota, got %.2f", float64(limit))
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"bytes"
+	"internal/clilog"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRetry(t *testing.T) {
+	clilog.Init(false, false, true, true)
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "payload" {
+			t.Errorf("attempt %d sent body %q", attempts, string(body))
+		}
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &RateLimitedHTTPClient{
+		client:  http.DefaultClient,
+		session: NewClient(IntegrationClientOptions{MaxAttempts: 3, MaxElapsedTime: time.Minute}),
+	}
+
+	req, _ := http.NewRequest(http.MethodPut, server.URL, bytes.NewBufferString("payload"))
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || attempts != 2 {
+		t.Errorf("got status code %d after %d attempts, expected 200 after 2", resp.StatusCode, attempts)
+	}
+
+	// POST is not retried after a server error
+	attempts = 0
+	req, _ = http.NewRequest(http.MethodPost, server.URL, bytes.NewBufferString("payload"))
+	if resp, err = client.Do(req); err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable || attempts != 1 {
+		t.Errorf("got status code %d after %d attempts, expected 503 after 1", resp.StatusCode, attempts)
+	}
+}
+
+func TestGetBackoff(t *testing.T) {
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"7"}}}
+	if backoff := getBackoff(1, resp); backoff != 7*time.Second {
+		t.Errorf("expected Retry-After to be honoured, got %s", backoff)
+	}
+	for attempt := 1; attempt < 10; attempt++ {
+		backoff := getBackoff(attempt, nil)
+		limit := initialBackoff << uint(attempt-1)
+		if limit > maxBackoff {
+			limit = maxBackoff
+		}
+		if backoff < limit/2 || backoff > limit {
+			t.Errorf("attempt %d backoff %s is not between %s and %s", attempt, backoff, limit/2, limit)
+		}
+	}
+}
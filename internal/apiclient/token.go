@@ -15,7 +15,6 @@
 package apiclient
 
 import (
-	"context"
 	"crypto/x509"
 	"encoding/json"
 	"encoding/pem"
@@ -26,7 +25,6 @@ import (
 	"net/http"
 	"net/url"
 	"os"
-	"reflect"
 	"strconv"
 	"strings"
 	"time"
@@ -49,8 +47,6 @@ type serviceAccount struct {
 	ClientCertURL       string `json:"client_x509_cert_url,omitempty"`
 }
 
-var account = serviceAccount{}
-
 const tokenUri = "https://www.googleapis.com/oauth2/v4/token"
 
 func getPrivateKey(privateKey string) (interface{}, error) {
@@ -64,10 +60,10 @@ func getPrivateKey(privateKey string) (interface{}, error) {
 	return privKey, nil
 }
 
-func generateJWT(privateKey string) (string, error) {
+func generateJWT(account serviceAccount) (string, error) {
 	const scope = "https://www.googleapis.com/auth/cloud-platform"
 
-	privKey, err := getPrivateKey(privateKey)
+	privKey, err := getPrivateKey(account.PrivateKey)
 	if err != nil {
 		return "", err
 	}
@@ -81,7 +77,7 @@ func generateJWT(privateKey string) (string, error) {
 	token.Options().IsEnabled(jwt.FlattenAudience)
 
 	_ = token.Set("aud", tokenUri)
-	_ = token.Set(jwt.IssuerKey, getServiceAccountProperty("ClientEmail"))
+	_ = token.Set(jwt.IssuerKey, account.ClientEmail)
 	_ = token.Set("scope", scope)
 	_ = token.Set(jwt.IssuedAtKey, now.Unix())
 	_ = token.Set(jwt.ExpirationKey, now.Unix())
@@ -96,7 +92,7 @@ func generateJWT(privateKey string) (string, error) {
 }
 
 // generateAccessToken generates a Google OAuth access token from a service account
-func generateAccessToken(privateKey string) (string, error) {
+func (c *Client) generateAccessToken(account serviceAccount) (string, error) {
 	const grantType = "urn:ietf:params:oauth:grant-type:jwt-bearer"
 	var respBody []byte
 
@@ -107,7 +103,7 @@ func generateAccessToken(privateKey string) (string, error) {
 		TokenType   string `json:"token_type,omitempty"`
 	}
 
-	token, err := generateJWT(privateKey)
+	token, err := generateJWT(account)
 	if err != nil {
 		return "", nil
 	}
@@ -116,8 +112,11 @@ func generateAccessToken(privateKey string) (string, error) {
 	form.Add("grant_type", grantType)
 	form.Add("assertion", token)
 
-	client := &http.Client{}
-	req, err := http.NewRequest("POST", tokenUri, strings.NewReader(form.Encode()))
+	client, err := c.newHTTPClient(false)
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(c.GetContext(), http.MethodPost, tokenUri, strings.NewReader(form.Encode()))
 	if err != nil {
 		clilog.Error.Println("error in client: ", err)
 		return "", err
@@ -158,32 +157,23 @@ func generateAccessToken(privateKey string) (string, error) {
 
 	clilog.Debug.Println("access token : ", accessToken)
 
-	SetIntegrationToken(accessToken.AccessToken)
-	_ = writeToken(accessToken.AccessToken)
+	c.SetIntegrationToken(accessToken.AccessToken)
+	_ = c.writeToken(accessToken.AccessToken)
 	return accessToken.AccessToken, nil
 }
 
-func readServiceAccount(serviceAccountPath string) error {
+func readServiceAccount(serviceAccountPath string) (account serviceAccount, err error) {
 	content, err := os.ReadFile(serviceAccountPath)
 	if err != nil {
-		return err
+		return account, err
 	}
 
 	err = json.Unmarshal(content, &account)
-	if err != nil {
-		return err
-	}
-	return nil
-}
-
-func getServiceAccountProperty(key string) (value string) {
-	r := reflect.ValueOf(&account)
-	field := reflect.Indirect(r).FieldByName(key)
-	return field.String()
+	return account, err
 }
 
-func checkAccessToken() bool {
-	if TokenCheckEnabled() {
+func (c *Client) checkAccessToken() bool {
+	if c.TokenCheckEnabled() {
 		clilog.Debug.Println("skipping token validity")
 		return true
 	}
@@ -191,13 +181,17 @@ func checkAccessToken() bool {
 	const tokenInfo = "https://oauth2.googleapis.com/tokeninfo"
 	u, _ := url.Parse(tokenInfo)
 	q := u.Query()
-	q.Set("access_token", GetIntegrationToken())
+	q.Set("access_token", c.GetIntegrationToken())
 	u.RawQuery = q.Encode()
 
-	client := &http.Client{}
+	client, err := c.newHTTPClient(false)
+	if err != nil {
+		clilog.Error.Println("error in client:", err)
+		return false
+	}
 
 	clilog.Debug.Println("Connecting to : ", u.String())
-	req, err := http.NewRequest("GET", u.String(), nil)
+	req, err := http.NewRequestWithContext(c.GetContext(), http.MethodGet, u.String(), nil)
 	if err != nil {
 		clilog.Error.Println("error in client:", err)
 		return false
@@ -218,41 +212,40 @@ func checkAccessToken() bool {
 		return false
 	}
 	clilog.Debug.Println("Response: ", string(body))
-	clilog.Debug.Println("Reusing the cached token: ", GetIntegrationToken())
+	clilog.Debug.Println("Reusing the cached token: ", c.GetIntegrationToken())
 	return true
 }
 
 // SetAccessToken read from cache or if not found or expired will generate a new one
-func SetAccessToken() error {
-	if GetIntegrationToken() == "" && GetServiceAccount() == "" {
-		SetIntegrationToken(getToken()) // read from configuration
-		if GetIntegrationToken() == "" {
+func (c *Client) SetAccessToken() error {
+	if c.GetIntegrationToken() == "" && c.GetServiceAccount() == "" {
+		c.SetIntegrationToken(getToken()) // read from configuration
+		if c.GetIntegrationToken() == "" {
 			return fmt.Errorf("either token or service account must be provided")
 		}
-		if checkAccessToken() { // check if the token is still valid
+		if c.checkAccessToken() { // check if the token is still valid
 			return nil
 		}
 		return fmt.Errorf("token expired: request a new access token or pass the service account")
 	}
-	if GetIntegrationToken() != "" {
+	if c.GetIntegrationToken() != "" {
 		// a token was passed, cache it
-		if checkAccessToken() {
-			_ = writeToken(GetIntegrationToken())
+		if c.checkAccessToken() {
+			_ = c.writeToken(c.GetIntegrationToken())
 			return nil
 		}
 	} else {
-		err := readServiceAccount(GetServiceAccount())
+		account, err := readServiceAccount(c.GetServiceAccount())
 		if err != nil { // Handle errors reading the config file
 			return fmt.Errorf("error reading config file: %s", err)
 		}
-		privateKey := getServiceAccountProperty("PrivateKey")
-		if privateKey == "" {
+		if account.PrivateKey == "" {
 			return fmt.Errorf("private key missing in the service account")
 		}
-		if getServiceAccountProperty("ClientEmail") == "" {
+		if account.ClientEmail == "" {
 			return fmt.Errorf("client email missing in the service account")
 		}
-		_, err = generateAccessToken(privateKey)
+		_, err = c.generateAccessToken(account)
 		if err != nil {
 			return fmt.Errorf("fatal error generating access token: %s", err)
 		}
@@ -262,8 +255,8 @@ func SetAccessToken() error {
 }
 
 // GetDefaultAccessToken
-func GetDefaultAccessToken() (err error) {
-	ctx := context.Background()
+func (c *Client) GetDefaultAccessToken() (err error) {
+	ctx := c.GetContext()
 	tokenSource, err := google.DefaultTokenSource(ctx, "https://www.googleapis.com/auth/cloud-platform")
 	if err != nil {
 		return err
@@ -272,18 +265,18 @@ func GetDefaultAccessToken() (err error) {
 	if err != nil {
 		return err
 	}
-	SetIntegrationToken(token.AccessToken)
+	c.SetIntegrationToken(token.AccessToken)
 	return nil
 }
 
 // GetMetadataAccessToken
-func GetMetadataAccessToken() (err error) {
+func (c *Client) GetMetadataAccessToken() (err error) {
 	var req *http.Request
 	var tokenResponse map[string]interface{}
 
 	metadataURL := "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
 
-	client, err := getHttpClient()
+	client, err := c.getHttpClient()
 	if err != nil {
 		return err
 	}
@@ -294,7 +287,7 @@ func GetMetadataAccessToken() (err error) {
 
 	clilog.Debug.Println("Connecting to: ", metadataURL)
 
-	req, err = http.NewRequest(http.MethodGet, metadataURL, nil)
+	req, err = http.NewRequestWithContext(c.GetContext(), http.MethodGet, metadataURL, nil)
 	if err != nil {
 		clilog.Error.Println("error in client: ", err)
 		return err
@@ -331,7 +324,7 @@ func GetMetadataAccessToken() (err error) {
 		return err
 	}
 
-	SetIntegrationToken(tokenResponse["access_token"].(string))
+	c.SetIntegrationToken(tokenResponse["access_token"].(string))
 
 	return nil
 }
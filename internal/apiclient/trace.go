@@ -0,0 +1,134 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// OTLP exports the spans with OTLP/HTTP to the endpoint set in the OTEL_EXPORTER_OTLP_ENDPOINT
+// or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT environment variables, default is http://localhost:4318
+const OTLP = "otlp"
+
+// attributes of the spans
+const (
+	apiAttribute        = attribute.Key("integrationcli.api")
+	resourceAttribute   = attribute.Key("integrationcli.resource")
+	retryCountAttribute = attribute.Key("integrationcli.retry_count")
+	methodAttribute     = attribute.Key("http.request.method")
+	urlAttribute        = attribute.Key("url.full")
+	statusAttribute     = attribute.Key("http.response.status_code")
+)
+
+// tracer creates the spans of commands and requests. Spans are not recorded
+// unless tracing is started
+var tracer = otel.Tracer("integrationcli")
+
+// StartTracing records the spans and exports them to OTLP when the destination is otlp,
+// or as json to the destination file otherwise. The returned function flushes the spans, it
+// returns when the context is done even if the spans were not exported
+func StartTracing(destination string) (shutdown func(context.Context) error, err error) {
+	var exporter sdktrace.SpanExporter
+	var file *os.File
+
+	if destination == OTLP {
+		// the exporter reads the endpoint, headers and timeout from the OTEL_EXPORTER_OTLP variables
+		if exporter, err = otlptracehttp.New(context.Background()); err != nil {
+			return nil, fmt.Errorf("unable to create the OTLP exporter: %w", err)
+		}
+	} else {
+		if file, err = os.Create(destination); err != nil {
+			return nil, fmt.Errorf("unable to create the trace file: %w", err)
+		}
+		if exporter, err = stdouttrace.New(stdouttrace.WithWriter(file)); err != nil {
+			file.Close()
+			return nil, err
+		}
+	}
+
+	version, _, _ := GetBuildParams()
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", "integrationcli"),
+			attribute.String("service.version", version),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if file != nil {
+			err = errors.Join(err, file.Close())
+		}
+		return err
+	}, nil
+}
+
+// StartSpan starts a span for an operation on the resource, for example a command or a
+// wait for a long running operation. Call the returned function with the result of the operation
+func StartSpan(ctx context.Context, name string, resource string) (context.Context, func(error)) {
+	ctx, span := tracer.Start(ctx, name)
+	if resource != "" {
+		span.SetAttributes(resourceAttribute.String(resource))
+	}
+	return ctx, func(err error) {
+		endSpan(span, err)
+	}
+}
+
+// startRequestSpan starts the span of a request to the api, retries are recorded in the same span
+func startRequestSpan(req *http.Request, api string) (context.Context, trace.Span) {
+	return tracer.Start(req.Context(), req.Method+" "+req.URL.Host,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			apiAttribute.String(api),
+			resourceAttribute.String(req.URL.Path),
+			methodAttribute.String(req.Method),
+			urlAttribute.String(sanitizeURL(req.URL)),
+		))
+}
+
+func endRequestSpan(span trace.Span, resp *http.Response, attempt int, err error) {
+	span.SetAttributes(retryCountAttribute.Int(attempt - 1))
+	if resp != nil {
+		span.SetAttributes(statusAttribute.Int(resp.StatusCode))
+		if err == nil && resp.StatusCode > 399 {
+			err = errors.New(http.StatusText(resp.StatusCode))
+		}
+	}
+	endSpan(span, err)
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,120 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"bytes"
+	"context"
+	"internal/clilog"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestTrace(t *testing.T) {
+	clilog.Init(false, false, true, true)
+
+	exported := &collectortrace.ExportTraceServiceRequest{}
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traces" || r.Header.Get("X-Api-Key") != "key" {
+			t.Errorf("unexpected export to %s", r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if err := proto.Unmarshal(body, exported); err != nil {
+			t.Errorf("invalid export: %v", err)
+		}
+	}))
+	defer collector.Close()
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", collector.URL)
+	t.Setenv("OTEL_EXPORTER_OTLP_HEADERS", "X-Api-Key=key")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	provider := otel.GetTracerProvider()
+	defer otel.SetTracerProvider(provider)
+	stopTracing, err := StartTracing(OTLP)
+	if err != nil {
+		t.Fatalf("StartTracing failed: %v", err)
+	}
+
+	ctx, endCommandSpan := StartSpan(context.Background(), "integrationcli integrations get", "sample")
+	// the api is known from the endpoint of the client, not from the hostname of the server
+	session := NewClient(IntegrationClientOptions{
+		ProjectID: "project", Region: "us-west1", MaxAttempts: 1, MaxElapsedTime: time.Minute,
+		Endpoints: Endpoints{Integrations: server.URL},
+	})
+	client := &RateLimitedHTTPClient{client: http.DefaultClient, session: session}
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, session.GetBaseIntegrationURL()+"integrations/sample", nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	resp.Body.Close()
+	endCommandSpan(nil)
+
+	if err = stopTracing(context.Background()); err != nil {
+		t.Fatalf("stopTracing failed: %v", err)
+	}
+
+	spans := map[string]*tracepb.Span{}
+	for _, rs := range exported.GetResourceSpans() {
+		for _, ss := range rs.GetScopeSpans() {
+			for _, s := range ss.GetSpans() {
+				spans[s.GetName()] = s
+			}
+		}
+	}
+	command, ok := spans["integrationcli integrations get"]
+	if !ok {
+		t.Fatalf("the command span was not exported: %+v", exported)
+	}
+	request, ok := spans["GET "+req.URL.Host]
+	if !ok {
+		t.Fatalf("the request span was not exported: %+v", exported)
+	}
+	if !bytes.Equal(request.GetParentSpanId(), command.GetSpanId()) || !bytes.Equal(request.GetTraceId(), command.GetTraceId()) {
+		t.Errorf("the request span is not a child of the command span")
+	}
+	if request.GetStatus().GetCode() != tracepb.Status_STATUS_CODE_ERROR {
+		t.Errorf("expected the request span to have an error status, got %s", request.GetStatus().GetCode())
+	}
+	attributes := map[string]interface{}{}
+	for _, a := range request.GetAttributes() {
+		if _, ok := a.GetValue().GetValue().(*commonpb.AnyValue_IntValue); ok {
+			attributes[a.GetKey()] = a.GetValue().GetIntValue()
+		} else {
+			attributes[a.GetKey()] = a.GetValue().GetStringValue()
+		}
+	}
+	if attributes["http.response.status_code"] != int64(404) || attributes["integrationcli.retry_count"] != int64(0) ||
+		attributes["integrationcli.resource"] != "/v1/projects/project/locations/us-west1/integrations/sample" ||
+		attributes["integrationcli.api"] != "integrations" {
+		t.Errorf("unexpected attributes %v", attributes)
+	}
+	if _, ok = spans["rate limiter wait"]; !ok {
+		t.Errorf("the rate limiter span was not exported")
+	}
+}
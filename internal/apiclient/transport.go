@@ -0,0 +1,170 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"internal/clilog"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// metadataHosts serve the metadata access tokens, they are never reached through a proxy
+const metadataHosts = "metadata.google.internal,169.254.169.254"
+
+// transportConfig are the options the transport is built from
+type transportConfig struct {
+	proxyURL      string
+	caBundle      string
+	clientCert    string
+	clientKey     string
+	tlsServerName string
+}
+
+// transports are shared by the clients with the same options, so connections are reused
+var transports = struct {
+	sync.Mutex
+	m map[transportConfig]*http.Transport
+}{m: map[transportConfig]*http.Transport{}}
+
+// getTransport returns the transport for the proxy, CA bundle and client certificate options
+// of the client that verifies the TLS server name, the hostname when it is empty
+func (c *Client) getTransport(tlsServerName string) (*http.Transport, error) {
+	config := transportConfig{
+		proxyURL:      c.GetProxyURL(),
+		caBundle:      c.options.CABundle,
+		clientCert:    c.options.ClientCert,
+		clientKey:     c.options.ClientKey,
+		tlsServerName: tlsServerName,
+	}
+
+	transports.Lock()
+	defer transports.Unlock()
+	if t, ok := transports.m[config]; ok {
+		return t, nil
+	}
+	t, err := newTransport(config)
+	if err != nil {
+		return nil, err
+	}
+	transports.m[config] = t
+	return t, nil
+}
+
+// endpointTransport verifies the TLS server name of the endpoints in the certificates of the
+// integrations and connectors APIs, the certificates of other hosts are verified with their hostname
+type endpointTransport struct {
+	client    *Client
+	transport *http.Transport
+}
+
+func (t *endpointTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if _, ok := t.client.serviceOf(req.URL); ok {
+		if tlsServerName := t.client.GetEndpoints().TLSServerName; tlsServerName != "" {
+			transport, err := t.client.getTransport(tlsServerName)
+			if err != nil {
+				return nil, err
+			}
+			return transport.RoundTrip(req)
+		}
+	}
+	return t.transport.RoundTrip(req)
+}
+
+func newTransport(config transportConfig) (*http.Transport, error) {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+
+	proxy, err := proxyFunc(config.proxyURL)
+	if err != nil {
+		return nil, err
+	}
+	t.Proxy = proxy
+
+	if config.caBundle == "" && config.clientCert == "" && config.clientKey == "" && config.tlsServerName == "" {
+		return t, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: config.tlsServerName,
+	}
+
+	if config.caBundle != "" {
+		pem, err := os.ReadFile(config.caBundle)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the CA bundle: %w", err)
+		}
+		// the bundle adds to the system roots, so Google endpoints are still trusted
+		if tlsConfig.RootCAs, err = x509.SystemCertPool(); err != nil || tlsConfig.RootCAs == nil {
+			tlsConfig.RootCAs = x509.NewCertPool()
+		}
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates were found in the CA bundle %s", config.caBundle)
+		}
+		clilog.Debug.Println("Trusting the certificates in ", config.caBundle)
+	}
+
+	if config.clientCert != "" || config.clientKey != "" {
+		if config.clientCert == "" || config.clientKey == "" {
+			return nil, errors.New("client-cert and client-key must be set together")
+		}
+		cert, err := tls.LoadX509KeyPair(config.clientCert, config.clientKey)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load the client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+		clilog.Debug.Println("Presenting the client certificate in ", config.clientCert)
+	}
+
+	t.TLSClientConfig = tlsConfig
+	return t, nil
+}
+
+// proxyFunc returns the proxy for each request. The proxy url in the preferences takes
+// precedence over HTTPS_PROXY and HTTP_PROXY, hosts in NO_PROXY are not proxied in both cases
+func proxyFunc(proxyURL string) (func(*http.Request) (*url.URL, error), error) {
+	config := httpproxy.FromEnvironment()
+	if proxyURL != "" {
+		u, err := url.Parse(proxyURL)
+		if err != nil || u.Host == "" {
+			return nil, errors.New("invalid proxy url in the preferences, it must include the scheme and host")
+		}
+		config.HTTPProxy = proxyURL
+		config.HTTPSProxy = proxyURL
+		clilog.Debug.Println("Using the proxy ", u.Redacted())
+	}
+	if config.HTTPProxy == "" && config.HTTPSProxy == "" {
+		return nil, nil
+	}
+
+	noProxy := []string{metadataHosts}
+	if config.NoProxy != "" {
+		noProxy = append(noProxy, config.NoProxy)
+	}
+	config.NoProxy = strings.Join(noProxy, ",")
+
+	proxy := config.ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxy(req.URL)
+	}, nil
+}
@@ -0,0 +1,185 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"internal/clilog"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestProxy(t *testing.T) {
+	clilog.Init(false, false, true, true)
+	t.Setenv("HTTPS_PROXY", "http://env-proxy:3128")
+	t.Setenv("NO_PROXY", "internal.example.com")
+
+	tests := []struct {
+		proxyURL string
+		target   string
+		expected string
+	}{
+		{"", "https://integrations.googleapis.com/v1", "http://env-proxy:3128"},
+		{"http://pref-proxy:8080", "https://integrations.googleapis.com/v1", "http://pref-proxy:8080"},
+		{"http://pref-proxy:8080", "https://integrations.internal.example.com/v1", ""},
+		{"http://pref-proxy:8080", "http://metadata.google.internal/computeMetadata/v1", ""},
+	}
+	for _, test := range tests {
+		proxy, err := proxyFunc(test.proxyURL)
+		if err != nil {
+			t.Fatalf("proxyFunc(%s) failed: %v", test.proxyURL, err)
+		}
+		req, _ := http.NewRequest(http.MethodGet, test.target, nil)
+		u, err := proxy(req)
+		if err != nil {
+			t.Fatalf("proxy(%s) failed: %v", test.target, err)
+		}
+		var actual string
+		if u != nil {
+			actual = u.String()
+		}
+		if actual != test.expected {
+			t.Errorf("proxy for %s with %q = %v, expected %q", test.target, test.proxyURL, u, test.expected)
+		}
+	}
+
+	if _, err := proxyFunc("pref-proxy"); err == nil {
+		t.Errorf("expected an error for a proxy url without a scheme")
+	}
+}
+
+func TestTLS(t *testing.T) {
+	clilog.Init(false, false, true, true)
+	folder := t.TempDir()
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	caBundle := filepath.Join(folder, "ca.pem")
+	writePEM(t, caBundle, "CERTIFICATE", server.Certificate().Raw)
+	clientCert, clientKey := filepath.Join(folder, "client.pem"), filepath.Join(folder, "client.key")
+	writeClientCertificate(t, clientCert, clientKey)
+
+	// the test server is the integrations endpoint
+	get := func(o IntegrationClientOptions) error {
+		o.ProjectID, o.Region = "project", "us-west1"
+		if o.Endpoints.Integrations == "" {
+			o.Endpoints.Integrations = server.URL
+		}
+		c := NewClient(o)
+		httpClient, err := c.newHTTPClient(true)
+		if err != nil {
+			return err
+		}
+		resp, err := httpClient.Get(c.GetBaseIntegrationURL())
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	if err := get(IntegrationClientOptions{}); err == nil {
+		t.Errorf("expected the certificate of the server to be untrusted without the CA bundle")
+	}
+	if err := get(IntegrationClientOptions{CABundle: caBundle}); err == nil {
+		t.Errorf("expected the server to require a client certificate")
+	}
+	if err := get(IntegrationClientOptions{CABundle: caBundle, ClientCert: clientCert, ClientKey: clientKey}); err != nil {
+		t.Errorf("request with the CA bundle and client certificate failed: %v", err)
+	}
+	// the certificate of the test server is valid for example.com
+	if err := get(IntegrationClientOptions{
+		CABundle: caBundle, ClientCert: clientCert, ClientKey: clientKey,
+		TLSServerName: "example.com",
+	}); err != nil {
+		t.Errorf("request with the TLS server name failed: %v", err)
+	}
+	if err := get(IntegrationClientOptions{
+		CABundle: caBundle, ClientCert: clientCert, ClientKey: clientKey,
+		TLSServerName: "integrations.example.net",
+	}); err == nil {
+		t.Errorf("expected the certificate to be rejected for another TLS server name")
+	}
+	// the TLS server name of the endpoint set takes precedence
+	if err := get(IntegrationClientOptions{
+		Api: "psc", CABundle: caBundle, ClientCert: clientCert, ClientKey: clientKey,
+		TLSServerName: "integrations.example.net",
+		EndpointSets:  map[string]Endpoints{"psc": {Integrations: server.URL, TLSServerName: "example.com"}},
+	}); err != nil {
+		t.Errorf("request with the TLS server name of the endpoint set failed: %v", err)
+	}
+	// the TLS server name is not verified for the hosts of other APIs
+	c := NewClient(IntegrationClientOptions{
+		CABundle: caBundle, ClientCert: clientCert, ClientKey: clientKey,
+		TLSServerName: "integrations.example.net",
+	})
+	httpClient, err := c.newHTTPClient(true)
+	if err != nil {
+		t.Fatalf("newHTTPClient failed: %v", err)
+	}
+	if resp, err := httpClient.Get(server.URL); err != nil {
+		t.Errorf("expected the TLS server name to be ignored for another host: %v", err)
+	} else {
+		resp.Body.Close()
+	}
+	if err := get(IntegrationClientOptions{CABundle: caBundle, ClientCert: clientCert}); err == nil {
+		t.Errorf("expected an error when the client key is missing")
+	}
+}
+
+func writePEM(t *testing.T, name string, blockType string, der []byte) {
+	if err := os.WriteFile(name, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatalf("unable to write %s: %v", name, err)
+	}
+}
+
+func writeClientCertificate(t *testing.T, certFile string, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate a key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "integrationcli"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("unable to create a certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("unable to marshal the key: %v", err)
+	}
+	writePEM(t, certFile, "CERTIFICATE", der)
+	writePEM(t, keyFile, "EC PRIVATE KEY", keyDER)
+}
@@ -100,37 +100,38 @@ type oauth2ResourceOwnerCredentials struct {
 }
 
 // Create
-func Create(content []byte) (respBody []byte, err error) {
+func Create(client *apiclient.Client, content []byte) (respBody []byte, err error) {
 	c := authConfig{}
 
 	if err = json.Unmarshal(content, &c); err != nil {
 		return nil, err
 	}
 
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 
 	u.Path = path.Join(u.Path, "authConfigs")
-	respBody, err = apiclient.HttpClient(u.String(), string(content))
+	respBody, err = client.HttpClient(u.String(), string(content))
 	return respBody, err
 }
 
 // Delete
-func Delete(name string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+func Delete(client *apiclient.Client, name string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	u.Path = path.Join(u.Path, "authConfigs", name)
-	respBody, err = apiclient.HttpClient(u.String(), "", "DELETE")
+	respBody, err = client.HttpClient(u.String(), "", "DELETE")
 	return respBody, err
 }
 
 // Get
-func Get(name string, minimal bool) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+func Get(client *apiclient.Client, name string, minimal bool) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	u.Path = path.Join(u.Path, "authConfigs", name)
 
+	httpClient := client
 	if minimal {
-		apiclient.ClientPrintHttpResponse.Set(false)
+		httpClient = client.WithoutOutput()
 	}
-	respBody, err = apiclient.HttpClient(u.String())
+	respBody, err = httpClient.HttpClient(u.String())
 	if minimal {
 		iversion := authConfig{}
 		err := json.Unmarshal(respBody, &iversion)
@@ -142,21 +143,19 @@ func Get(name string, minimal bool) (respBody []byte, err error) {
 		if err != nil {
 			return nil, err
 		}
-		apiclient.PrettyPrint(respBody)
+		client.PrettyPrint(respBody)
 	}
-	apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
 	return respBody, err
 }
 
 // GetDisplayName
-func GetDisplayName(name string) (displayName string, err error) {
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+func GetDisplayName(client *apiclient.Client, name string) (displayName string, err error) {
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	u.Path = path.Join(u.Path, "authConfigs", name)
 
-	apiclient.ClientPrintHttpResponse.Set(false)
-	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+	client = client.WithoutOutput()
 
-	respBody, err := apiclient.HttpClient(u.String())
+	respBody, err := client.HttpClient(u.String())
 	if err != nil {
 		return "", err
 	}
@@ -171,8 +170,8 @@ func GetDisplayName(name string) (displayName string, err error) {
 }
 
 // List
-func List(pageSize int, pageToken string, filter string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+func List(client *apiclient.Client, pageSize int, pageToken string, filter string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	q := u.Query()
 	if pageSize != -1 {
 		q.Set("pageSize", strconv.Itoa(pageSize))
@@ -186,16 +185,24 @@ func List(pageSize int, pageToken string, filter string) (respBody []byte, err e
 
 	u.RawQuery = q.Encode()
 	u.Path = path.Join(u.Path, "authConfigs")
-	respBody, err = apiclient.HttpClient(u.String())
+	respBody, err = client.HttpClient(u.String())
 	return respBody, err
 }
 
+// ListAll lists the authConfigs of every page, with a limit greater than 0
+// no more than limit authConfigs are returned
+func ListAll(client *apiclient.Client, pageSize int, filter string, limit int) (respBody []byte, err error) {
+	return client.ListAll(func(client *apiclient.Client, pageToken string, pageSize int) ([]byte, error) {
+		return List(client, pageSize, pageToken, filter)
+	}, pageSize, limit)
+}
+
 // Find
-func Find(name string, pageToken string) (version string, err error) {
+func Find(client *apiclient.Client, name string, pageToken string) (version string, err error) {
 	ac := authConfigs{}
 	var respBody []byte
 
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	if pageToken != "" {
 		q := u.Query()
 		q.Set("pageToken", pageToken)
@@ -203,7 +210,7 @@ func Find(name string, pageToken string) (version string, err error) {
 	}
 
 	u.Path = path.Join(u.Path, "authConfigs")
-	if respBody, err = apiclient.HttpClient(u.String()); err != nil {
+	if respBody, err = client.HttpClient(u.String()); err != nil {
 		return "", err
 	}
 
@@ -218,66 +225,40 @@ func Find(name string, pageToken string) (version string, err error) {
 		}
 	}
 	if ac.NextPageToken != "" {
-		return Find(name, ac.NextPageToken)
+		return Find(client, name, ac.NextPageToken)
 	}
 	return "", fmt.Errorf("authConfig not found")
 }
 
 // Export
-func Export(folder string) (err error) {
-	var respBody []byte
-	count := 1
-
-	apiclient.ClientPrintHttpResponse.Set(false)
-	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
-
-	apiclient.SetExportToFile(folder)
-
-	if respBody, err = List(100, "", ""); err != nil {
-		return err
-	}
-
-	fileName := "authconfigs_" + strconv.Itoa(count) + ".json"
-	if err = apiclient.WriteByteArrayToFile(path.Join(apiclient.GetExportToFile(), fileName), false, respBody); err != nil {
-		clilog.Error.Println(err)
-		return err
-	}
-	clilog.Info.Printf("Downloaded %s\n", fileName)
+func Export(client *apiclient.Client, folder string) (err error) {
+	count := 0
 
-	aconfigs := authConfigs{}
-	if err = json.Unmarshal(respBody, &aconfigs); err != nil {
-		return err
-	}
+	client = client.WithoutOutput()
 
-	for aconfigs.NextPageToken != "" {
-
-		if respBody, err = List(100, "", ""); err != nil {
-			return err
-		}
-
-		if err = json.Unmarshal(respBody, &aconfigs); err != nil {
-			return err
-		}
+	client = client.WithExportToFile(folder)
 
+	return client.ForEachPage(func(client *apiclient.Client, pageToken string) ([]byte, error) {
+		return List(client, 100, pageToken, "")
+	}, func(respBody []byte) error {
 		count++
 		fileName := "authconfigs_" + strconv.Itoa(count) + ".json"
-		if err = apiclient.WriteByteArrayToFile(path.Join(apiclient.GetExportToFile(), fileName), false, respBody); err != nil {
+		if err := apiclient.WriteByteArrayToFile(path.Join(client.GetExportToFile(), fileName), false, respBody); err != nil {
 			clilog.Error.Println(err)
 			return err
 		}
 		clilog.Info.Printf("Downloaded %s\n", fileName)
-	}
-
-	return nil
+		return nil
+	})
 }
 
-func Patch(name string, content []byte, updateMask []string) (respBody []byte, err error) {
+func Patch(client *apiclient.Client, name string, content []byte, updateMask []string) (respBody []byte, err error) {
 	a := authConfig{}
 	if err = json.Unmarshal(content, &a); err != nil {
 		return nil, err
 	}
 
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 
 	if len(updateMask) != 0 {
 		updates := strings.Join(updateMask, ",")
@@ -288,7 +269,7 @@ func Patch(name string, content []byte, updateMask []string) (respBody []byte, e
 
 	u.Path = path.Join(u.Path, "authConfigs", name)
 
-	return apiclient.HttpClient(u.String(), string(content), "PATCH")
+	return client.HttpClient(u.String(), string(content), "PATCH")
 }
 
 // convertInternalToExternal
@@ -15,6 +15,8 @@
 package authconfigs
 
 import (
+	"encoding/json"
+	"internal/apiclient"
 	"internal/client/clienttest"
 	"internal/cmd/utils"
 	"os"
@@ -22,67 +24,117 @@ import (
 	"testing"
 )
 
-var cliPath = os.Getenv("INTEGRATIONCLI_PATH")
+// createSample creates the authConfig of test/ac_username.json
+func createSample(t *testing.T, client *apiclient.Client) []byte {
+	contents, err := utils.ReadFile(path.Join("..", "..", "..", "test", "ac_username.json"))
+	if err != nil {
+		t.Fatalf("unable to read authConfig failed: %v", err)
+	}
+	respBody, err := Create(client, contents)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	return respBody
+}
 
-var authConfigID string
+// setupSample starts a fake server with the sample authConfig and returns its id
+func setupSample(t *testing.T) (*apiclient.Client, string) {
+	_, client := clienttest.FakeSetup(t)
+	createSample(t, client)
+	authConfigID, err := Find(client, "authconfig-sample", "")
+	if err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	return client, authConfigID
+}
 
 func TestCreate(t *testing.T) {
-	var contents []byte
-	if err := clienttest.TestSetup(); err != nil {
-		t.Fatalf("TestSetup failed: %v", err)
+	server, client := clienttest.FakeSetup(t)
+	created := authConfig{}
+	if err := json.Unmarshal(createSample(t, client), &created); err != nil {
+		t.Fatalf("unable to parse authConfig: %v", err)
 	}
-	contents, err := utils.ReadFile(path.Join(cliPath, "test", "ac_username.json"))
-	if err != nil {
-		t.Fatalf("unable to read authConfig failed: %v", err)
-	}
-	if _, err := Create(contents); err != nil {
-		t.Fatalf("TestCreate failed: %v", err)
+	if _, ok := server.Get(created.Name); !ok {
+		t.Errorf("expected the authConfig %s to be stored", created.Name)
 	}
 }
 
 func TestFind(t *testing.T) {
-	var err error
-	if err = clienttest.TestSetup(); err != nil {
-		t.Fatalf("TestSetup failed: %v", err)
-	}
-	authConfigID, err = Find("authconfig-sample", "")
-	if err != nil {
-		t.Fatalf("TestFind failed: %v", err)
+	client, _ := setupSample(t)
+	if _, err := Find(client, "unknown", ""); err == nil {
+		t.Errorf("expected an error for an unknown authConfig")
 	}
 }
 
 func TestGet(t *testing.T) {
-	if err := clienttest.TestSetup(); err != nil {
-		t.Fatalf("TestSetup failed: %v", err)
-	}
-	if _, err := Get(authConfigID, false); err != nil {
-		t.Fatalf("Get failed: %v", err)
+	client, authConfigID := setupSample(t)
+	for _, minimal := range []bool{false, true} {
+		if _, err := Get(client, authConfigID, minimal); err != nil {
+			t.Fatalf("Get with minimal %t failed: %v", minimal, err)
+		}
 	}
 }
 
 func TestGetDisplayName(t *testing.T) {
-	if err := clienttest.TestSetup(); err != nil {
-		t.Fatalf("TestSetup failed: %v", err)
+	client, authConfigID := setupSample(t)
+	displayName, err := GetDisplayName(client, authConfigID)
+	if err != nil {
+		t.Fatalf("GetDisplayName failed: %v", err)
 	}
-	if _, err := GetDisplayName("authconfig-sample"); err != nil {
-		t.Fatalf("Get failed: %v", err)
+	if displayName != "authconfig-sample" {
+		t.Errorf("expected the display name authconfig-sample, got %s", displayName)
 	}
 }
 
 func TestList(t *testing.T) {
-	if err := clienttest.TestSetup(); err != nil {
-		t.Fatalf("TestSetup failed: %v", err)
-	}
-	if _, err := List(-1, "", ""); err != nil {
+	client, _ := setupSample(t)
+	respBody, err := List(client, -1, "", "")
+	if err != nil {
 		t.Fatalf("List failed: %v", err)
 	}
+	aconfigs := authConfigs{}
+	if err = json.Unmarshal(respBody, &aconfigs); err != nil {
+		t.Fatalf("unable to parse authConfigs: %v", err)
+	}
+	if len(aconfigs.AuthConfig) != 1 {
+		t.Errorf("expected 1 authConfig, got %d", len(aconfigs.AuthConfig))
+	}
 }
 
 func TestDelete(t *testing.T) {
-	if err := clienttest.TestSetup(); err != nil {
-		t.Fatalf("TestSetup failed: %v", err)
-	}
-	if _, err := Delete(authConfigID); err != nil {
+	client, authConfigID := setupSample(t)
+	if _, err := Delete(client, authConfigID); err != nil {
 		t.Fatalf("Delete failed: %v", err)
 	}
+	if _, err := Find(client, "authconfig-sample", ""); err == nil {
+		t.Errorf("expected an error for a deleted authConfig")
+	}
+}
+
+func TestExport(t *testing.T) {
+	_, client := clienttest.FakeSetup(t)
+	// Export requests pages of 100 authConfigs
+	for i := 0; i < 101; i++ {
+		createSample(t, client)
+	}
+
+	respBody, err := ListAll(client, 40, "", 0)
+	if err != nil {
+		t.Fatalf("ListAll failed: %v", err)
+	}
+	aconfigs := authConfigs{}
+	if err = json.Unmarshal(respBody, &aconfigs); err != nil {
+		t.Fatalf("unable to parse authConfigs: %v", err)
+	}
+	if len(aconfigs.AuthConfig) != 101 || aconfigs.NextPageToken != "" {
+		t.Errorf("expected 101 authConfigs without a page token, got %d", len(aconfigs.AuthConfig))
+	}
+
+	folder := t.TempDir()
+	if err = Export(client, folder); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if files, _ := os.ReadDir(folder); len(files) != 2 {
+		t.Errorf("expected 2 exported pages, got %d", len(files))
+	}
 }
@@ -40,8 +40,8 @@ type cert struct {
 }
 
 // Create
-func Create(displayName string, description string, sslCertificate string, privateKey string, passphrase string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+func Create(client *apiclient.Client, displayName string, description string, sslCertificate string, privateKey string, passphrase string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	certStr := []string{}
 	rawCertStr := []string{}
 
@@ -63,13 +63,13 @@ func Create(displayName string, description string, sslCertificate string, priva
 	u.Path = path.Join(u.Path, "certificates")
 
 	payload := "{" + strings.Join(certStr, ",") + "}"
-	respBody, err = apiclient.HttpClient(u.String(), payload)
+	respBody, err = client.HttpClient(u.String(), payload)
 	return respBody, err
 }
 
 // List
-func List(pageSize int, pageToken string, filter string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+func List(client *apiclient.Client, pageSize int, pageToken string, filter string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	q := u.Query()
 	if pageSize != -1 {
 		q.Set("pageSize", strconv.Itoa(pageSize))
@@ -83,35 +83,35 @@ func List(pageSize int, pageToken string, filter string) (respBody []byte, err e
 
 	u.RawQuery = q.Encode()
 	u.Path = path.Join(u.Path, "certificates")
-	respBody, err = apiclient.HttpClient(u.String())
+	respBody, err = client.HttpClient(u.String())
 	return respBody, err
 }
 
 // Delete
-func Delete(name string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+func Delete(client *apiclient.Client, name string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	u.Path = path.Join(u.Path, "certificates", name)
-	respBody, err = apiclient.HttpClient(u.String(), "", "DELETE")
+	respBody, err = client.HttpClient(u.String(), "", "DELETE")
 	return respBody, err
 }
 
 // Get
-func Get(name string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+func Get(client *apiclient.Client, name string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	u.Path = path.Join(u.Path, "certificates", name)
-	respBody, err = apiclient.HttpClient(u.String())
+	respBody, err = client.HttpClient(u.String())
 	return respBody, err
 }
 
 // Find
-func Find(name string) (version string, err error) {
+func Find(client *apiclient.Client, name string) (version string, err error) {
 	cs := certs{}
 	var respBody []byte
 
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 
 	u.Path = path.Join(u.Path, "certificates")
-	if respBody, err = apiclient.HttpClient(u.String()); err != nil {
+	if respBody, err = client.HttpClient(u.String()); err != nil {
 		return "", err
 	}
 
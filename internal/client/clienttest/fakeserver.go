@@ -0,0 +1,527 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clienttest
+
+import (
+	"encoding/json"
+	"fmt"
+	"internal/apiclient"
+	"internal/clilog"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fake project and region used by FakeSetup
+const (
+	FakeProjectID = "fake-project"
+	FakeRegion    = "us-central1"
+)
+
+// FakeServer is an in-memory implementation of the integrations and connectors APIs.
+// Integrations, versions, testCases, authConfigs, connections, operations, sfdcInstances
+// and any other collection below a location are stored by resource name
+type FakeServer struct {
+	*httptest.Server
+	resources map[string]*fakeResource
+	sequence  int
+	sync.Mutex
+}
+
+type fakeResource struct {
+	sequence int
+	data     map[string]interface{}
+}
+
+// NewFakeServer starts a fake server. Close it when the test completes
+func NewFakeServer() *FakeServer {
+	f := &FakeServer{resources: make(map[string]*fakeResource)}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serveHTTP))
+	return f
+}
+
+// FakeSetup starts a fake server that is closed when the test completes and returns a
+// client that sends its requests to the server
+func FakeSetup(t testing.TB) (*FakeServer, *apiclient.Client) {
+	clilog.Init(false, false, true, false)
+
+	f := NewFakeServer()
+	t.Cleanup(f.Close)
+
+	client := apiclient.NewClient(apiclient.IntegrationClientOptions{
+		ProjectID:          FakeProjectID,
+		Region:             FakeRegion,
+		Token:              "fake-token",
+		TokenCheck:         false,
+		SkipCache:          true,
+		NoOutput:           true,
+		ConflictsAreErrors: true,
+	})
+	client.SetAPIEndpoint(f.URL)
+	return f, client
+}
+
+// Get returns a copy of the resource stored with the name, such as
+// projects/p/locations/l/authConfigs/id
+func (f *FakeServer) Get(name string) (map[string]interface{}, bool) {
+	f.Lock()
+	defer f.Unlock()
+	r, ok := f.resources[name]
+	if !ok {
+		return nil, false
+	}
+	return copyResource(r.data), true
+}
+
+// Put stores a resource with the name, to seed the server before a test
+func (f *FakeServer) Put(name string, resource map[string]interface{}) {
+	f.Lock()
+	defer f.Unlock()
+	resource = copyResource(resource)
+	resource["name"] = name
+	f.store(name, resource)
+}
+
+func (f *FakeServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var payload map[string]interface{}
+	if len(strings.TrimSpace(string(body))) > 0 {
+		if err = json.Unmarshal(body, &payload); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid json payload: "+err.Error())
+			return
+		}
+	}
+	if payload == nil {
+		payload = make(map[string]interface{})
+	}
+
+	// custom methods are sent as name:action or name/:action
+	p := strings.Trim(strings.TrimPrefix(r.URL.Path, "/v1/"), "/")
+	p = strings.ReplaceAll(p, "/:", ":")
+	action := ""
+	if i := strings.LastIndex(p, ":"); i > strings.LastIndex(p, "/") {
+		p, action = p[:i], p[i+1:]
+	}
+
+	segments := strings.Split(p, "/")
+	if len(segments) < 5 || segments[0] != "projects" || segments[2] != "locations" {
+		writeError(w, http.StatusNotFound, "unknown resource "+r.URL.Path)
+		return
+	}
+	// the collection is the last segment for collections and the one before it for resources
+	collection := segments[len(segments)-1]
+	isResource := len(segments)%2 == 0
+	if isResource {
+		collection = segments[len(segments)-2]
+	}
+
+	var status int
+	var resp interface{}
+	switch {
+	case collection == "integrations":
+		status, resp = f.integrations(r, p, isResource)
+	case collection == "versions":
+		status, resp = f.versions(r, p, isResource, action, payload)
+	case collection == "operations":
+		status, resp = f.operations(r, p, isResource, action)
+	case collection == "connections":
+		status, resp = f.connections(r, p, isResource, payload)
+	case action == "executeTest":
+		status, resp = http.StatusOK, map[string]interface{}{"testExecutionState": "PASSED"}
+	default:
+		status, resp = f.crud(r, p, isResource, payload)
+	}
+
+	if status >= 400 {
+		writeError(w, status, fmt.Sprint(resp))
+		return
+	}
+	// like the APIs, responses do not end with a new line
+	b, _ := json.Marshal(resp)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(b)
+}
+
+// crud creates, lists, reads, updates and deletes resources of any collection
+func (f *FakeServer) crud(r *http.Request, p string, isResource bool, payload map[string]interface{}) (int, interface{}) {
+	if isResource {
+		switch r.Method {
+		case http.MethodGet:
+			return f.get(p)
+		case http.MethodPatch, http.MethodPut:
+			resource, ok := f.resources[p]
+			if !ok {
+				return http.StatusNotFound, p + " not found"
+			}
+			for key, value := range payload {
+				resource.data[key] = value
+			}
+			resource.data["name"] = p
+			resource.data["updateTime"] = now()
+			return http.StatusOK, resource.data
+		case http.MethodDelete:
+			if _, ok := f.resources[p]; !ok {
+				return http.StatusNotFound, p + " not found"
+			}
+			f.delete(p)
+			return http.StatusOK, map[string]interface{}{}
+		}
+		return http.StatusMethodNotAllowed, r.Method + " is not supported for " + p
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		return f.list(r, p, nil)
+	case http.MethodPost:
+		return http.StatusOK, f.create(r, p, payload)
+	}
+	return http.StatusMethodNotAllowed, r.Method + " is not supported for " + p
+}
+
+// integrations lists the integrations that have versions and deletes integrations
+func (f *FakeServer) integrations(r *http.Request, p string, isResource bool) (int, interface{}) {
+	if isResource {
+		if r.Method != http.MethodDelete {
+			return http.StatusMethodNotAllowed, r.Method + " is not supported for " + p
+		}
+		if len(f.children(p+"/versions")) == 0 {
+			return http.StatusNotFound, p + " not found"
+		}
+		f.delete(p)
+		return http.StatusOK, map[string]interface{}{}
+	}
+	if r.Method != http.MethodGet {
+		return http.StatusMethodNotAllowed, r.Method + " is not supported for " + p
+	}
+
+	names := make(map[string]bool)
+	for name := range f.resources {
+		if strings.HasPrefix(name, p+"/") && strings.Count(strings.TrimPrefix(name, p+"/"), "/") == 2 {
+			names[strings.SplitN(strings.TrimPrefix(name, p+"/"), "/", 2)[0]] = true
+		}
+	}
+	var integrations []interface{}
+	for name := range names {
+		integrations = append(integrations, map[string]interface{}{"name": p + "/" + name, "active": f.hasActive(p + "/" + name)})
+	}
+	sort.Slice(integrations, func(i, j int) bool {
+		return integrations[i].(map[string]interface{})["name"].(string) < integrations[j].(map[string]interface{})["name"].(string)
+	})
+	return paginate(r, "integrations", integrations)
+}
+
+// versions implements integration versions and their custom methods
+func (f *FakeServer) versions(r *http.Request, p string, isResource bool, action string,
+	payload map[string]interface{},
+) (int, interface{}) {
+	if !isResource {
+		switch {
+		case r.Method == http.MethodGet:
+			return f.list(r, p, func(a, b map[string]interface{}) bool {
+				return toInt(a["snapshotNumber"]) > toInt(b["snapshotNumber"])
+			})
+		case r.Method == http.MethodPost && action == "upload":
+			content, _ := payload["content"].(string)
+			version := make(map[string]interface{})
+			if err := json.Unmarshal([]byte(content), &version); err != nil {
+				return http.StatusBadRequest, "invalid content: " + err.Error()
+			}
+			return http.StatusOK, map[string]interface{}{"integrationVersion": f.createVersion(p, version)}
+		case r.Method == http.MethodPost && action == "":
+			return http.StatusOK, f.createVersion(p, payload)
+		}
+		return http.StatusMethodNotAllowed, r.Method + " is not supported for " + p
+	}
+
+	resource, ok := f.resources[p]
+	if !ok {
+		return http.StatusNotFound, p + " not found"
+	}
+	switch action {
+	case "":
+		if r.Method == http.MethodPost {
+			// take over the edit lock
+			return http.StatusOK, resource.data
+		}
+		return f.crud(r, p, true, payload)
+	case "download":
+		content, _ := json.Marshal(resource.data)
+		return http.StatusOK, map[string]interface{}{"content": string(content)}
+	case "publish":
+		parent := p[:strings.LastIndex(p, "/")]
+		for _, other := range f.children(parent) {
+			if other.data["state"] == "ACTIVE" {
+				other.data["state"] = "SNAPSHOT"
+			}
+		}
+		resource.data["state"] = "ACTIVE"
+		if configParameters, ok := payload["configParameters"]; ok {
+			resource.data["integrationConfigParameters"] = configParameters
+		}
+	case "unpublish", "deactivate":
+		resource.data["state"] = "SNAPSHOT"
+	case "archive":
+		resource.data["state"] = "ARCHIVED"
+	default:
+		return http.StatusBadRequest, "unsupported method " + action
+	}
+	resource.data["updateTime"] = now()
+	return http.StatusOK, map[string]interface{}{}
+}
+
+func (f *FakeServer) createVersion(parent string, version map[string]interface{}) map[string]interface{} {
+	snapshotNumber := 0
+	for _, other := range f.children(parent) {
+		if n := toInt(other.data["snapshotNumber"]); n > snapshotNumber {
+			snapshotNumber = n
+		}
+	}
+	version = copyResource(version)
+	name := parent + "/" + uuid.NewString()
+	version["name"] = name
+	if toInt(version["snapshotNumber"]) == 0 {
+		version["snapshotNumber"] = strconv.Itoa(snapshotNumber + 1)
+	}
+	version["state"] = "DRAFT"
+	version["createTime"] = now()
+	version["updateTime"] = now()
+	f.store(name, version)
+	return version
+}
+
+func (f *FakeServer) hasActive(integration string) bool {
+	for _, version := range f.children(integration + "/versions") {
+		if version.data["state"] == "ACTIVE" {
+			return true
+		}
+	}
+	return false
+}
+
+// connections are created and deleted with long running operations that are done immediately
+func (f *FakeServer) connections(r *http.Request, p string, isResource bool, payload map[string]interface{}) (int, interface{}) {
+	switch {
+	case !isResource && r.Method == http.MethodPost:
+		connection := f.create(r, p, payload)
+		connection["status"] = map[string]interface{}{"state": "ACTIVE"}
+		return http.StatusOK, f.operation(p, connection)
+	case isResource && r.Method == http.MethodDelete:
+		status, resp := f.crud(r, p, true, payload)
+		if status != http.StatusOK {
+			return status, resp
+		}
+		return http.StatusOK, f.operation(p[:strings.LastIndex(p, "/")], map[string]interface{}{})
+	case isResource && r.Method == http.MethodPatch:
+		status, resp := f.crud(r, p, true, payload)
+		if status != http.StatusOK {
+			return status, resp
+		}
+		return http.StatusOK, f.operation(p[:strings.LastIndex(p, "/")], resp.(map[string]interface{}))
+	}
+	return f.crud(r, p, isResource, payload)
+}
+
+// operation stores a completed long running operation for the collection
+func (f *FakeServer) operation(collection string, response map[string]interface{}) map[string]interface{} {
+	location := strings.Join(strings.Split(collection, "/")[:4], "/")
+	name := location + "/operations/operation-" + uuid.NewString()
+	operation := map[string]interface{}{
+		"name":     name,
+		"done":     true,
+		"response": response,
+		"metadata": map[string]interface{}{"createTime": now(), "endTime": now()},
+	}
+	f.store(name, operation)
+	return operation
+}
+
+func (f *FakeServer) operations(r *http.Request, p string, isResource bool, action string) (int, interface{}) {
+	if action == "cancel" {
+		if _, ok := f.resources[p]; !ok {
+			return http.StatusNotFound, p + " not found"
+		}
+		return http.StatusOK, map[string]interface{}{}
+	}
+	if r.Method != http.MethodGet {
+		return http.StatusMethodNotAllowed, r.Method + " is not supported for " + p
+	}
+	if isResource {
+		return f.get(p)
+	}
+	return f.list(r, p, nil)
+}
+
+func (f *FakeServer) get(name string) (int, interface{}) {
+	resource, ok := f.resources[name]
+	if !ok {
+		return http.StatusNotFound, name + " not found"
+	}
+	return http.StatusOK, resource.data
+}
+
+// create stores a resource. The id is read from the <collection>Id query parameter,
+// such as connectionId, or generated
+func (f *FakeServer) create(r *http.Request, collection string, payload map[string]interface{}) map[string]interface{} {
+	kind := collection[strings.LastIndex(collection, "/")+1:]
+	id := r.URL.Query().Get(strings.TrimSuffix(kind, "s") + "Id")
+	if id == "" {
+		id = uuid.NewString()
+	}
+	resource := copyResource(payload)
+	resource["name"] = collection + "/" + id
+	resource["createTime"] = now()
+	resource["updateTime"] = now()
+	f.store(collection+"/"+id, resource)
+	return resource
+}
+
+// list returns the resources of the collection that match the filter
+func (f *FakeServer) list(r *http.Request, collection string, less func(a, b map[string]interface{}) bool) (int, interface{}) {
+	filter := parseFilter(r.URL.Query().Get("filter"))
+
+	children := f.children(collection)
+	if less != nil {
+		sort.SliceStable(children, func(i, j int) bool { return less(children[i].data, children[j].data) })
+	}
+	var items []interface{}
+	for _, child := range children {
+		if matchesFilter(child.data, filter) {
+			items = append(items, child.data)
+		}
+	}
+
+	kind := collection[strings.LastIndex(collection, "/")+1:]
+	if kind == "versions" {
+		kind = "integrationVersions"
+	}
+	return paginate(r, kind, items)
+}
+
+// children returns the direct children of a collection in creation order
+func (f *FakeServer) children(collection string) []*fakeResource {
+	var children []*fakeResource
+	for name, resource := range f.resources {
+		if strings.HasPrefix(name, collection+"/") && !strings.Contains(strings.TrimPrefix(name, collection+"/"), "/") {
+			children = append(children, resource)
+		}
+	}
+	sort.Slice(children, func(i, j int) bool { return children[i].sequence < children[j].sequence })
+	return children
+}
+
+func (f *FakeServer) store(name string, data map[string]interface{}) {
+	if existing, ok := f.resources[name]; ok {
+		existing.data = data
+		return
+	}
+	f.sequence++
+	f.resources[name] = &fakeResource{sequence: f.sequence, data: data}
+}
+
+// delete removes the resource and its children
+func (f *FakeServer) delete(name string) {
+	for key := range f.resources {
+		if key == name || strings.HasPrefix(key, name+"/") {
+			delete(f.resources, key)
+		}
+	}
+}
+
+// paginate returns a page of items. Like the APIs, an empty list is returned as {}
+func paginate(r *http.Request, kind string, items []interface{}) (int, interface{}) {
+	start, _ := strconv.Atoi(r.URL.Query().Get("pageToken"))
+	if start < 0 || start > len(items) {
+		return http.StatusBadRequest, "invalid page token"
+	}
+	end := len(items)
+	if pageSize, err := strconv.Atoi(r.URL.Query().Get("pageSize")); err == nil && pageSize > 0 && start+pageSize < end {
+		end = start + pageSize
+	}
+
+	resp := make(map[string]interface{})
+	if end > start {
+		resp[kind] = items[start:end]
+	}
+	if end < len(items) {
+		resp["nextPageToken"] = strconv.Itoa(end)
+	}
+	return http.StatusOK, resp
+}
+
+// parseFilter reads filters such as state=ACTIVE AND userLabel="prod"
+func parseFilter(filter string) map[string]string {
+	conditions := make(map[string]string)
+	for _, condition := range strings.Split(filter, " AND ") {
+		key, value, ok := strings.Cut(condition, "=")
+		if !ok {
+			continue
+		}
+		conditions[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), "\"")
+	}
+	return conditions
+}
+
+func matchesFilter(resource map[string]interface{}, conditions map[string]string) bool {
+	for key, value := range conditions {
+		if fmt.Sprint(resource[key]) != value {
+			return false
+		}
+	}
+	return true
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    status,
+			"message": message,
+			"status":  strings.ToUpper(strings.ReplaceAll(http.StatusText(status), " ", "_")),
+		},
+	})
+}
+
+func copyResource(resource map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{})
+	b, _ := json.Marshal(resource)
+	_ = json.Unmarshal(b, &copied)
+	return copied
+}
+
+func toInt(v interface{}) int {
+	n, _ := strconv.Atoi(fmt.Sprint(v))
+	return n
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339Nano)
+}
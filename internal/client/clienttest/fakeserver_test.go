@@ -0,0 +1,82 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clienttest
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestFakeServer(t *testing.T) {
+	server, client := FakeSetup(t)
+
+	if !strings.HasPrefix(client.GetBaseConnectorURL(), server.URL) {
+		t.Fatalf("expected the connectors base url to use the fake server, got %s", client.GetBaseConnectorURL())
+	}
+
+	// empty lists are returned as {}
+	respBody, err := client.HttpClient(client.GetBaseIntegrationURL() + "sfdcInstances")
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if string(respBody) != "{}" {
+		t.Errorf("expected an empty response, got %s", string(respBody))
+	}
+
+	// connections are created with an operation that is done
+	respBody, err = client.HttpClient(client.GetBaseConnectorURL()+"?connectionId=conn", `{"description": "test"}`)
+	if err != nil {
+		t.Fatalf("create connection failed: %v", err)
+	}
+	operation := map[string]interface{}{}
+	if err = json.Unmarshal(respBody, &operation); err != nil {
+		t.Fatalf("unable to parse operation: %v", err)
+	}
+	operationId := operation["name"].(string)[strings.LastIndex(operation["name"].(string), "/")+1:]
+	if respBody, err = client.HttpClient(client.GetBaseConnectorOperationsrURL() + "/" + operationId); err != nil {
+		t.Fatalf("get operation failed: %v", err)
+	}
+	if !strings.Contains(string(respBody), `"done":true`) {
+		t.Errorf("expected a completed operation, got %s", string(respBody))
+	}
+	if _, ok := server.Get("projects/" + FakeProjectID + "/locations/" + FakeRegion + "/connections/conn"); !ok {
+		t.Errorf("expected the connection to be stored")
+	}
+
+	// pagination
+	for i := 0; i < 3; i++ {
+		if _, err = client.HttpClient(client.GetBaseIntegrationURL()+"authConfigs", `{"displayName": "ac"}`); err != nil {
+			t.Fatalf("create authConfig failed: %v", err)
+		}
+	}
+	if respBody, err = client.HttpClient(client.GetBaseIntegrationURL() + "authConfigs?pageSize=2"); err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	page := struct {
+		AuthConfigs   []interface{} `json:"authConfigs"`
+		NextPageToken string        `json:"nextPageToken"`
+	}{}
+	if err = json.Unmarshal(respBody, &page); err != nil {
+		t.Fatalf("unable to parse list: %v", err)
+	}
+	if len(page.AuthConfigs) != 2 || page.NextPageToken == "" {
+		t.Errorf("expected 2 authConfigs and a page token, got %s", string(respBody))
+	}
+
+	if _, err = client.HttpClient(client.GetBaseIntegrationURL() + "authConfigs/missing"); err == nil {
+		t.Errorf("expected an error for a missing authConfig")
+	}
+}
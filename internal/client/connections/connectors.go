@@ -264,22 +264,22 @@ type proxyDestinationConfig struct {
 const interval = 10
 
 // Create
-func Create(name string, content []byte, serviceAccountName string, serviceAccountProject string,
+func Create(client *apiclient.Client, name string, content []byte, serviceAccountName string, serviceAccountProject string,
 	encryptionKey string, grantPermission bool, createSecret bool, wait bool,
 ) (respBody []byte, err error) {
 	if serviceAccountName != "" && strings.Contains(serviceAccountName, ".iam.gserviceaccount.com") {
 		serviceAccountName = strings.Split(serviceAccountName, "@")[0]
 	}
 
-	operationsBytes, err := create(name, content, serviceAccountName,
+	operationsBytes, err := create(client, name, content, serviceAccountName,
 		serviceAccountProject, encryptionKey, grantPermission, createSecret)
 	if err != nil {
 		return nil, err
 	}
 
 	if wait {
-		apiclient.ClientPrintHttpResponse.Set(false)
-		defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+		ctx, endWait := apiclient.StartSpan(client.GetContext(), "wait for connection", name)
+		client = client.WithoutOutput().WithContext(ctx)
 
 		o := operation{}
 		if err = json.Unmarshal(operationsBytes, &o); err != nil {
@@ -289,10 +289,10 @@ func Create(name string, content []byte, serviceAccountName string, serviceAccou
 		operationId := filepath.Base(o.Name)
 		clilog.Info.Printf("Checking connection status for %s in %d seconds\n", operationId, interval)
 
-		stop := apiclient.Every(interval*time.Second, func(time.Time) bool {
+		stop := client.Every(interval*time.Second, func(time.Time) bool {
 			var respBody []byte
 
-			if respBody, err = GetOperation(operationId); err != nil {
+			if respBody, err = GetOperation(client, operationId); err != nil {
 				return false
 			}
 
@@ -314,13 +314,17 @@ func Create(name string, content []byte, serviceAccountName string, serviceAccou
 		})
 
 		<-stop
+		if err == nil {
+			err = client.ContextErr()
+		}
+		endWait(err)
 	}
 
 	return respBody, err
 }
 
 // create
-func create(name string, content []byte, serviceAccountName string, serviceAccountProject string,
+func create(client *apiclient.Client, name string, content []byte, serviceAccountName string, serviceAccountProject string,
 	encryptionKey string, grantPermission bool, createSecret bool,
 ) (respBody []byte, err error) {
 	var secretVersion string
@@ -334,15 +338,15 @@ func create(name string, content []byte, serviceAccountName string, serviceAccou
 	if serviceAccountName != "" {
 		// set the project id if one was not presented
 		if serviceAccountProject == "" {
-			serviceAccountProject = apiclient.GetProjectID()
+			serviceAccountProject = client.GetProjectID()
 		}
 		serviceAccountName = fmt.Sprintf("%s@%s.iam.gserviceaccount.com", serviceAccountName, serviceAccountProject)
 		// create the SA if it doesn't exist
-		if err = apiclient.CreateServiceAccount(serviceAccountName); err != nil {
+		if err = client.CreateServiceAccount(serviceAccountName); err != nil {
 			return nil, err
 		}
 	} else if grantPermission { // use the default compute engine SA to grant permissions
-		serviceAccountName, err = apiclient.GetComputeEngineDefaultServiceAccount(apiclient.GetProjectID())
+		serviceAccountName, err = client.GetComputeEngineDefaultServiceAccount(client.GetProjectID())
 		if err != nil {
 			return nil, err
 		}
@@ -379,10 +383,10 @@ func create(name string, content []byte, serviceAccountName string, serviceAccou
 	if c.ConfigVariables != nil && len(*c.ConfigVariables) > 0 {
 		for index := range *c.ConfigVariables {
 			if (*c.ConfigVariables)[index].Key == "project_id" && *(*c.ConfigVariables)[index].StringValue == "$PROJECT_ID$" {
-				*(*c.ConfigVariables)[index].StringValue = apiclient.GetProjectID()
+				*(*c.ConfigVariables)[index].StringValue = client.GetProjectID()
 			} else if strings.Contains((*c.ConfigVariables)[index].Key, "_region") &&
 				*(*c.ConfigVariables)[index].StringValue == "$REGION$" {
-				*(*c.ConfigVariables)[index].StringValue = apiclient.GetRegion()
+				*(*c.ConfigVariables)[index].StringValue = client.GetRegion()
 			}
 		}
 	}
@@ -408,7 +412,7 @@ func create(name string, content []byte, serviceAccountName string, serviceAccou
 				return nil, fmt.Errorf("projectId or topicName was not set")
 			}
 
-			if err = apiclient.SetPubSubIAMPermission(projectID, topicName, *c.ServiceAccount); err != nil {
+			if err = client.SetPubSubIAMPermission(projectID, topicName, *c.ServiceAccount); err != nil {
 				clilog.Warning.Printf("Unable to update permissions for the service account: %v\n", err)
 			}
 		case "bigquery":
@@ -426,7 +430,7 @@ func create(name string, content []byte, serviceAccountName string, serviceAccou
 				return nil, fmt.Errorf("project_id or dataset_id was not set")
 			}
 
-			if err = apiclient.SetBigQueryIAMPermission(projectID, datasetID, *c.ServiceAccount); err != nil {
+			if err = client.SetBigQueryIAMPermission(projectID, datasetID, *c.ServiceAccount); err != nil {
 				clilog.Warning.Printf("Unable to update permissions for the service account: %v\n", err)
 			}
 		case "gcs":
@@ -438,7 +442,7 @@ func create(name string, content []byte, serviceAccountName string, serviceAccou
 			if projectID == "" {
 				return nil, fmt.Errorf("project_id was not set")
 			}
-			if err = apiclient.SetCloudStorageIAMPermission(projectID, *c.ServiceAccount); err != nil {
+			if err = client.SetCloudStorageIAMPermission(projectID, *c.ServiceAccount); err != nil {
 				clilog.Warning.Printf("Unable to update permissions for the service account: %v\n", err)
 			}
 		case "cloudsql-mysql", "cloudsql-postgresql", "cloudsql-sqlserver":
@@ -450,7 +454,7 @@ func create(name string, content []byte, serviceAccountName string, serviceAccou
 			if projectID == "" {
 				return nil, fmt.Errorf("projectId was not set")
 			}
-			if err = apiclient.SetCloudSQLIAMPermission(projectID, *c.ServiceAccount); err != nil {
+			if err = client.SetCloudSQLIAMPermission(projectID, *c.ServiceAccount); err != nil {
 				clilog.Warning.Printf("Unable to update permissions for the service account: %v\n", err)
 			}
 		case "cloudspanner":
@@ -462,7 +466,7 @@ func create(name string, content []byte, serviceAccountName string, serviceAccou
 			if projectID == "" {
 				return nil, fmt.Errorf("project_id was not set")
 			}
-			if err = apiclient.SetCloudSpannerIAMPermission(projectID, *c.ServiceAccount); err != nil {
+			if err = client.SetCloudSpannerIAMPermission(projectID, *c.ServiceAccount); err != nil {
 				clilog.Warning.Printf("Unable to update permissions for the service account: %v\n", err)
 			}
 		}
@@ -471,10 +475,10 @@ func create(name string, content []byte, serviceAccountName string, serviceAccou
 	c.ConnectorVersion = new(string)
 	if c.ConnectorDetails.VersionId != nil {
 		*c.ConnectorVersion = fmt.Sprintf("projects/%s/locations/global/providers/%s/connectors/%s/versions/%s",
-			apiclient.GetProjectID(), c.ConnectorDetails.Provider, c.ConnectorDetails.Name, *c.ConnectorDetails.VersionId)
+			client.GetProjectID(), c.ConnectorDetails.Provider, c.ConnectorDetails.Name, *c.ConnectorDetails.VersionId)
 	} else {
 		*c.ConnectorVersion = fmt.Sprintf("projects/%s/locations/global/providers/%s/connectors/%s/versions/%d",
-			apiclient.GetProjectID(), c.ConnectorDetails.Provider, c.ConnectorDetails.Name, *c.ConnectorDetails.Version)
+			client.GetProjectID(), c.ConnectorDetails.Provider, c.ConnectorDetails.Name, *c.ConnectorDetails.Version)
 	}
 
 	// remove the element
@@ -496,15 +500,15 @@ func create(name string, content []byte, serviceAccountName string, serviceAccou
 
 					// check if a Cloud KMS key was passsed, assume the file is encrypted
 					if encryptionKey != "" {
-						encryptionKey := path.Join("projects", apiclient.GetProjectID(), encryptionKey)
+						encryptionKey := path.Join("projects", client.GetProjectID(), encryptionKey)
 						payload, err = cloudkms.DecryptSymmetric(encryptionKey, payload)
 						if err != nil {
 							return nil, err
 						}
 					}
 
-					if secretVersion, err = secmgr.Create(
-						apiclient.GetProjectID(),
+					if secretVersion, err = secmgr.Create(client,
+						client.GetProjectID(),
 						c.AuthConfig.UserPassword.PasswordDetails.SecretName,
 						payload); err != nil {
 						return nil, err
@@ -516,8 +520,8 @@ func create(name string, content []byte, serviceAccountName string, serviceAccou
 					c.AuthConfig.UserPassword.PasswordDetails = nil // clean the input
 					if grantPermission && c.ServiceAccount != nil {
 						// grant connector service account access to secretVersion
-						if err = apiclient.SetSecretManagerIAMPermission(
-							apiclient.GetProjectID(),
+						if err = client.SetSecretManagerIAMPermission(
+							client.GetProjectID(),
 							secretName,
 							*c.ServiceAccount); err != nil {
 							return nil, err
@@ -526,7 +530,7 @@ func create(name string, content []byte, serviceAccountName string, serviceAccou
 				} else {
 					c.AuthConfig.UserPassword.Password = new(secret)
 					c.AuthConfig.UserPassword.Password.SecretVersion = fmt.Sprintf("projects/%s/secrets/%s/versions/1",
-						apiclient.GetProjectID(), c.AuthConfig.UserPassword.PasswordDetails.SecretName)
+						client.GetProjectID(), c.AuthConfig.UserPassword.PasswordDetails.SecretName)
 					c.AuthConfig.UserPassword.PasswordDetails = nil // clean the input
 				}
 			}
@@ -540,14 +544,14 @@ func create(name string, content []byte, serviceAccountName string, serviceAccou
 					}
 					// check if a Cloud KMS key was passsed, assume the file is encrypted
 					if encryptionKey != "" {
-						encryptionKey := path.Join("projects", apiclient.GetProjectID(), encryptionKey)
+						encryptionKey := path.Join("projects", client.GetProjectID(), encryptionKey)
 						payload, err = cloudkms.DecryptSymmetric(encryptionKey, payload)
 						if err != nil {
 							return nil, err
 						}
 					}
-					if secretVersion, err = secmgr.Create(
-						apiclient.GetProjectID(),
+					if secretVersion, err = secmgr.Create(client,
+						client.GetProjectID(),
 						c.AuthConfig.Oauth2JwtBearer.ClientKeyDetails.SecretName,
 						payload); err != nil {
 						return nil, err
@@ -558,8 +562,8 @@ func create(name string, content []byte, serviceAccountName string, serviceAccou
 					c.AuthConfig.Oauth2JwtBearer.ClientKeyDetails = nil // clean the input
 					if grantPermission && c.ServiceAccount != nil {
 						// grant connector service account access to secret version
-						if err = apiclient.SetSecretManagerIAMPermission(
-							apiclient.GetProjectID(),
+						if err = client.SetSecretManagerIAMPermission(
+							client.GetProjectID(),
 							secretName,
 							*c.ServiceAccount); err != nil {
 							return nil, err
@@ -568,7 +572,7 @@ func create(name string, content []byte, serviceAccountName string, serviceAccou
 				} else {
 					c.AuthConfig.Oauth2JwtBearer.ClientKey = new(secret)
 					c.AuthConfig.Oauth2JwtBearer.ClientKey.SecretVersion = fmt.Sprintf("projects/%s/secrets/%s/versions/1",
-						apiclient.GetProjectID(),
+						client.GetProjectID(),
 						c.AuthConfig.Oauth2JwtBearer.ClientKeyDetails.SecretName)
 					c.AuthConfig.Oauth2JwtBearer.ClientKeyDetails = nil
 				}
@@ -600,15 +604,15 @@ func create(name string, content []byte, serviceAccountName string, serviceAccou
 				}
 				// check if a Cloud KMS key was passsed, assume the file is encrypted
 				if encryptionKey != "" {
-					encryptionKey := path.Join("projects", apiclient.GetProjectID(), encryptionKey)
+					encryptionKey := path.Join("projects", client.GetProjectID(), encryptionKey)
 					payload, err = cloudkms.DecryptSymmetric(encryptionKey, payload)
 					if err != nil {
 						return nil, err
 					}
 				}
 
-				if secretVersion, err = secmgr.Create(
-					apiclient.GetProjectID(),
+				if secretVersion, err = secmgr.Create(client,
+					client.GetProjectID(),
 					c.SslConfig.PrivateServerCertificate.SecretDetails.SecretName,
 					payload); err != nil {
 					return nil, err
@@ -621,7 +625,7 @@ func create(name string, content []byte, serviceAccountName string, serviceAccou
 			} else {
 				c.SslConfig.PrivateServerCertificate.SecretVersion = new(string)
 				*c.SslConfig.PrivateServerCertificate.SecretVersion = fmt.Sprintf("projects/%s/secrets/%s/versions/1",
-					apiclient.GetProjectID(), c.SslConfig.PrivateServerCertificate.SecretDetails.SecretName)
+					client.GetProjectID(), c.SslConfig.PrivateServerCertificate.SecretDetails.SecretName)
 				c.SslConfig.PrivateServerCertificate.SecretDetails = nil // clean the input
 			}
 		}
@@ -633,15 +637,15 @@ func create(name string, content []byte, serviceAccountName string, serviceAccou
 				}
 				// check if a Cloud KMS key was passsed, assume the file is encrypted
 				if encryptionKey != "" {
-					encryptionKey := path.Join("projects", apiclient.GetProjectID(), encryptionKey)
+					encryptionKey := path.Join("projects", client.GetProjectID(), encryptionKey)
 					payload, err = cloudkms.DecryptSymmetric(encryptionKey, payload)
 					if err != nil {
 						return nil, err
 					}
 				}
 
-				if secretVersion, err = secmgr.Create(
-					apiclient.GetProjectID(),
+				if secretVersion, err = secmgr.Create(client,
+					client.GetProjectID(),
 					c.SslConfig.ClientCertificate.SecretDetails.SecretName,
 					payload); err != nil {
 					return nil, err
@@ -653,7 +657,7 @@ func create(name string, content []byte, serviceAccountName string, serviceAccou
 			} else {
 				c.SslConfig.ClientCertificate.SecretVersion = new(string)
 				*c.SslConfig.ClientCertificate.SecretVersion = fmt.Sprintf("projects/%s/secrets/%s/versions/1",
-					apiclient.GetProjectID(), c.SslConfig.ClientCertificate.SecretDetails.SecretName)
+					client.GetProjectID(), c.SslConfig.ClientCertificate.SecretDetails.SecretName)
 				c.SslConfig.ClientCertificate.SecretDetails = nil // clean the input
 			}
 		}
@@ -665,15 +669,15 @@ func create(name string, content []byte, serviceAccountName string, serviceAccou
 				}
 				// check if a Cloud KMS key was passsed, assume the file is encrypted
 				if encryptionKey != "" {
-					encryptionKey := path.Join("projects", apiclient.GetProjectID(), encryptionKey)
+					encryptionKey := path.Join("projects", client.GetProjectID(), encryptionKey)
 					payload, err = cloudkms.DecryptSymmetric(encryptionKey, payload)
 					if err != nil {
 						return nil, err
 					}
 				}
 
-				if secretVersion, err = secmgr.Create(
-					apiclient.GetProjectID(),
+				if secretVersion, err = secmgr.Create(client,
+					client.GetProjectID(),
 					c.SslConfig.ClientPrivateKey.SecretDetails.SecretName,
 					payload); err != nil {
 					return nil, err
@@ -685,7 +689,7 @@ func create(name string, content []byte, serviceAccountName string, serviceAccou
 			} else {
 				c.SslConfig.ClientPrivateKey.SecretVersion = new(string)
 				*c.SslConfig.ClientPrivateKey.SecretVersion = fmt.Sprintf("projects/%s/secrets/%s/versions/1",
-					apiclient.GetProjectID(), c.SslConfig.ClientPrivateKey.SecretDetails.SecretName)
+					client.GetProjectID(), c.SslConfig.ClientPrivateKey.SecretDetails.SecretName)
 				c.SslConfig.ClientPrivateKey.SecretDetails = nil // clean the input
 			}
 		}
@@ -697,15 +701,15 @@ func create(name string, content []byte, serviceAccountName string, serviceAccou
 				}
 				// check if a Cloud KMS key was passsed, assume the file is encrypted
 				if encryptionKey != "" {
-					encryptionKey := path.Join("projects", apiclient.GetProjectID(), encryptionKey)
+					encryptionKey := path.Join("projects", client.GetProjectID(), encryptionKey)
 					payload, err = cloudkms.DecryptSymmetric(encryptionKey, payload)
 					if err != nil {
 						return nil, err
 					}
 				}
 
-				if secretVersion, err = secmgr.Create(
-					apiclient.GetProjectID(),
+				if secretVersion, err = secmgr.Create(client,
+					client.GetProjectID(),
 					c.SslConfig.ClientPrivateKeyPass.SecretDetails.SecretName,
 					payload); err != nil {
 					return nil, err
@@ -717,13 +721,13 @@ func create(name string, content []byte, serviceAccountName string, serviceAccou
 			} else {
 				c.SslConfig.ClientPrivateKeyPass.SecretVersion = new(string)
 				*c.SslConfig.ClientPrivateKeyPass.SecretVersion = fmt.Sprintf("projects/%s/secrets/%s/versions/1",
-					apiclient.GetProjectID(), c.SslConfig.ClientPrivateKeyPass.SecretDetails.SecretName)
+					client.GetProjectID(), c.SslConfig.ClientPrivateKeyPass.SecretDetails.SecretName)
 				c.SslConfig.ClientPrivateKeyPass.SecretDetails = nil // clean the input
 			}
 		}
 	}
 
-	u, _ := url.Parse(apiclient.GetBaseConnectorURL())
+	u, _ := url.Parse(client.GetBaseConnectorURL())
 	q := u.Query()
 	q.Set("connectionId", name)
 	u.RawQuery = q.Encode()
@@ -732,33 +736,34 @@ func create(name string, content []byte, serviceAccountName string, serviceAccou
 		return nil, err
 	}
 
-	respBody, err = apiclient.HttpClient(u.String(), string(content))
+	respBody, err = client.HttpClient(u.String(), string(content))
 	return respBody, err
 }
 
 // Delete
-func Delete(name string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseConnectorURL())
+func Delete(client *apiclient.Client, name string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseConnectorURL())
 	u.Path = path.Join(u.Path, name)
-	respBody, err = apiclient.HttpClient(u.String(), "", "DELETE")
+	respBody, err = client.HttpClient(u.String(), "", "DELETE")
 	return respBody, err
 }
 
 // Get
-func Get(name string, view string, minimal bool, overrides bool) (respBody []byte, err error) {
+func Get(client *apiclient.Client, name string, view string, minimal bool, overrides bool) (respBody []byte, err error) {
 	var connectionPayload []byte
-	u, _ := url.Parse(apiclient.GetBaseConnectorURL())
+	u, _ := url.Parse(client.GetBaseConnectorURL())
 	q := u.Query()
 	if view != "" {
 		q.Set("view", view)
 	}
 	u.Path = path.Join(u.Path, name)
 
+	httpClient := client
 	if minimal {
-		apiclient.ClientPrintHttpResponse.Set(false)
+		httpClient = client.WithoutOutput()
 	}
 
-	respBody, err = apiclient.HttpClient(u.String())
+	respBody, err = httpClient.HttpClient(u.String())
 
 	if minimal {
 		c := connection{}
@@ -831,8 +836,7 @@ func Get(name string, view string, minimal bool, overrides bool) (respBody []byt
 		if err != nil {
 			return nil, err
 		}
-		apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting()) // set original print output
-		apiclient.PrettyPrint(connectionPayload)
+		client.PrettyPrint(connectionPayload)
 
 		return connectionPayload, err
 	}
@@ -840,20 +844,21 @@ func Get(name string, view string, minimal bool, overrides bool) (respBody []byt
 }
 
 // Get Connection details With region
-func GetConnectionDetailWithRegion(name string, region string, view string, minimal bool, overrides bool) (respBody []byte, err error) {
+func GetConnectionDetailWithRegion(client *apiclient.Client, name string, region string, view string, minimal bool, overrides bool) (respBody []byte, err error) {
 	var connectionPayload []byte
-	u, _ := url.Parse(apiclient.GetBaseConnectorURLWithRegion(region))
+	u, _ := url.Parse(client.GetBaseConnectorURLWithRegion(region))
 	q := u.Query()
 	if view != "" {
 		q.Set("view", view)
 	}
 	u.Path = path.Join(u.Path, name)
 
+	httpClient := client
 	if minimal {
-		apiclient.ClientPrintHttpResponse.Set(false)
+		httpClient = client.WithoutOutput()
 	}
 
-	respBody, err = apiclient.HttpClient(u.String())
+	respBody, err = httpClient.HttpClient(u.String())
 
 	if minimal {
 		c := connection{}
@@ -930,8 +935,7 @@ func GetConnectionDetailWithRegion(name string, region string, view string, mini
 		if err != nil {
 			return nil, err
 		}
-		apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting()) // set original print output
-		apiclient.PrettyPrint(connectionPayload)
+		client.PrettyPrint(connectionPayload)
 
 		return connectionPayload, err
 	}
@@ -939,8 +943,8 @@ func GetConnectionDetailWithRegion(name string, region string, view string, mini
 }
 
 // List
-func List(pageSize int, pageToken string, filter string, orderBy string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseConnectorURL())
+func List(client *apiclient.Client, pageSize int, pageToken string, filter string, orderBy string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseConnectorURL())
 	q := u.Query()
 	if pageSize != -1 {
 		q.Set("pageSize", strconv.Itoa(pageSize))
@@ -956,17 +960,25 @@ func List(pageSize int, pageToken string, filter string, orderBy string) (respBo
 	}
 
 	u.RawQuery = q.Encode()
-	respBody, err = apiclient.HttpClient(u.String())
+	respBody, err = client.HttpClient(u.String())
 	return respBody, err
 }
 
-func Patch(name string, content []byte, updateMask []string) (respBody []byte, err error) {
+// ListAll lists the connections of every page, with a limit greater than 0
+// no more than limit connections are returned
+func ListAll(client *apiclient.Client, pageSize int, filter string, orderBy string, limit int) (respBody []byte, err error) {
+	return client.ListAll(func(client *apiclient.Client, pageToken string, pageSize int) ([]byte, error) {
+		return List(client, pageSize, pageToken, filter, orderBy)
+	}, pageSize, limit)
+}
+
+func Patch(client *apiclient.Client, name string, content []byte, updateMask []string) (respBody []byte, err error) {
 	c := connectionRequest{}
 	if err = json.Unmarshal(content, &c); err != nil {
 		return nil, err
 	}
 
-	u, _ := url.Parse(apiclient.GetBaseConnectorURL())
+	u, _ := url.Parse(client.GetBaseConnectorURL())
 
 	if len(updateMask) != 0 {
 		updates := strings.Join(updateMask, ",")
@@ -977,7 +989,7 @@ func Patch(name string, content []byte, updateMask []string) (respBody []byte, e
 
 	u.Path = path.Join(u.Path, name)
 
-	return apiclient.HttpClient(u.String(), string(content), "PATCH")
+	return client.HttpClient(u.String(), string(content), "PATCH")
 }
 
 func readSecretFile(name string) (payload []byte, err error) {
@@ -993,9 +1005,8 @@ func readSecretFile(name string) (payload []byte, err error) {
 }
 
 // Import
-func Import(folder string, createSecret bool, wait bool) (err error) {
-	apiclient.ClientPrintHttpResponse.Set(false)
-	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+func Import(client *apiclient.Client, folder string, createSecret bool, wait bool) (err error) {
+	client = client.WithoutOutput()
 	errs := []string{}
 
 	err = filepath.Walk(folder, func(path string, info os.FileInfo, err error) error {
@@ -1015,8 +1026,8 @@ func Import(folder string, createSecret bool, wait bool) (err error) {
 			return err
 		}
 
-		if _, err := Get(name, "", false, false); err != nil { // create only if connection doesn't exist
-			_, err = Create(name, content, "", "", "", false, createSecret, wait)
+		if _, err := Get(client, name, "", false, false); err != nil { // create only if connection doesn't exist
+			_, err = Create(client, name, content, "", "", "", false, createSecret, wait)
 			if err != nil {
 				errs = append(errs, err.Error())
 			}
@@ -1038,29 +1049,24 @@ func Import(folder string, createSecret bool, wait bool) (err error) {
 }
 
 // Export
-func Export(folder string) (err error) {
-	apiclient.SetExportToFile(folder)
-	apiclient.ClientPrintHttpResponse.Set(false)
-	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+func Export(client *apiclient.Client, folder string) (err error) {
+	client = client.WithExportToFile(folder)
+	client = client.WithoutOutput()
 
-	pageToken := ""
 	lconnections := listconnections{}
 
-	for {
+	err = client.ForEachPage(func(client *apiclient.Client, pageToken string) ([]byte, error) {
+		return List(client, maxPageSize, pageToken, "", "")
+	}, func(respBody []byte) error {
 		l := listconnections{}
-		respBody, err := List(maxPageSize, pageToken, "", "")
-		if err != nil {
-			return fmt.Errorf("failed to fetch Integrations: %w", err)
-		}
-		err = json.Unmarshal(respBody, &l)
-		if err != nil {
+		if err := json.Unmarshal(respBody, &l); err != nil {
 			return fmt.Errorf("failed to unmarshall: %w", err)
 		}
 		lconnections.Connections = append(lconnections.Connections, l.Connections...)
-		pageToken = l.NextPageToken
-		if l.NextPageToken == "" {
-			break
-		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fetch Connections: %w", err)
 	}
 
 	// no connections where found
@@ -1087,7 +1093,7 @@ func Export(folder string) (err error) {
 			return err
 		}
 		if err = apiclient.WriteByteArrayToFile(
-			path.Join(apiclient.GetExportToFile(), fileName),
+			path.Join(client.GetExportToFile(), fileName),
 			false,
 			connectionPayload); err != nil {
 			clilog.Error.Println(err)
@@ -1099,16 +1105,16 @@ func Export(folder string) (err error) {
 	return nil
 }
 
-func RepairEvent(name string, wait bool) (err error) {
-	u, _ := url.Parse(apiclient.GetBaseConnectorURL())
+func RepairEvent(client *apiclient.Client, name string, wait bool) (err error) {
+	u, _ := url.Parse(client.GetBaseConnectorURL())
 	u.Path = path.Join(u.Path, name)
-	operationsBytes, err := apiclient.HttpClient(u.String(), "")
+	operationsBytes, err := client.HttpClient(u.String(), "")
 	if err != nil {
 		return err
 	}
 	if wait {
-		apiclient.ClientPrintHttpResponse.Set(false)
-		defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+		ctx, endWait := apiclient.StartSpan(client.GetContext(), "wait for connection repair", name)
+		client = client.WithoutOutput().WithContext(ctx)
 
 		o := operation{}
 		if err = json.Unmarshal(operationsBytes, &o); err != nil {
@@ -1118,10 +1124,10 @@ func RepairEvent(name string, wait bool) (err error) {
 		operationId := filepath.Base(o.Name)
 		clilog.Info.Printf("Checking connection repair status for %s in %d seconds\n", operationId, interval)
 
-		stop := apiclient.Every(interval*time.Second, func(time.Time) bool {
+		stop := client.Every(interval*time.Second, func(time.Time) bool {
 			var respBody []byte
 
-			if respBody, err = GetOperation(operationId); err != nil {
+			if respBody, err = GetOperation(client, operationId); err != nil {
 				return false
 			}
 
@@ -1143,6 +1149,10 @@ func RepairEvent(name string, wait bool) (err error) {
 		})
 
 		<-stop
+		if err == nil {
+			err = client.ContextErr()
+		}
+		endWait(err)
 	}
 	return err
 }
@@ -57,10 +57,10 @@ type configVariableTemplate struct {
 const waitTime = 1 * time.Second
 
 // CreateCustom
-func CreateCustom(name string, description string, displayName string,
+func CreateCustom(client *apiclient.Client, name string, description string, displayName string,
 	connType string, labels map[string]string,
 ) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseCustomConnectorURL())
+	u, _ := url.Parse(client.GetBaseCustomConnectorURL())
 	q := u.Query()
 	q.Set("customConnectorId", name)
 
@@ -81,32 +81,32 @@ func CreateCustom(name string, description string, displayName string,
 
 	payload := "{" + strings.Join(customConnect, ",") + "}"
 	u.RawQuery = q.Encode()
-	respBody, err = apiclient.HttpClient(u.String(), payload)
+	respBody, err = client.HttpClient(u.String(), payload)
 	return respBody, err
 }
 
 // DeleteCustom
-func DeleteCustom(name string, force bool) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseCustomConnectorURL())
+func DeleteCustom(client *apiclient.Client, name string, force bool) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseCustomConnectorURL())
 	u.Path = path.Join(u.Path, name)
 	q := u.Query()
 	q.Set("force", strconv.FormatBool(force))
 	u.RawQuery = q.Encode()
-	respBody, err = apiclient.HttpClient(u.String(), "", "DELETE")
+	respBody, err = client.HttpClient(u.String(), "", "DELETE")
 	return respBody, err
 }
 
 // GetCustom
-func GetCustom(name string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseCustomConnectorURL())
+func GetCustom(client *apiclient.Client, name string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseCustomConnectorURL())
 	u.Path = path.Join(u.Path, name)
-	respBody, err = apiclient.HttpClient(u.String())
+	respBody, err = client.HttpClient(u.String())
 	return respBody, err
 }
 
 // ListCustom
-func ListCustom(pageSize int, pageToken string, filter string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseCustomConnectorURL())
+func ListCustom(client *apiclient.Client, pageSize int, pageToken string, filter string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseCustomConnectorURL())
 	q := u.Query()
 	if pageSize != -1 {
 		q.Set("pageSize", strconv.Itoa(pageSize))
@@ -118,12 +118,12 @@ func ListCustom(pageSize int, pageToken string, filter string) (respBody []byte,
 		q.Set("filter", filter)
 	}
 	u.RawQuery = q.Encode()
-	respBody, err = apiclient.HttpClient(u.String())
+	respBody, err = client.HttpClient(u.String())
 	return respBody, err
 }
 
 // CreateCustomVersion
-func CreateCustomVersion(connName string, versionName string, content []byte,
+func CreateCustomVersion(client *apiclient.Client, connName string, versionName string, content []byte,
 	serviceAccountName string, serviceAccountProject string,
 ) (respBody []byte, err error) {
 	c := customConnectorVersionRequest{}
@@ -135,11 +135,11 @@ func CreateCustomVersion(connName string, versionName string, content []byte,
 	if serviceAccountName != "" {
 		// set the project id if one was not presented
 		if serviceAccountProject == "" {
-			serviceAccountProject = apiclient.GetProjectID()
+			serviceAccountProject = client.GetProjectID()
 		}
 		serviceAccountName = fmt.Sprintf("%s@%s.iam.gserviceaccount.com", serviceAccountName, serviceAccountProject)
 		// create the SA if it doesn't exist
-		if err = apiclient.CreateServiceAccount(serviceAccountName); err != nil {
+		if err = client.CreateServiceAccount(serviceAccountName); err != nil {
 			return nil, err
 		}
 	}
@@ -152,34 +152,33 @@ func CreateCustomVersion(connName string, versionName string, content []byte,
 		return nil, err
 	}
 
-	u, _ := url.Parse(apiclient.GetBaseCustomConnectorURL())
+	u, _ := url.Parse(client.GetBaseCustomConnectorURL())
 	u.Path = path.Join(u.Path, connName, "customConnectorVersions")
 	q := u.Query()
 	q.Set("customConnectorVersionId", versionName)
 	u.RawQuery = q.Encode()
 
-	respBody, err = apiclient.HttpClient(u.String(), string(content))
+	respBody, err = client.HttpClient(u.String(), string(content))
 	return respBody, err
 }
 
-func GetCustomVersion(connName string, connVersion string, overrides bool) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseCustomConnectorURL())
+func GetCustomVersion(client *apiclient.Client, connName string, connVersion string, overrides bool) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseCustomConnectorURL())
 	u.Path = path.Join(u.Path, connName, "customConnectorVersions", connVersion)
 	if overrides {
-		apiclient.ClientPrintHttpResponse.Set(false)
+		quiet := client.WithoutOutput()
 		c := customConnectorOverrides{}
-		connRespBody, err := GetCustom(connName)
+		connRespBody, err := GetCustom(quiet, connName)
 		if err != nil {
 			return nil, err
 		}
 		if err = json.Unmarshal(connRespBody, &c); err != nil {
 			return nil, err
 		}
-		respBody, err = apiclient.HttpClient(u.String())
+		respBody, err = quiet.HttpClient(u.String())
 		if err != nil {
 			return nil, err
 		}
-		apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
 		cVerReq := customConnectorVersionRequest{}
 		if err = json.Unmarshal(respBody, &cVerReq); err != nil {
 			return nil, err
@@ -193,15 +192,15 @@ func GetCustomVersion(connName string, connVersion string, overrides bool) (resp
 		if err != nil {
 			return nil, err
 		}
-		apiclient.PrettyPrint(overridesResp)
+		client.PrettyPrint(overridesResp)
 		return overridesResp, nil
 	}
-	respBody, err = apiclient.HttpClient(u.String())
+	respBody, err = client.HttpClient(u.String())
 	return respBody, err
 }
 
-func ListCustomVersions(connName string, pageSize int, pageToken string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseCustomConnectorURL())
+func ListCustomVersions(client *apiclient.Client, connName string, pageSize int, pageToken string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseCustomConnectorURL())
 	u.Path = path.Join(u.Path, connName, "customConnectorVersions")
 	q := u.Query()
 	if pageSize != -1 {
@@ -211,11 +210,11 @@ func ListCustomVersions(connName string, pageSize int, pageToken string) (respBo
 		q.Set("pageToken", pageToken)
 	}
 	u.RawQuery = q.Encode()
-	respBody, err = apiclient.HttpClient(u.String())
+	respBody, err = client.HttpClient(u.String())
 	return respBody, err
 }
 
-func GetCustomFromConnection(contents []byte) (respBody []byte, err error) {
+func GetCustomFromConnection(client *apiclient.Client, contents []byte) (respBody []byte, err error) {
 	c := connection{}
 	err = json.Unmarshal(respBody, &c)
 	if err != nil {
@@ -224,7 +223,7 @@ func GetCustomFromConnection(contents []byte) (respBody []byte, err error) {
 	if c.ConnectorDetails.Provider != "customconnector" {
 		return nil, fmt.Errorf("connector is not of type customconnector")
 	}
-	respBody, err = GetCustomVersion(getConnectorName(*c.ConnectorVersion), getConnectorVersionId(*c.ConnectorVersion), false)
+	respBody, err = GetCustomVersion(client, getConnectorName(*c.ConnectorVersion), getConnectorVersionId(*c.ConnectorVersion), false)
 	return respBody, err
 }
 
@@ -240,7 +239,7 @@ func IsCustomConnector(contents []byte) bool {
 	return true
 }
 
-func CreateCustomWithVersion(name string, version string, contents []byte,
+func CreateCustomWithVersion(client *apiclient.Client, name string, version string, contents []byte,
 	serviceAccount string, serviceAccountProject string,
 ) (err error) {
 	c := customConnectorOverrides{}
@@ -248,7 +247,7 @@ func CreateCustomWithVersion(name string, version string, contents []byte,
 	if err != nil {
 		return err
 	}
-	createCustomBody, err := CreateCustom(name, c.Description, c.DisplayName, c.CustomConnectorType, c.Labels)
+	createCustomBody, err := CreateCustom(client, name, c.Description, c.DisplayName, c.CustomConnectorType, c.Labels)
 	if err != nil {
 		return err
 	}
@@ -262,7 +261,7 @@ func CreateCustomWithVersion(name string, version string, contents []byte,
 	// wait for custom connection to be created
 	if len(strings.Split(fmt.Sprintf("%s", createCustomMap["name"]), "/")) > 4 {
 		operationName := strings.Split(fmt.Sprintf("%s", createCustomMap["name"]), "/")[5]
-		err = waitForCustom(operationName)
+		err = waitForCustom(client, operationName)
 		if err != nil {
 			return err
 		}
@@ -272,28 +271,28 @@ func CreateCustomWithVersion(name string, version string, contents []byte,
 	if err != nil {
 		return err
 	}
-	_, err = CreateCustomVersion(name, version, connectionVersionContents, serviceAccount, serviceAccountProject)
+	_, err = CreateCustomVersion(client, name, version, connectionVersionContents, serviceAccount, serviceAccountProject)
 	if err != nil {
 		return err
 	}
 
 	// wait for custom version to be created
-	err = waitForCustomVersion(name, version)
+	err = waitForCustomVersion(client, name, version)
 	return err
 }
 
-func waitForCustom(operationName string) error {
-	var err error
+func waitForCustom(client *apiclient.Client, operationName string) (err error) {
 	var respBody []byte
 	var respMap map[string]interface{}
 
-	region := apiclient.GetRegion()
-	defer apiclient.SetRegion(region)
+	ctx, endWait := apiclient.StartSpan(client.GetContext(), "wait for custom connection", operationName)
+	defer func() { endWait(err) }()
 
-	apiclient.SetRegion("global")
+	// custom connector operations are global
+	client = client.WithRegion("global").WithContext(ctx)
 
 	for {
-		if respBody, err = GetOperation(operationName); err != nil {
+		if respBody, err = GetOperation(client, operationName); err != nil {
 			return err
 		}
 		if err = json.Unmarshal(respBody, &respMap); err != nil {
@@ -301,20 +300,24 @@ func waitForCustom(operationName string) error {
 		}
 		done := respMap["done"].(bool)
 		if done {
-			time.Sleep(waitTime)
-			return nil
+			return client.Sleep(waitTime)
+		}
+		if err = client.Sleep(waitTime); err != nil {
+			return err
 		}
-		time.Sleep(waitTime)
 	}
 }
 
-func waitForCustomVersion(name string, version string) error {
-	var err error
+func waitForCustomVersion(client *apiclient.Client, name string, version string) (err error) {
 	var respBody []byte
 	var respMap map[string]string
 
+	ctx, endWait := apiclient.StartSpan(client.GetContext(), "wait for custom connection version", name)
+	defer func() { endWait(err) }()
+	client = client.WithContext(ctx)
+
 	for {
-		if respBody, err = GetCustomVersion(name, version, false); err != nil {
+		if respBody, err = GetCustomVersion(client, name, version, false); err != nil {
 			return err
 		}
 
@@ -323,9 +326,10 @@ func waitForCustomVersion(name string, version string) error {
 		}
 
 		if respMap["state"] == "ACTIVE" {
-			time.Sleep(waitTime)
-			return nil
+			return client.Sleep(waitTime)
+		}
+		if err = client.Sleep(waitTime); err != nil {
+			return err
 		}
-		time.Sleep(waitTime)
 	}
 }
@@ -45,12 +45,12 @@ type endpointExternal struct {
 }
 
 // CreateEndpoint
-func CreateEndpoint(name string, serviceAttachment string, description string, wait bool) (respBody []byte, err error) {
+func CreateEndpoint(client *apiclient.Client, name string, serviceAttachment string, description string, wait bool) (respBody []byte, err error) {
 	endpointStr := []string{}
 
 	endpointStr = append(endpointStr, "\"name\":\""+
 		fmt.Sprintf("projects/%s/locations/%s/endpointAttachments/%s",
-			apiclient.GetProjectID(), apiclient.GetRegion(), name)+"\"")
+			client.GetProjectID(), client.GetRegion(), name)+"\"")
 	endpointStr = append(endpointStr, "\"serviceAttachment\":\""+serviceAttachment+"\"")
 
 	if description != "" {
@@ -59,18 +59,18 @@ func CreateEndpoint(name string, serviceAttachment string, description string, w
 
 	payload := "{" + strings.Join(endpointStr, ",") + "}"
 
-	u, _ := url.Parse(apiclient.GetBaseConnectorEndpointAttachURL())
+	u, _ := url.Parse(client.GetBaseConnectorEndpointAttachURL())
 	u.Path = path.Join(u.Path)
 
 	q := u.Query()
 	q.Set("endpointAttachmentId", name)
 	u.RawQuery = q.Encode()
 
-	respBody, err = apiclient.HttpClient(u.String(), payload)
+	respBody, err = client.HttpClient(u.String(), payload)
 
 	if wait {
-		apiclient.ClientPrintHttpResponse.Set(false)
-		defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+		ctx, endWait := apiclient.StartSpan(client.GetContext(), "wait for endpoint attachment", name)
+		client = client.WithoutOutput().WithContext(ctx)
 
 		o := operation{}
 		if err = json.Unmarshal(respBody, &o); err != nil {
@@ -80,10 +80,10 @@ func CreateEndpoint(name string, serviceAttachment string, description string, w
 		operationId := filepath.Base(o.Name)
 		clilog.Info.Printf("Checking connection status for %s in %d seconds\n", operationId, interval)
 
-		stop := apiclient.Every(interval*time.Second, func(time.Time) bool {
+		stop := client.Every(interval*time.Second, func(time.Time) bool {
 			var respBody []byte
 
-			if respBody, err = GetOperation(operationId); err != nil {
+			if respBody, err = GetOperation(client, operationId); err != nil {
 				return false
 			}
 
@@ -105,19 +105,24 @@ func CreateEndpoint(name string, serviceAttachment string, description string, w
 		})
 
 		<-stop
+		if err == nil {
+			err = client.ContextErr()
+		}
+		endWait(err)
 	}
 	return
 }
 
 // GetEndpoint
-func GetEndpoint(name string, overrides bool) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseConnectorEndpointAttachURL())
+func GetEndpoint(client *apiclient.Client, name string, overrides bool) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseConnectorEndpointAttachURL())
 	u.Path = path.Join(u.Path, name)
+	httpClient := client
 	if overrides {
-		apiclient.ClientPrintHttpResponse.Set(false)
+		httpClient = client.WithoutOutput()
 	}
 
-	respBody, err = apiclient.HttpClient(u.String())
+	respBody, err = httpClient.HttpClient(u.String())
 	if overrides {
 		e := endpoint{}
 		if err = json.Unmarshal(respBody, &e); err != nil {
@@ -128,17 +133,15 @@ func GetEndpoint(name string, overrides bool) (respBody []byte, err error) {
 		if err != nil {
 			return nil, err
 		}
-		apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
-		apiclient.PrettyPrint(respBody)
+		client.PrettyPrint(respBody)
 		return
 	}
-	apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
 	return
 }
 
 // ListEndpoints
-func ListEndpoints(pageSize int, pageToken string, filter string, orderBy string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseConnectorEndpointAttachURL())
+func ListEndpoints(client *apiclient.Client, pageSize int, pageToken string, filter string, orderBy string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseConnectorEndpointAttachURL())
 	q := u.Query()
 	if pageSize != -1 {
 		q.Set("pageSize", strconv.Itoa(pageSize))
@@ -154,25 +157,25 @@ func ListEndpoints(pageSize int, pageToken string, filter string, orderBy string
 	}
 
 	u.RawQuery = q.Encode()
-	respBody, err = apiclient.HttpClient(u.String())
+	respBody, err = client.HttpClient(u.String())
 	return respBody, err
 }
 
 // DeleteEndpoint
-func DeleteEndpoint(name string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseConnectorEndpointAttachURL())
+func DeleteEndpoint(client *apiclient.Client, name string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseConnectorEndpointAttachURL())
 	u.Path = path.Join(u.Path, name)
-	respBody, err = apiclient.HttpClient(u.String(), "", "DELETE")
+	respBody, err = client.HttpClient(u.String(), "", "DELETE")
 	return respBody, err
 }
 
-func FindEndpoint(name string) (found bool) {
+func FindEndpoint(client *apiclient.Client, name string) (found bool) {
 	var pageToken string
 	var respBody []byte
 	var err error
 
 	for {
-		if respBody, err = ListEndpoints(maxPageSize, pageToken, "", ""); err != nil {
+		if respBody, err = ListEndpoints(client, maxPageSize, pageToken, "", ""); err != nil {
 			return false
 		}
 		l := endpoints{}
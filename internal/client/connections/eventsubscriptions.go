@@ -53,47 +53,47 @@ type jms struct {
 }
 
 // CreateEventSubscription
-func CreateEventSubscription(connName string, subscriptionId string, contents []byte) (respBody []byte, err error) {
+func CreateEventSubscription(client *apiclient.Client, connName string, subscriptionId string, contents []byte) (respBody []byte, err error) {
 	e := eventRequest{}
 	if err = json.Unmarshal(contents, &e); err != nil {
 		return nil, err
 	}
-	u, _ := url.Parse(apiclient.GetBaseConnectorURL())
+	u, _ := url.Parse(client.GetBaseConnectorURL())
 	u.Path = path.Join(u.Path, "connectors", connName, "eventSubscriptions")
 	q := u.Query()
 	q.Set("eventSubscriptionId", subscriptionId)
 	u.RawQuery = q.Encode()
-	respBody, err = apiclient.HttpClient(u.String(), string(contents))
+	respBody, err = client.HttpClient(u.String(), string(contents))
 	return respBody, err
 }
 
 // GetEventSubscription
-func GetEventSubscription(name string, connName string, overrides bool) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseConnectorURL())
+func GetEventSubscription(client *apiclient.Client, name string, connName string, overrides bool) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseConnectorURL())
 	u.Path = path.Join(u.Path, "connectors", connName, "eventSubscriptions", name)
-	respBody, err = apiclient.HttpClient(u.String())
+	respBody, err = client.HttpClient(u.String())
 	return respBody, err
 }
 
 // DeleteEventSubscription
-func DeleteEventSubscription(name string, connName string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseConnectorURL())
+func DeleteEventSubscription(client *apiclient.Client, name string, connName string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseConnectorURL())
 	u.Path = path.Join(u.Path, "connectors", connName, "eventSubscriptions", name)
-	respBody, err = apiclient.HttpClient(u.String(), "", "DELETE")
+	respBody, err = client.HttpClient(u.String(), "", "DELETE")
 	return respBody, err
 }
 
 // RetryEventSubscription
-func RetryEventSubscription(name string, connName string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseConnectorURL())
+func RetryEventSubscription(client *apiclient.Client, name string, connName string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseConnectorURL())
 	u.Path = path.Join(u.Path, "connectors", connName, "eventSubscriptions", name+":retry")
-	respBody, err = apiclient.HttpClient(u.String(), "")
+	respBody, err = client.HttpClient(u.String(), "")
 	return respBody, err
 }
 
 // ListEventSubscriptions
-func ListEventSubscriptions(connName string, pageSize int, pageToken string, filter string, orderBy string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseConnectorURL())
+func ListEventSubscriptions(client *apiclient.Client, connName string, pageSize int, pageToken string, filter string, orderBy string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseConnectorURL())
 	u.Path = path.Join(u.Path, "connectors", connName, "eventSubscriptions")
 	q := u.Query()
 	if pageSize != -1 {
@@ -109,6 +109,6 @@ func ListEventSubscriptions(connName string, pageSize int, pageToken string, fil
 		q.Set("orderBy", orderBy)
 	}
 	u.RawQuery = q.Encode()
-	respBody, err = apiclient.HttpClient(u.String())
+	respBody, err = client.HttpClient(u.String())
 	return respBody, err
 }
@@ -24,27 +24,27 @@ import (
 var validMemberTypes = []string{"serviceAccount", "group", "user", "domain"}
 
 // GetIAM
-func GetIAM(name string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseConnectorURL())
+func GetIAM(client *apiclient.Client, name string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseConnectorURL())
 	u.Path = path.Join(u.Path, name+":getIamPolicy")
-	respBody, err = apiclient.HttpClient(u.String())
+	respBody, err = client.HttpClient(u.String())
 	return respBody, err
 }
 
 // SetIAM
-func SetIAM(name string, memberName string, permission string, memberType string) (err error) {
+func SetIAM(client *apiclient.Client, name string, memberName string, permission string, memberType string) (err error) {
 	if !isValidMemberType(memberType) {
 		return fmt.Errorf("invalid memberType. Valid types are %v", validMemberTypes)
 	}
-	return apiclient.SetConnectorIAMPermission(name, memberName, permission, memberType)
+	return client.SetConnectorIAMPermission(name, memberName, permission, memberType)
 }
 
 // TestIAM
-func TestIAM(name string, resource string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseConnectorURL())
+func TestIAM(client *apiclient.Client, name string, resource string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseConnectorURL())
 	u.Path = path.Join(u.Path, name+":testIamPermissions")
 	payload := "{\"permissions\":[\"" + resource + "\"]}"
-	respBody, err = apiclient.HttpClient(u.String(), payload)
+	respBody, err = client.HttpClient(u.String(), payload)
 	return respBody, err
 }
 
@@ -30,8 +30,8 @@ type zone struct {
 }
 
 // CreateZone
-func CreateZone(name string, content []byte) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseConnectorZonesURL())
+func CreateZone(client *apiclient.Client, name string, content []byte) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseConnectorZonesURL())
 	q := u.Query()
 	q.Set("managedZoneId", name)
 	u.RawQuery = q.Encode()
@@ -43,18 +43,19 @@ func CreateZone(name string, content []byte) (respBody []byte, err error) {
 	}
 
 	u.Path = path.Join(u.Path, name)
-	respBody, err = apiclient.HttpClient(u.String(), string(content))
+	respBody, err = client.HttpClient(u.String(), string(content))
 	return respBody, err
 }
 
 // GetZone
-func GetZone(name string, overrides bool) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseConnectorZonesURL())
+func GetZone(client *apiclient.Client, name string, overrides bool) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseConnectorZonesURL())
 	u.Path = path.Join(u.Path, name)
+	httpClient := client
 	if overrides {
-		apiclient.ClientPrintHttpResponse.Set(false)
+		httpClient = client.WithoutOutput()
 	}
-	respBody, err = apiclient.HttpClient(u.String())
+	respBody, err = httpClient.HttpClient(u.String())
 	if overrides {
 		z := zone{}
 		if err = json.Unmarshal(respBody, &z); err != nil {
@@ -66,16 +67,16 @@ func GetZone(name string, overrides bool) (respBody []byte, err error) {
 }
 
 // DeleteZone
-func DeleteZone(name string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseConnectorZonesURL())
+func DeleteZone(client *apiclient.Client, name string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseConnectorZonesURL())
 	u.Path = path.Join(u.Path, name)
-	respBody, err = apiclient.HttpClient(u.String(), "", "DELETE")
+	respBody, err = client.HttpClient(u.String(), "", "DELETE")
 	return respBody, err
 }
 
 // ListZones
-func ListZones(pageSize int, pageToken string, filter string, orderBy string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseConnectorZonesURL())
+func ListZones(client *apiclient.Client, pageSize int, pageToken string, filter string, orderBy string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseConnectorZonesURL())
 	q := u.Query()
 	if pageSize != -1 {
 		q.Set("pageSize", strconv.Itoa(pageSize))
@@ -91,6 +92,6 @@ func ListZones(pageSize int, pageToken string, filter string, orderBy string) (r
 	}
 
 	u.RawQuery = q.Encode()
-	respBody, err = apiclient.HttpClient(u.String())
+	respBody, err = client.HttpClient(u.String())
 	return respBody, err
 }
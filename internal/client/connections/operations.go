@@ -22,16 +22,16 @@ import (
 )
 
 // GetOperation
-func GetOperation(name string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseConnectorOperationsrURL())
+func GetOperation(client *apiclient.Client, name string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseConnectorOperationsrURL())
 	u.Path = path.Join(u.Path, name)
-	respBody, err = apiclient.HttpClient(u.String())
+	respBody, err = client.HttpClient(u.String())
 	return respBody, err
 }
 
 // ListOperations
-func ListOperations(pageSize int, pageToken string, filter string, orderBy string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseConnectorOperationsrURL())
+func ListOperations(client *apiclient.Client, pageSize int, pageToken string, filter string, orderBy string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseConnectorOperationsrURL())
 	q := u.Query()
 	if pageSize != -1 {
 		q.Set("pageSize", strconv.Itoa(pageSize))
@@ -47,14 +47,14 @@ func ListOperations(pageSize int, pageToken string, filter string, orderBy strin
 	}
 
 	u.RawQuery = q.Encode()
-	respBody, err = apiclient.HttpClient(u.String())
+	respBody, err = client.HttpClient(u.String())
 	return respBody, err
 }
 
 // CancelOperation
-func CancelOperation(name string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseConnectorOperationsrURL())
+func CancelOperation(client *apiclient.Client, name string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseConnectorOperationsrURL())
 	u.Path = path.Join(u.Path, name+":cancel")
-	respBody, err = apiclient.HttpClient(u.String(), "")
+	respBody, err = client.HttpClient(u.String(), "")
 	return respBody, err
 }
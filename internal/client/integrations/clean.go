@@ -20,28 +20,22 @@ import (
 	"internal/clilog"
 )
 
-func Clean(name string, reportOnly bool, keepList []string) (err error) {
+func Clean(client *apiclient.Client, name string, reportOnly bool, keepList []string) (err error) {
 	var listOfVersions []basicIntegrationVersion
-	var nextPage string
 
-	apiclient.ClientPrintHttpResponse.Set(false)
-	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
-	for {
-		respBody, err := ListVersions(name, -1, nextPage, "", "", false, false, true)
-		if err != nil {
-			return err
-		}
+	client = client.WithoutOutput()
+	err = client.ForEachPage(func(client *apiclient.Client, pageToken string) ([]byte, error) {
+		return ListVersions(client, name, -1, pageToken, "", "", false, false, true)
+	}, func(respBody []byte) error {
 		iversions := listbasicIntegrationVersions{}
-		err = json.Unmarshal(respBody, &iversions)
-		if err != nil {
+		if err := json.Unmarshal(respBody, &iversions); err != nil {
 			return err
 		}
-
 		listOfVersions = append(listOfVersions, iversions.BasicIntegrationVersions...)
-		if iversions.NextPageToken == "" {
-			break
-		}
-		nextPage = iversions.NextPageToken
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
 	if len(listOfVersions) == 0 {
@@ -54,7 +48,7 @@ func Clean(name string, reportOnly bool, keepList []string) (err error) {
 			if reportOnly {
 				clilog.Info.Println("[REPORT]: Integration '" + name + "' Version: " + iversion.Version + " and Snapshot " + iversion.SnapshotNumber + " can be cleaned")
 			} else {
-				_, err = DeleteVersion(name, iversion.Version)
+				_, err = DeleteVersion(client, name, iversion.Version)
 				if err != nil {
 					return err
 				}
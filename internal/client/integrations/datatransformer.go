@@ -0,0 +1,303 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/google/go-jsonnet"
+	"github.com/google/go-jsonnet/ast"
+	"github.com/google/uuid"
+)
+
+// dataTransformerFunctions is a local implementation of the Data Transformer function library,
+// imported by templates with local f = import "functions"
+const dataTransformerFunctions = `{
+  abs(num):: std.abs(num),
+  append(arr, element):: arr + [element],
+  avg(arr):: std.foldl(function(a, b) a + b, arr, 0) / std.length(arr),
+  ceil(num):: std.ceil(num),
+  contains(arr, element):: std.member(arr, element),
+  floor(num):: std.floor(num),
+  groupBy(arr, func):: std.foldl(function(acc, e) acc + {
+    [std.toString(func(e))]+: [e],
+  }, arr, {}),
+  isDecimal(num):: std.isNumber(num) && std.floor(num) != num,
+  isEven(num):: num % 2 == 0,
+  isOdd(num):: num % 2 == 1,
+  join(arr, sep):: std.join(sep, arr),
+  max(arr):: std.foldl(std.max, arr, arr[0]),
+  min(arr):: std.foldl(std.min, arr, arr[0]),
+  parseJson(str):: std.parseJson(str),
+  pow(base, exp):: std.pow(base, exp),
+  remove(arr, element):: [e for e in arr if e != element],
+  removeAt(arr, index):: [arr[i] for i in std.range(0, std.length(arr) - 1) if i != index],
+  round(num):: std.round(num),
+  sum(arr):: std.foldl(function(a, b) a + b, arr, 0),
+  sha1(str):: std.native("sha1")(str),
+  sha256(str):: std.native("sha256")(str),
+  sha512(str):: std.native("sha512")(str),
+  uuid():: std.native("uuid")(),
+  xmlToJson(str):: std.native("xmlToJson")(str),
+  getExecutionId():: std.native("getVariable")("ExecutionId"),
+  getIntegrationName():: std.native("getVariable")("IntegrationName"),
+  getIntegrationRegion():: std.native("getVariable")("Region"),
+  getProjectId():: std.native("getVariable")("ProjectId"),
+}
+`
+
+// dataTransformerImporter resolves the functions library and falls back to files
+// relative to the template
+type dataTransformerImporter struct {
+	fileImporter *jsonnet.FileImporter
+}
+
+func (i *dataTransformerImporter) Import(importedFrom, importedPath string) (jsonnet.Contents, string, error) {
+	if importedPath == "functions" {
+		return jsonnet.MakeContents(dataTransformerFunctions), "functions", nil
+	}
+	return i.fileImporter.Import(importedFrom, importedPath)
+}
+
+// EvaluateDataTransformer evaluates a Data Transformer Jsonnet template locally. Integration variables
+// from the inputs are available through std.extVar; the output variables are returned as json
+func EvaluateDataTransformer(filename string, template []byte, inputs []byte) ([]byte, error) {
+	variables, err := ParseVariables(inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	vm := jsonnet.MakeVM()
+	vm.Importer(&dataTransformerImporter{
+		fileImporter: &jsonnet.FileImporter{JPaths: []string{filepath.Dir(filename)}},
+	})
+	for key, value := range variables {
+		b, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		vm.ExtCode(key, string(b))
+	}
+
+	hash := func(h func([]byte) []byte) func(args []interface{}) (interface{}, error) {
+		return func(args []interface{}) (interface{}, error) {
+			s, ok := args[0].(string)
+			if !ok {
+				return nil, fmt.Errorf("expected a string argument")
+			}
+			return hex.EncodeToString(h([]byte(s))), nil
+		}
+	}
+	vm.NativeFunction(&jsonnet.NativeFunction{Name: "sha1", Params: ast.Identifiers{"str"},
+		Func: hash(func(b []byte) []byte { s := sha1.Sum(b); return s[:] })})
+	vm.NativeFunction(&jsonnet.NativeFunction{Name: "sha256", Params: ast.Identifiers{"str"},
+		Func: hash(func(b []byte) []byte { s := sha256.Sum256(b); return s[:] })})
+	vm.NativeFunction(&jsonnet.NativeFunction{Name: "sha512", Params: ast.Identifiers{"str"},
+		Func: hash(func(b []byte) []byte { s := sha512.Sum512(b); return s[:] })})
+	vm.NativeFunction(&jsonnet.NativeFunction{Name: "uuid", Params: ast.Identifiers{},
+		Func: func(args []interface{}) (interface{}, error) {
+			return uuid.NewString(), nil
+		}})
+	vm.NativeFunction(&jsonnet.NativeFunction{Name: "xmlToJson", Params: ast.Identifiers{"str"},
+		Func: func(args []interface{}) (interface{}, error) {
+			s, ok := args[0].(string)
+			if !ok {
+				return nil, fmt.Errorf("expected a string argument")
+			}
+			return xmlToJson(s)
+		}})
+	vm.NativeFunction(&jsonnet.NativeFunction{Name: "getVariable", Params: ast.Identifiers{"name"},
+		Func: func(args []interface{}) (interface{}, error) {
+			name, _ := args[0].(string)
+			if value, ok := variables[name]; ok {
+				return value, nil
+			}
+			if value, ok := variables["`"+name+"`"]; ok {
+				return value, nil
+			}
+			return "", nil
+		}})
+
+	output, err := vm.EvaluateAnonymousSnippet(filename, string(template))
+	if err != nil {
+		return nil, err
+	}
+	return []byte(output), nil
+}
+
+// ParseVariables reads integration variables from a json object of variable names and values,
+// or from a test configuration with typed inputParameters
+func ParseVariables(content []byte) (variables map[string]interface{}, err error) {
+	variables = make(map[string]interface{})
+	if len(bytes.TrimSpace(content)) == 0 {
+		return variables, nil
+	}
+	if err = json.Unmarshal(content, &variables); err != nil {
+		return nil, fmt.Errorf("unable to parse variables: %w", err)
+	}
+
+	inputParameters, ok := variables["inputParameters"].(map[string]interface{})
+	if !ok || len(variables) != 1 {
+		return variables, nil
+	}
+
+	// test configuration, unwrap typed values such as {"stringValue": "a"}
+	variables = make(map[string]interface{})
+	for key, value := range inputParameters {
+		typed, ok := value.(map[string]interface{})
+		if !ok || len(typed) != 1 {
+			variables[key] = value
+			continue
+		}
+		for valueType, v := range typed {
+			switch valueType {
+			case "stringArray", "intArray", "doubleArray", "booleanArray":
+				if values, ok := v.(map[string]interface{}); ok && len(values) == 1 {
+					for _, array := range values {
+						v = array
+					}
+				}
+			case "jsonValue":
+				if s, ok := v.(string); ok {
+					var parsed interface{}
+					if json.Unmarshal([]byte(s), &parsed) == nil {
+						v = parsed
+					}
+				}
+			}
+			variables[key] = v
+		}
+	}
+	return variables, nil
+}
+
+// CompareVariables compares the actual and expected variables and returns a message
+// for every variable that is different
+func CompareVariables(actual []byte, expected []byte) (mismatches []string, err error) {
+	return compareVariables(actual, expected, false)
+}
+
+// AssertVariables compares only the variables in expected with the actual variables
+// and returns a message for every variable that is missing or different
+func AssertVariables(actual []byte, expected []byte) (mismatches []string, err error) {
+	return compareVariables(actual, expected, true)
+}
+
+func compareVariables(actual []byte, expected []byte, partial bool) (mismatches []string, err error) {
+	actualVariables, expectedVariables := make(map[string]interface{}), make(map[string]interface{})
+	if err = json.Unmarshal(actual, &actualVariables); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(expected, &expectedVariables); err != nil {
+		return nil, fmt.Errorf("unable to parse expected variables: %w", err)
+	}
+
+	keys := make([]string, 0, len(actualVariables)+len(expectedVariables))
+	for key := range expectedVariables {
+		keys = append(keys, key)
+	}
+	for key := range actualVariables {
+		if _, ok := expectedVariables[key]; !ok && !partial {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		actualValue, inActual := actualVariables[key]
+		expectedValue, inExpected := expectedVariables[key]
+		switch {
+		case !inActual:
+			mismatches = append(mismatches, fmt.Sprintf("variable %s is missing", key))
+		case !inExpected:
+			mismatches = append(mismatches, fmt.Sprintf("variable %s was not expected", key))
+		case !reflect.DeepEqual(actualValue, expectedValue):
+			a, _ := json.Marshal(actualValue)
+			e, _ := json.Marshal(expectedValue)
+			mismatches = append(mismatches, fmt.Sprintf("variable %s is %s, expected %s", key, a, e))
+		}
+	}
+	return mismatches, nil
+}
+
+// xmlToJson converts an xml document to a json object. Attributes are prefixed with @,
+// text in elements with attributes or children is stored as #text and repeated elements become arrays
+func xmlToJson(s string) (interface{}, error) {
+	decoder := xml.NewDecoder(strings.NewReader(s))
+
+	type element struct {
+		name     string
+		children map[string]interface{}
+		text     strings.Builder
+	}
+	root := &element{children: make(map[string]interface{})}
+	stack := []*element{root}
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			e := &element{name: t.Name.Local, children: make(map[string]interface{})}
+			for _, attr := range t.Attr {
+				e.children["@"+attr.Name.Local] = attr.Value
+			}
+			stack = append(stack, e)
+		case xml.CharData:
+			stack[len(stack)-1].text.Write(t)
+		case xml.EndElement:
+			e := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			var value interface{}
+			text := strings.TrimSpace(e.text.String())
+			if len(e.children) == 0 {
+				value = text
+			} else {
+				if text != "" {
+					e.children["#text"] = text
+				}
+				value = e.children
+			}
+			parent := stack[len(stack)-1].children
+			if existing, ok := parent[e.name]; ok {
+				if array, ok := existing.([]interface{}); ok {
+					parent[e.name] = append(array, value)
+				} else {
+					parent[e.name] = []interface{}{existing, value}
+				}
+			} else {
+				parent[e.name] = value
+			}
+		}
+	}
+	return root.children, nil
+}
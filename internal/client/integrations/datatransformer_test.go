@@ -0,0 +1,57 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"testing"
+)
+
+func TestEvaluateDataTransformer(t *testing.T) {
+	template := []byte(`local f = import "functions";
+local order = std.extVar("order");
+{
+  "total": f.sum([item.price for item in order.items]),
+  "customer": std.asciiUpper(std.extVar("customer")),
+  "hash": f.sha256("a"),
+  "xml": f.xmlToJson("<a id=\"1\"><b>x</b><b>y</b></a>"),
+}`)
+	inputs := []byte(`{"inputParameters": {
+		"order": {"jsonValue": "{\"items\": [{\"price\": 1.5}, {\"price\": 2}]}"},
+		"customer": {"stringValue": "acme"}
+	}}`)
+
+	output, err := EvaluateDataTransformer("datatransformer_1.jsonnet", template, inputs)
+	if err != nil {
+		t.Fatalf("EvaluateDataTransformer failed: %v", err)
+	}
+
+	expected := []byte(`{
+		"total": 3.5,
+		"customer": "ACME",
+		"hash": "ca978112ca1bbdcafac231b39a23dc4da786eff8147c4e72b9807785afee48bb",
+		"xml": {"a": {"@id": "1", "b": ["x", "y"]}}
+	}`)
+	mismatches, err := CompareVariables(output, expected)
+	if err != nil {
+		t.Fatalf("CompareVariables failed: %v", err)
+	}
+	if len(mismatches) > 0 {
+		t.Errorf("unexpected output %s: %v", string(output), mismatches)
+	}
+
+	if _, err = EvaluateDataTransformer("datatransformer_2.jsonnet", []byte(`{"a": std.extVar("missing")}`), nil); err == nil {
+		t.Errorf("expected an error for an undefined variable")
+	}
+}
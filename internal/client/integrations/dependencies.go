@@ -0,0 +1,97 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"encoding/json"
+	"internal/clilog"
+	"sort"
+	"strings"
+)
+
+// subIntegrationTask is the prefix of the tasks that call an integration: Call Integration,
+// For Each Loop, For Each Parallel and While Loop
+const subIntegrationTask = "SubWorkflow"
+
+// GetSubIntegrations returns the names of the integrations referenced by Call Integration
+// (and loop) tasks in an integration version
+func GetSubIntegrations(content []byte) (names []string, err error) {
+	iversion := integrationVersion{}
+	if err = json.Unmarshal(content, &iversion); err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]bool)
+	for _, task := range iversion.TaskConfigs {
+		if !strings.HasPrefix(task.Task, subIntegrationTask) {
+			continue
+		}
+		workflowName, ok := task.Parameters["workflowName"]
+		if !ok || workflowName.Value.StringValue == nil || *workflowName.Value.StringValue == "" {
+			continue
+		}
+		if !found[*workflowName.Value.StringValue] {
+			found[*workflowName.Value.StringValue] = true
+			names = append(names, *workflowName.Value.StringValue)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// SortByDependencies orders integrations so that every integration appears after the integrations
+// it calls. dependencies maps an integration name to the sub-integrations it calls; sub-integrations
+// that are not keys in the map are ignored. An integration may call itself, other circular calls
+// are reported with a warning and the integrations of the cycle are sorted by name
+func SortByDependencies(dependencies map[string][]string) (sorted []string) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int)
+
+	names := make([]string, 0, len(dependencies))
+	for name := range dependencies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var visit func(name string, chain []string)
+	visit = func(name string, chain []string) {
+		switch state[name] {
+		case visited:
+			return
+		case visiting:
+			clilog.Warning.Printf("circular dependency between integrations: %s\n",
+				strings.Join(append(chain, name), " -> "))
+			return
+		}
+		state[name] = visiting
+		for _, dependency := range dependencies[name] {
+			if _, ok := dependencies[dependency]; !ok || dependency == name {
+				continue
+			}
+			visit(dependency, append(chain, name))
+		}
+		state[name] = visited
+		sorted = append(sorted, name)
+	}
+
+	for _, name := range names {
+		visit(name, nil)
+	}
+	return sorted
+}
@@ -0,0 +1,63 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"internal/clilog"
+	"reflect"
+	"testing"
+)
+
+func TestGetSubIntegrations(t *testing.T) {
+	content := []byte(`{"taskConfigs": [
+		{"taskId": "1", "task": "SubWorkflowExecutorV2Task", "parameters": {"workflowName": {"key": "workflowName", "value": {"stringValue": "child-b"}}}},
+		{"taskId": "2", "task": "SubWorkflowForEachLoopV2Task", "parameters": {"workflowName": {"key": "workflowName", "value": {"stringValue": "child-a"}}}},
+		{"taskId": "3", "task": "SubWorkflowExecutorV2Task", "parameters": {"workflowName": {"key": "workflowName", "value": {"stringValue": "child-b"}}}},
+		{"taskId": "4", "task": "FieldMappingTask"},
+		{"taskId": "5", "task": "GenericConnectorTask", "parameters": {"workflowName": {"key": "workflowName", "value": {"stringValue": "not-a-call"}}}}
+	]}`)
+	names, err := GetSubIntegrations(content)
+	if err != nil {
+		t.Fatalf("GetSubIntegrations failed: %v", err)
+	}
+	if !reflect.DeepEqual(names, []string{"child-a", "child-b"}) {
+		t.Errorf("unexpected sub-integrations %v", names)
+	}
+}
+
+func TestSortByDependencies(t *testing.T) {
+	clilog.Init(false, false, true, true)
+
+	sorted := SortByDependencies(map[string][]string{
+		"main":    {"child-b", "child-a", "external"},
+		"child-a": {"child-b"},
+		"child-b": nil,
+	})
+	if !reflect.DeepEqual(sorted, []string{"child-b", "child-a", "main"}) {
+		t.Errorf("unexpected order %v", sorted)
+	}
+
+	// an integration that calls itself
+	sorted = SortByDependencies(map[string][]string{"a": {"a", "b"}, "b": nil})
+	if !reflect.DeepEqual(sorted, []string{"b", "a"}) {
+		t.Errorf("unexpected order with a self call %v", sorted)
+	}
+
+	// mutual calls are sorted with a warning
+	sorted = SortByDependencies(map[string][]string{"a": {"b"}, "b": {"a"}, "main": {"a"}})
+	if !reflect.DeepEqual(sorted, []string{"b", "a", "main"}) {
+		t.Errorf("unexpected order with mutual calls %v", sorted)
+	}
+}
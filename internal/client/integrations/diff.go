@@ -0,0 +1,298 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+)
+
+// kinds of changes reported by Diff
+const (
+	DiffAdded   = "added"
+	DiffRemoved = "removed"
+	DiffChanged = "changed"
+)
+
+// DiffChange is a semantic difference between two integration versions
+type DiffChange struct {
+	Kind    string      `json:"kind"`
+	Element string      `json:"element"`
+	Id      string      `json:"id,omitempty"`
+	Name    string      `json:"name,omitempty"`
+	Field   string      `json:"field,omitempty"`
+	From    interface{} `json:"from,omitempty"`
+	To      interface{} `json:"to,omitempty"`
+}
+
+// edge is a transition from a trigger, task or error catcher to a task
+type edge struct {
+	from      string
+	to        string
+	condition string
+}
+
+// Diff compares two integration versions and returns the tasks, triggers, edges, variables
+// and settings that were added, removed or changed. Fields such as the version name,
+// snapshot number, user label and timestamps are ignored
+func Diff(fromContent []byte, toContent []byte) (changes []DiffChange, err error) {
+	fromVersion, toVersion := integrationVersion{}, integrationVersion{}
+	if err = json.Unmarshal(fromContent, &fromVersion); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(toContent, &toVersion); err != nil {
+		return nil, err
+	}
+	from, to := convertInternalToExternal(fromVersion), convertInternalToExternal(toVersion)
+
+	// settings
+	changes = append(changes, diffObjects("integration", "", "", settingsMap(from), settingsMap(to), nil)...)
+
+	// triggers
+	fromTriggers, toTriggers := make(map[string]interface{}), make(map[string]interface{})
+	triggerNames := make(map[string]string)
+	for _, t := range from.TriggerConfigs {
+		fromTriggers[t.TriggerNumber] = t
+		triggerNames[t.TriggerNumber] = triggerLabel(t)
+	}
+	for _, t := range to.TriggerConfigs {
+		toTriggers[t.TriggerNumber] = t
+		triggerNames[t.TriggerNumber] = triggerLabel(t)
+	}
+	changes = append(changes, diffElements("trigger", fromTriggers, toTriggers, triggerNames,
+		[]string{"startTasks"}, map[string]bool{"properties": true})...)
+
+	// tasks
+	fromTasks, toTasks := make(map[string]interface{}), make(map[string]interface{})
+	taskNames := make(map[string]string)
+	for _, t := range from.TaskConfigs {
+		fromTasks[t.TaskId] = t
+		taskNames[t.TaskId] = taskLabel(t)
+	}
+	for _, t := range to.TaskConfigs {
+		toTasks[t.TaskId] = t
+		taskNames[t.TaskId] = taskLabel(t)
+	}
+	changes = append(changes, diffElements("task", fromTasks, toTasks, taskNames,
+		[]string{"nextTasks"}, map[string]bool{"parameters": true})...)
+
+	// error catchers
+	fromCatchers, toCatchers := make(map[string]interface{}), make(map[string]interface{})
+	for _, e := range from.ErrorCatcherConfigs {
+		fromCatchers[e.ErrorCatcherId] = e
+	}
+	for _, e := range to.ErrorCatcherConfigs {
+		toCatchers[e.ErrorCatcherId] = e
+	}
+	changes = append(changes, diffElements("errorCatcher", fromCatchers, toCatchers, nil,
+		[]string{"startErrorTasks"}, nil)...)
+
+	// edges and conditions
+	changes = append(changes, diffEdges(getEdges(from), getEdges(to))...)
+
+	// variables
+	fromParams, toParams := make(map[string]interface{}), make(map[string]interface{})
+	for _, p := range from.IntegrationParameters {
+		fromParams[p.Key] = p
+	}
+	for _, p := range to.IntegrationParameters {
+		toParams[p.Key] = p
+	}
+	changes = append(changes, diffElements("variable", fromParams, toParams, nil, nil, nil)...)
+
+	fromConfigParams, toConfigParams := make(map[string]interface{}), make(map[string]interface{})
+	for _, p := range from.IntegrationConfigParameters {
+		fromConfigParams[p.Parameter.Key] = p
+	}
+	for _, p := range to.IntegrationConfigParameters {
+		toConfigParams[p.Parameter.Key] = p
+	}
+	changes = append(changes, diffElements("configVariable", fromConfigParams, toConfigParams, nil, nil, nil)...)
+
+	return changes, nil
+}
+
+// settingsMap returns the integration level settings that are compared
+func settingsMap(v integrationVersionExternal) map[string]interface{} {
+	return toJsonMap(struct {
+		Description               string              `json:"description,omitempty"`
+		DatabasePersistencePolicy string              `json:"databasePersistencePolicy,omitempty"`
+		CloudLoggingDetails       cloudLoggingDetails `json:"cloudLoggingDetails,omitempty"`
+		EnableVariableMasking     bool                `json:"enableVariableMasking,omitempty"`
+	}{v.Description, v.DatabasePersistencePolicy, v.CloudLoggingDetails, v.EnableVariableMasking})
+}
+
+// diffElements compares elements by id. Fields in ignore are compared elsewhere,
+// fields in nested are compared per key
+func diffElements(element string, from map[string]interface{}, to map[string]interface{},
+	names map[string]string, ignore []string, nested map[string]bool,
+) (changes []DiffChange) {
+	for _, id := range sortedKeys(from, to) {
+		fromElement, inFrom := from[id]
+		toElement, inTo := to[id]
+		switch {
+		case !inTo:
+			changes = append(changes, DiffChange{Kind: DiffRemoved, Element: element, Id: id, Name: names[id]})
+		case !inFrom:
+			changes = append(changes, DiffChange{Kind: DiffAdded, Element: element, Id: id, Name: names[id]})
+		default:
+			fromMap, toMap := toJsonMap(fromElement), toJsonMap(toElement)
+			for _, field := range ignore {
+				delete(fromMap, field)
+				delete(toMap, field)
+			}
+			changes = append(changes, diffObjects(element, id, names[id], fromMap, toMap, nested)...)
+		}
+	}
+	return changes
+}
+
+// diffObjects compares the fields of two json objects
+func diffObjects(element string, id string, name string, from map[string]interface{}, to map[string]interface{},
+	nested map[string]bool,
+) (changes []DiffChange) {
+	for _, field := range sortedKeys(from, to) {
+		fromValue, toValue := from[field], to[field]
+		if reflect.DeepEqual(fromValue, toValue) {
+			continue
+		}
+		if nested[field] {
+			fromNested, _ := fromValue.(map[string]interface{})
+			toNested, _ := toValue.(map[string]interface{})
+			for _, key := range sortedKeys(fromNested, toNested) {
+				if reflect.DeepEqual(fromNested[key], toNested[key]) {
+					continue
+				}
+				changes = append(changes, newChange(element, id, name, field+"."+key, fromNested[key], toNested[key]))
+			}
+			continue
+		}
+		changes = append(changes, newChange(element, id, name, field, fromValue, toValue))
+	}
+	return changes
+}
+
+func newChange(element string, id string, name string, field string, from interface{}, to interface{}) DiffChange {
+	kind := DiffChanged
+	if from == nil {
+		kind = DiffAdded
+	} else if to == nil {
+		kind = DiffRemoved
+	}
+	return DiffChange{Kind: kind, Element: element, Id: id, Name: name, Field: field, From: from, To: to}
+}
+
+// getEdges returns the transitions of an integration keyed by source and target
+func getEdges(v integrationVersionExternal) map[string]edge {
+	edges := make(map[string]edge)
+	for _, t := range v.TriggerConfigs {
+		for _, next := range t.StartTasks {
+			e := edge{from: "trigger " + t.TriggerNumber, to: "task " + next.TaskId, condition: next.Condition}
+			edges[e.from+" -> "+e.to] = e
+		}
+	}
+	for _, t := range v.TaskConfigs {
+		for _, next := range t.NextTasks {
+			e := edge{from: "task " + t.TaskId, to: "task " + next.TaskId, condition: next.Condition}
+			edges[e.from+" -> "+e.to] = e
+		}
+	}
+	for _, c := range v.ErrorCatcherConfigs {
+		for _, next := range c.StartErrorTasks {
+			e := edge{from: "errorCatcher " + c.ErrorCatcherId, to: "task " + next.TaskId}
+			edges[e.from+" -> "+e.to] = e
+		}
+	}
+	return edges
+}
+
+func diffEdges(from map[string]edge, to map[string]edge) (changes []DiffChange) {
+	keys := make(map[string]bool)
+	for key := range from {
+		keys[key] = true
+	}
+	for key := range to {
+		keys[key] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+
+	for _, key := range sorted {
+		fromEdge, inFrom := from[key]
+		toEdge, inTo := to[key]
+		switch {
+		case !inTo:
+			changes = append(changes, DiffChange{Kind: DiffRemoved, Element: "edge", Id: key, From: emptyToNil(fromEdge.condition)})
+		case !inFrom:
+			changes = append(changes, DiffChange{Kind: DiffAdded, Element: "edge", Id: key, To: emptyToNil(toEdge.condition)})
+		case fromEdge.condition != toEdge.condition:
+			changes = append(changes, DiffChange{
+				Kind: DiffChanged, Element: "edge", Id: key, Field: "condition",
+				From: fromEdge.condition, To: toEdge.condition,
+			})
+		}
+	}
+	return changes
+}
+
+func emptyToNil(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func taskLabel(t taskconfig) string {
+	if t.DisplayName != "" {
+		return t.DisplayName
+	}
+	return t.Task
+}
+
+func triggerLabel(t triggerconfig) string {
+	if t.Label != "" {
+		return t.Label
+	}
+	return t.TriggerType
+}
+
+// toJsonMap converts a struct to a generic json object
+func toJsonMap(v interface{}) map[string]interface{} {
+	m := make(map[string]interface{})
+	b, err := json.Marshal(v)
+	if err != nil {
+		return m
+	}
+	_ = json.Unmarshal(b, &m)
+	return m
+}
+
+func sortedKeys(from map[string]interface{}, to map[string]interface{}) []string {
+	keys := make([]string, 0, len(from)+len(to))
+	for key := range from {
+		keys = append(keys, key)
+	}
+	for key := range to {
+		if _, ok := from[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
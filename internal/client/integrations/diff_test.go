@@ -0,0 +1,75 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	from := []byte(`{
+		"name": "projects/p/locations/l/integrations/sample/versions/1",
+		"snapshotNumber": "1",
+		"updateTime": "2024-01-01T00:00:00Z",
+		"triggerConfigs": [{"triggerNumber": "1", "triggerType": "API", "triggerId": "api_trigger/a", "startTasks": [{"taskId": "1"}]}],
+		"taskConfigs": [
+			{"taskId": "1", "task": "GenericRestV2Task", "parameters": {"url": {"key": "url", "value": {"stringValue": "https://dev"}}},
+				"nextTasks": [{"taskId": "2"}]},
+			{"taskId": "2", "task": "FieldMappingTask"}
+		],
+		"integrationParameters": [{"key": "a", "dataType": "STRING_VALUE"}]
+	}`)
+	to := []byte(`{
+		"name": "projects/p/locations/l/integrations/sample/versions/2",
+		"snapshotNumber": "2",
+		"updateTime": "2024-02-01T00:00:00Z",
+		"triggerConfigs": [{"triggerNumber": "1", "triggerType": "API", "triggerId": "api_trigger/b", "startTasks": [{"taskId": "1"}]}],
+		"taskConfigs": [
+			{"taskId": "1", "task": "GenericRestV2Task", "parameters": {"url": {"key": "url", "value": {"stringValue": "https://prod"}}},
+				"nextTasks": [{"taskId": "2", "condition": "$a$ = \"x\""}, {"taskId": "3"}]},
+			{"taskId": "2", "task": "FieldMappingTask"},
+			{"taskId": "3", "task": "EmailTask"}
+		],
+		"integrationParameters": [{"key": "b", "dataType": "STRING_VALUE"}]
+	}`)
+
+	changes, err := Diff(from, to)
+	if err != nil {
+		t.Fatalf("Diff failed: %v", err)
+	}
+
+	want := []struct{ kind, element, id, field string }{
+		{DiffChanged, "trigger", "1", "triggerId"},
+		{DiffChanged, "task", "1", "parameters.url"},
+		{DiffAdded, "task", "3", ""},
+		{DiffChanged, "edge", "task 1 -> task 2", "condition"},
+		{DiffAdded, "edge", "task 1 -> task 3", ""},
+		{DiffRemoved, "variable", "a", ""},
+		{DiffAdded, "variable", "b", ""},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("expected %d changes, got %d: %+v", len(want), len(changes), changes)
+	}
+	for i, w := range want {
+		c := changes[i]
+		if c.Kind != w.kind || c.Element != w.element || c.Id != w.id || c.Field != w.field {
+			t.Errorf("change %d: expected %+v, got %+v", i, w, c)
+		}
+	}
+
+	if changes, err = Diff(from, from); err != nil || len(changes) != 0 {
+		t.Errorf("expected no changes, got %+v, %v", changes, err)
+	}
+}
@@ -66,8 +66,8 @@ type executionResponse struct {
 }
 
 // ListExecutions lists all executions
-func ListExecutions(name string, pageSize int, pageToken string, filter string, orderBy string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+func ListExecutions(client *apiclient.Client, name string, pageSize int, pageToken string, filter string, orderBy string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	q := u.Query()
 	if pageSize != -1 {
 		q.Set("pageSize", strconv.Itoa(pageSize))
@@ -84,12 +84,20 @@ func ListExecutions(name string, pageSize int, pageToken string, filter string,
 
 	u.RawQuery = q.Encode()
 	u.Path = path.Join(u.Path, "integrations", name, "executions")
-	respBody, err = apiclient.HttpClient(u.String())
+	respBody, err = client.HttpClient(u.String())
 	return respBody, err
 }
 
+// ListAllExecutions lists the executions of every page, with a limit greater than 0
+// no more than limit executions are returned
+func ListAllExecutions(client *apiclient.Client, name string, pageSize int, filter string, orderBy string, limit int) (respBody []byte, err error) {
+	return client.ListAll(func(client *apiclient.Client, pageToken string, pageSize int) ([]byte, error) {
+		return ListExecutions(client, name, pageSize, pageToken, filter, orderBy)
+	}, pageSize, limit)
+}
+
 // Execute
-func Execute(name string, content []byte) (respBody []byte, err error) {
+func Execute(client *apiclient.Client, name string, content []byte) (respBody []byte, err error) {
 	e := execute{}
 	if err = json.Unmarshal(content, &e); err != nil {
 		return nil, err
@@ -100,10 +108,9 @@ func Execute(name string, content []byte) (respBody []byte, err error) {
 		return nil, fmt.Errorf("triggerId must match the format api_trigger/*")
 	}
 
-	apiclient.ClientPrintHttpResponse.Set(false)
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	u.Path = path.Join(u.Path, "integrations", name+":execute")
-	respBody, err = apiclient.HttpClient(u.String(), string(content))
+	respBody, err = client.WithoutOutput().HttpClient(u.String(), string(content))
 	if err != nil {
 		return nil, err
 	}
@@ -122,24 +129,23 @@ func Execute(name string, content []byte) (respBody []byte, err error) {
 		return nil, err
 	}
 
-	apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
-	apiclient.PrettyPrint(respBody)
+	client.PrettyPrint(respBody)
 
 	return respBody, err
 }
 
-func Cancel(name string, executionID string, cancelReason string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+func Cancel(client *apiclient.Client, name string, executionID string, cancelReason string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	u.Path = path.Join(u.Path, "integrations", name, "executions", executionID, ":cancel")
 	payload := "{ \"cancelReason\":\"" + cancelReason + "\"}"
-	respBody, err = apiclient.HttpClient(u.String(), payload)
+	respBody, err = client.HttpClient(u.String(), payload)
 	return respBody, err
 }
 
-func Replay(name string, executionID string, replayReason string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+func Replay(client *apiclient.Client, name string, executionID string, replayReason string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	u.Path = path.Join(u.Path, "integrations", name, "executions", executionID, ":replay")
 	payload := "{ \"replayReason\":\"" + replayReason + "\"}"
-	respBody, err = apiclient.HttpClient(u.String(), payload)
+	respBody, err = client.HttpClient(u.String(), payload)
 	return respBody, err
 }
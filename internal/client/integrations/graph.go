@@ -0,0 +1,213 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// graph formats
+const (
+	MermaidGraph = "mermaid"
+	DotGraph     = "dot"
+)
+
+type graphNode struct {
+	id    string
+	kind  string // trigger, task or errorCatcher
+	lines []string
+}
+
+type graphEdge struct {
+	from  string
+	to    string
+	label string
+	error bool // edges to and from error catchers
+}
+
+// RenderGraph renders the triggers, tasks and error catchers of an integration version
+// as a Mermaid flowchart or a Graphviz DOT digraph
+func RenderGraph(content []byte, format string) (string, error) {
+	iversion := integrationVersion{}
+	if err := json.Unmarshal(content, &iversion); err != nil {
+		return "", err
+	}
+
+	var nodes []graphNode
+	var edges []graphEdge
+
+	for _, trigger := range iversion.TriggerConfigs {
+		id := "trigger_" + graphId(trigger.TriggerNumber)
+		lines := []string{trigger.TriggerType}
+		if trigger.Label != "" {
+			lines = []string{trigger.Label}
+		}
+		if trigger.TriggerId != "" {
+			lines = append(lines, trigger.TriggerId)
+		}
+		nodes = append(nodes, graphNode{id: id, kind: "trigger", lines: lines})
+		for _, startTask := range trigger.StartTasks {
+			edges = append(edges, graphEdge{from: id, to: "task_" + graphId(startTask.TaskId), label: startTask.Condition})
+		}
+		if trigger.ErrorCatcherId != "" {
+			edges = append(edges, graphEdge{
+				from: id, to: "errorcatcher_" + graphId(trigger.ErrorCatcherId),
+				label: "on error", error: true,
+			})
+		}
+	}
+
+	for _, task := range iversion.TaskConfigs {
+		id := "task_" + graphId(task.TaskId)
+		title := task.DisplayName
+		if title == "" {
+			title = task.Task
+		}
+		nodes = append(nodes, graphNode{
+			id: id, kind: "task",
+			lines: []string{task.TaskId + ": " + title, task.Task},
+		})
+		for _, next := range task.NextTasks {
+			edges = append(edges, graphEdge{from: id, to: "task_" + graphId(next.TaskId), label: next.Condition})
+		}
+		if task.ErrorCatcherId != "" {
+			edges = append(edges, graphEdge{
+				from: id, to: "errorcatcher_" + graphId(task.ErrorCatcherId),
+				label: "on error", error: true,
+			})
+		}
+	}
+
+	for _, errorCatcher := range iversion.ErrorCatcherConfigs {
+		id := "errorcatcher_" + graphId(errorCatcher.ErrorCatcherId)
+		title := errorCatcher.Label
+		if title == "" {
+			title = "Error catcher " + errorCatcher.ErrorCatcherNumber
+		}
+		nodes = append(nodes, graphNode{id: id, kind: "errorCatcher", lines: []string{title, errorCatcher.ErrorCatcherId}})
+		for _, startTask := range errorCatcher.StartErrorTasks {
+			edges = append(edges, graphEdge{from: id, to: "task_" + graphId(startTask.TaskId), error: true})
+		}
+	}
+
+	switch format {
+	case MermaidGraph:
+		return renderMermaid(nodes, edges), nil
+	case DotGraph:
+		return renderDot(nodes, edges), nil
+	default:
+		return "", fmt.Errorf("unsupported graph format %s", format)
+	}
+}
+
+func renderMermaid(nodes []graphNode, edges []graphEdge) string {
+	escape := func(s string) string {
+		s = strings.ReplaceAll(s, "\"", "#quot;")
+		return strings.ReplaceAll(s, "\n", "<br/>")
+	}
+
+	graph := strings.Builder{}
+	graph.WriteString("flowchart TD\n")
+	for _, node := range nodes {
+		var lines []string
+		for _, line := range node.lines {
+			lines = append(lines, escape(line))
+		}
+		label := "\"" + strings.Join(lines, "<br/>") + "\""
+		switch node.kind {
+		case "trigger":
+			graph.WriteString(fmt.Sprintf("    %s([%s]):::trigger\n", node.id, label))
+		case "errorCatcher":
+			graph.WriteString(fmt.Sprintf("    %s{{%s}}:::errorCatcher\n", node.id, label))
+		default:
+			graph.WriteString(fmt.Sprintf("    %s[%s]:::task\n", node.id, label))
+		}
+	}
+	for _, edge := range edges {
+		arrow := "-->"
+		if edge.error {
+			arrow = "-.->"
+		}
+		if edge.label != "" {
+			graph.WriteString(fmt.Sprintf("    %s %s|\"%s\"| %s\n", edge.from, arrow, escape(edge.label), edge.to))
+		} else {
+			graph.WriteString(fmt.Sprintf("    %s %s %s\n", edge.from, arrow, edge.to))
+		}
+	}
+	graph.WriteString("    classDef trigger fill:#e3f2fd,stroke:#1565c0\n")
+	graph.WriteString("    classDef task fill:#f5f5f5,stroke:#424242\n")
+	graph.WriteString("    classDef errorCatcher fill:#ffebee,stroke:#c62828\n")
+	return graph.String()
+}
+
+func renderDot(nodes []graphNode, edges []graphEdge) string {
+	escape := func(s string) string {
+		s = strings.ReplaceAll(s, "\\", "\\\\")
+		s = strings.ReplaceAll(s, "\"", "\\\"")
+		return strings.ReplaceAll(s, "\n", "\\n")
+	}
+
+	graph := strings.Builder{}
+	graph.WriteString("digraph integration {\n")
+	graph.WriteString("    rankdir=TB;\n")
+	graph.WriteString("    node [fontname=\"Helvetica\"];\n")
+	graph.WriteString("    edge [fontname=\"Helvetica\", fontsize=10];\n")
+	for _, node := range nodes {
+		var lines []string
+		for _, line := range node.lines {
+			lines = append(lines, escape(line))
+		}
+		label := strings.Join(lines, "\\n")
+		switch node.kind {
+		case "trigger":
+			graph.WriteString(fmt.Sprintf("    %s [label=\"%s\", shape=oval, style=filled, fillcolor=\"#e3f2fd\"];\n",
+				node.id, label))
+		case "errorCatcher":
+			graph.WriteString(fmt.Sprintf("    %s [label=\"%s\", shape=hexagon, style=filled, fillcolor=\"#ffebee\"];\n",
+				node.id, label))
+		default:
+			graph.WriteString(fmt.Sprintf("    %s [label=\"%s\", shape=box, style=\"rounded,filled\", fillcolor=\"#f5f5f5\"];\n",
+				node.id, label))
+		}
+	}
+	for _, edge := range edges {
+		var attributes []string
+		if edge.label != "" {
+			attributes = append(attributes, "label=\""+escape(edge.label)+"\"")
+		}
+		if edge.error {
+			attributes = append(attributes, "style=dashed", "color=\"#c62828\"")
+		}
+		if len(attributes) > 0 {
+			graph.WriteString(fmt.Sprintf("    %s -> %s [%s];\n", edge.from, edge.to, strings.Join(attributes, ", ")))
+		} else {
+			graph.WriteString(fmt.Sprintf("    %s -> %s;\n", edge.from, edge.to))
+		}
+	}
+	graph.WriteString("}\n")
+	return graph.String()
+}
+
+// graphId returns an identifier that is valid in both Mermaid and DOT
+func graphId(id string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+			return r
+		}
+		return '_'
+	}, id)
+}
@@ -0,0 +1,65 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderGraph(t *testing.T) {
+	content := []byte(`{
+		"triggerConfigs": [{"triggerNumber": "1", "triggerType": "API", "label": "API Trigger", "startTasks": [{"taskId": "1"}]}],
+		"taskConfigs": [
+			{"taskId": "1", "task": "FieldMappingTask", "displayName": "Map", "errorCatcherId": "e1",
+				"nextTasks": [{"taskId": "2", "condition": "$status$ = \"ok\""}]},
+			{"taskId": "2", "task": "GenericRestV2Task"},
+			{"taskId": "3", "task": "EmailTask"}
+		],
+		"errorCatcherConfigs": [{"errorCatcherId": "e1", "errorCatcherNumber": "1", "startErrorTasks": [{"taskId": "3"}]}]
+	}`)
+
+	mermaid, err := RenderGraph(content, MermaidGraph)
+	if err != nil {
+		t.Fatalf("RenderGraph failed: %v", err)
+	}
+	for _, want := range []string{
+		`trigger_1(["API Trigger"]):::trigger`,
+		`task_1 -->|"$status$ = #quot;ok#quot;"| task_2`,
+		`task_1 -.->|"on error"| errorcatcher_e1`,
+		`errorcatcher_e1 -.-> task_3`,
+	} {
+		if !strings.Contains(mermaid, want) {
+			t.Errorf("mermaid graph does not contain %s:\n%s", want, mermaid)
+		}
+	}
+
+	dot, err := RenderGraph(content, DotGraph)
+	if err != nil {
+		t.Fatalf("RenderGraph failed: %v", err)
+	}
+	for _, want := range []string{
+		`task_1 -> task_2 [label="$status$ = \"ok\""];`,
+		`errorcatcher_e1 -> task_3 [style=dashed, color="#c62828"];`,
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("dot graph does not contain %s:\n%s", want, dot)
+		}
+	}
+
+	if _, err = RenderGraph(content, "svg"); err == nil {
+		t.Errorf("expected an error for an unsupported format")
+	}
+}
@@ -277,7 +277,7 @@ type conditionalFailurePolicy struct {
 }
 
 // CreateVersion
-func CreateVersion(name string, content []byte, overridesContent []byte, snapshot string,
+func CreateVersion(client *apiclient.Client, name string, content []byte, overridesContent []byte, snapshot string,
 	userlabel string, grantPermission bool, basicInfo bool,
 ) (respBody []byte, err error) {
 	iversion := integrationVersion{}
@@ -288,6 +288,8 @@ func CreateVersion(name string, content []byte, overridesContent []byte, snapsho
 	// remove any internal elements if exists
 	eversion := convertInternalToExternal(iversion)
 
+	var patches []patchOperation
+
 	// merge overrides if overrides were provided
 	if len(overridesContent) > 0 {
 		o := overrides{
@@ -305,9 +307,10 @@ func CreateVersion(name string, content []byte, overridesContent []byte, snapsho
 		if err = json.Unmarshal(overridesContent, &o); err != nil {
 			return nil, err
 		}
-		if eversion, err = mergeOverrides(eversion, o, grantPermission); err != nil {
+		if eversion, err = mergeOverrides(client, eversion, o, grantPermission); err != nil {
 			return nil, err
 		}
+		patches = o.Patches
 	}
 
 	if snapshot != "" {
@@ -323,21 +326,28 @@ func CreateVersion(name string, content []byte, overridesContent []byte, snapsho
 		return nil, err
 	}
 
+	// apply json patches after the typed overrides
+	if len(patches) > 0 {
+		if content, err = applyPatches(content, patches); err != nil {
+			return nil, err
+		}
+	}
+
+	httpClient := client
 	if basicInfo {
-		apiclient.ClientPrintHttpResponse.Set(false)
+		httpClient = client.WithoutOutput()
 	}
 
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	u.Path = path.Join(u.Path, "integrations", name, "versions")
-	respBody, err = apiclient.HttpClient(u.String(), string(content))
+	respBody, err = httpClient.HttpClient(u.String(), string(content))
 
 	if basicInfo {
 		var respBasicBody []byte
-		apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
 		if respBasicBody, err = getBasicInfo(respBody); err != nil {
 			return nil, err
 		}
-		apiclient.PrettyPrint(respBasicBody)
+		client.PrettyPrint(respBasicBody)
 		return respBasicBody, nil
 	}
 
@@ -345,7 +355,7 @@ func CreateVersion(name string, content []byte, overridesContent []byte, snapsho
 }
 
 // Upload
-func Upload(name string, content []byte) (respBody []byte, err error) {
+func Upload(client *apiclient.Client, name string, content []byte) (respBody []byte, err error) {
 	uploadVersion := uploadIntegrationFormat{}
 	if err = json.Unmarshal(content, &uploadVersion); err != nil {
 		clilog.Error.Println("invalid format for upload. Upload must have the json field content which contains " +
@@ -358,14 +368,14 @@ func Upload(name string, content []byte) (respBody []byte, err error) {
 			"stringified integration json and optionally the file format")
 	}
 
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	u.Path = path.Join(u.Path, "integrations", name, "versions:upload")
-	respBody, err = apiclient.HttpClient(u.String(), string(content))
+	respBody, err = client.HttpClient(u.String(), string(content))
 	return respBody, err
 }
 
 // Patch
-func Patch(name string, version string, content []byte) (respBody []byte, err error) {
+func Patch(client *apiclient.Client, name string, version string, content []byte) (respBody []byte, err error) {
 	iversion := integrationVersion{}
 	if err = json.Unmarshal(content, &iversion); err != nil {
 		return nil, err
@@ -378,27 +388,27 @@ func Patch(name string, version string, content []byte) (respBody []byte, err er
 		return nil, err
 	}
 
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	u.Path = path.Join(u.Path, "integrations", name, "versions", version)
-	respBody, err = apiclient.HttpClient(u.String(), string(content), "PATCH")
+	respBody, err = client.HttpClient(u.String(), string(content), "PATCH")
 	return respBody, err
 }
 
 // TakeOverEditLock
-func TakeoverEditLock(name string, version string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+func TakeoverEditLock(client *apiclient.Client, name string, version string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	u.Path = path.Join(u.Path, "integrations", name, "versions", version)
-	respBody, err = apiclient.HttpClient(u.String(), "")
+	respBody, err = client.HttpClient(u.String(), "")
 	return respBody, err
 }
 
 // ListVersions
-func ListVersions(name string, pageSize int, pageToken string, filter string, orderBy string,
+func ListVersions(client *apiclient.Client, name string, pageSize int, pageToken string, filter string, orderBy string,
 	allVersions bool, download bool, basicInfo bool,
 ) (respBody []byte, err error) {
-	clientPrintSetting := apiclient.ClientPrintHttpResponse.Get()
+	printClient := client
 
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	q := u.Query()
 	if pageSize != -1 {
 		q.Set("pageSize", strconv.Itoa(pageSize))
@@ -417,103 +427,114 @@ func ListVersions(name string, pageSize int, pageToken string, filter string, or
 
 	u.Path = path.Join(u.Path, "integrations", name, "versions")
 
-	if apiclient.GetExportToFile() != "" {
-		apiclient.ClientPrintHttpResponse.Set(false)
-		defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+	if client.GetExportToFile() != "" {
+		client = client.WithoutOutput()
 	}
 
-	if !allVersions {
-		if basicInfo {
-			apiclient.ClientPrintHttpResponse.Set(false)
-			respBody, err = apiclient.HttpClient(u.String())
-			if err != nil {
-				return nil, err
-			}
-			apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
-			listIvers := listIntegrationVersions{}
-			listBIvers := listbasicIntegrationVersions{}
-
-			if err = json.Unmarshal(respBody, &listIvers); err != nil {
-				return nil, err
-			}
+	if allVersions {
+		return nil, exportVersions(client, name, pageSize, filter, orderBy, download)
+	}
 
-			for _, iVer := range listIvers.IntegrationVersions {
-				basicIVer := basicIntegrationVersion{}
-				basicIVer.SnapshotNumber = iVer.SnapshotNumber
-				basicIVer.Version = getVersion(iVer.Name)
-				basicIVer.State = iVer.State
-				listBIvers.BasicIntegrationVersions = append(listBIvers.BasicIntegrationVersions, basicIVer)
-			}
-			newResp, err := json.Marshal(listBIvers)
-			if clientPrintSetting {
-				apiclient.PrettyPrint(newResp)
-			}
-			return newResp, err
-		}
-		respBody, err = apiclient.HttpClient(u.String())
+	if basicInfo {
+		respBody, err = client.WithoutOutput().HttpClient(u.String())
 		if err != nil {
 			return nil, err
 		}
-		return respBody, err
-	} else {
-		respBody, err = apiclient.HttpClient(u.String())
+		newResp, err := getBasicVersions(respBody)
 		if err != nil {
 			return nil, err
 		}
+		printClient.PrettyPrint(newResp)
+		return newResp, err
+	}
+	respBody, err = client.HttpClient(u.String())
+	if err != nil {
+		return nil, err
+	}
+	return respBody, err
+}
+
+// ListAllVersions lists the versions of every page, with a limit greater than 0
+// no more than limit versions are returned
+func ListAllVersions(client *apiclient.Client, name string, pageSize int, filter string, orderBy string,
+	basicInfo bool, limit int,
+) (respBody []byte, err error) {
+	listClient := client
+	if basicInfo {
+		listClient = client.WithoutOutput()
+	}
+	respBody, err = listClient.ListAll(func(client *apiclient.Client, pageToken string, pageSize int) ([]byte, error) {
+		return ListVersions(client, name, pageSize, pageToken, filter, orderBy, false, false, false)
+	}, pageSize, limit)
+	if err != nil || !basicInfo {
+		return respBody, err
+	}
+	if respBody, err = getBasicVersions(respBody); err != nil {
+		return nil, err
+	}
+	return respBody, client.PrettyPrint(respBody)
+}
+
+// exportVersions writes every version of the integration to the export folder
+func exportVersions(client *apiclient.Client, name string, pageSize int, filter string, orderBy string, download bool) error {
+	return client.ForEachPage(func(client *apiclient.Client, pageToken string) ([]byte, error) {
+		return ListVersions(client, name, pageSize, pageToken, filter, orderBy, false, false, false)
+	}, func(respBody []byte) error {
+		if client.GetExportToFile() == "" {
+			return nil
+		}
 
 		iversions := listIntegrationVersions{}
-		if err = json.Unmarshal(respBody, &iversions); err != nil {
-			return nil, err
+		if err := json.Unmarshal(respBody, &iversions); err != nil {
+			return err
 		}
 
-		if apiclient.GetExportToFile() != "" {
-			// Write each version to a file
-			for _, iversion := range iversions.IntegrationVersions {
-				var iversionBytes []byte
-				if iversionBytes, err = json.Marshal(iversion); err != nil {
-					return nil, err
-				}
-				version := iversion.Name[strings.LastIndex(iversion.Name, "/")+1:]
-				fileName := strings.Join([]string{name, iversion.SnapshotNumber, version}, "+") + ".json"
-				if download {
-					version := iversion.Name[strings.LastIndex(iversion.Name, "/")+1:]
-					payload, err := Download(name, version)
-					if err != nil {
-						return nil, err
-					}
-					if err = apiclient.WriteByteArrayToFile(
-						path.Join(apiclient.GetExportToFile(), fileName),
-						false,
-						payload); err != nil {
-						return nil, err
-					}
-				} else {
-					if err = apiclient.WriteByteArrayToFile(
-						path.Join(apiclient.GetExportToFile(), fileName),
-						false,
-						iversionBytes); err != nil {
-						return nil, err
-					}
+		// Write each version to a file
+		for _, iversion := range iversions.IntegrationVersions {
+			iversionBytes, err := json.Marshal(iversion)
+			if err != nil {
+				return err
+			}
+			version := iversion.Name[strings.LastIndex(iversion.Name, "/")+1:]
+			fileName := strings.Join([]string{name, iversion.SnapshotNumber, version}, "+") + ".json"
+			if download {
+				if iversionBytes, err = Download(client, name, version); err != nil {
+					return err
 				}
-				clilog.Info.Printf("Downloaded version %s for Integration flow %s\n", version, name)
 			}
-		}
-
-		// if more versions exist, repeat the process
-		if iversions.NextPageToken != "" {
-			if _, err = ListVersions(name, -1, iversions.NextPageToken, filter, orderBy, true, download, false); err != nil {
-				return nil, err
+			if err = apiclient.WriteByteArrayToFile(
+				path.Join(client.GetExportToFile(), fileName),
+				false,
+				iversionBytes); err != nil {
+				return err
 			}
-		} else {
-			return nil, nil
+			clilog.Info.Printf("Downloaded version %s for Integration flow %s\n", version, name)
 		}
+		return nil
+	})
+}
+
+// getBasicVersions returns the version, snapshot number and state of the versions
+func getBasicVersions(respBody []byte) ([]byte, error) {
+	listIvers := listIntegrationVersions{}
+	if err := json.Unmarshal(respBody, &listIvers); err != nil {
+		return nil, err
+	}
+
+	listBIvers := listbasicIntegrationVersions{NextPageToken: listIvers.NextPageToken}
+	for _, iVer := range listIvers.IntegrationVersions {
+		basicIVer := basicIntegrationVersion{}
+		basicIVer.SnapshotNumber = iVer.SnapshotNumber
+		basicIVer.Version = getVersion(iVer.Name)
+		basicIVer.State = iVer.State
+		listBIvers.BasicIntegrationVersions = append(listBIvers.BasicIntegrationVersions, basicIVer)
 	}
-	return nil, err
+	return json.Marshal(listBIvers)
 }
 
 // List
-func List(pageSize int, pageToken string, filter string, orderBy string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+func List(client *apiclient.Client, pageSize int, pageToken string, filter string, orderBy string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	q := u.Query()
 	if pageSize != -1 {
 		q.Set("pageSize", strconv.Itoa(pageSize))
@@ -530,25 +551,30 @@ func List(pageSize int, pageToken string, filter string, orderBy string) (respBo
 
 	u.RawQuery = q.Encode()
 	u.Path = path.Join(u.Path, "integrations")
-	respBody, err = apiclient.HttpClient(u.String())
+	respBody, err = client.HttpClient(u.String())
 	return respBody, err
 }
 
+// ListAll lists the integrations of every page, with a limit greater than 0
+// no more than limit integrations are returned
+func ListAll(client *apiclient.Client, pageSize int, filter string, orderBy string, limit int) (respBody []byte, err error) {
+	return client.ListAll(func(client *apiclient.Client, pageToken string, pageSize int) ([]byte, error) {
+		return List(client, pageSize, pageToken, filter, orderBy)
+	}, pageSize, limit)
+}
+
 // Get
-func Get(name string, version string, basicInfo bool, minimal bool, override bool) ([]byte, error) {
+func Get(client *apiclient.Client, name string, version string, basicInfo bool, minimal bool, override bool) ([]byte, error) {
 	if (basicInfo && minimal) || (basicInfo && override) || (minimal && override) {
 		return nil, errors.New("cannot combine basicInfo, minimal and override flags")
 	}
 
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	u.Path = path.Join(u.Path, "integrations", name, "versions", version)
-	apiclient.ClientPrintHttpResponse.Set(false)
-
-	respBody, err := apiclient.HttpClient(u.String())
+	respBody, err := client.WithoutOutput().HttpClient(u.String())
 
 	if !override && !minimal && !basicInfo {
-		apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
-		apiclient.PrettyPrint(respBody)
+		client.PrettyPrint(respBody)
 		return respBody, nil
 	}
 
@@ -560,11 +586,10 @@ func Get(name string, version string, basicInfo bool, minimal bool, override boo
 
 	if basicInfo {
 		var respBasicBody []byte
-		apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
 		if respBasicBody, err = getBasicInfo(respBody); err != nil {
 			return nil, err
 		}
-		apiclient.PrettyPrint(respBasicBody)
+		client.PrettyPrint(respBasicBody)
 		return respBasicBody, nil
 	}
 
@@ -574,38 +599,32 @@ func Get(name string, version string, basicInfo bool, minimal bool, override boo
 		if err != nil {
 			return nil, err
 		}
-		apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
-		apiclient.PrettyPrint(respExtBody)
+		client.PrettyPrint(respExtBody)
 		return respExtBody, nil
 	}
 
 	if override {
 		var or overrides
 		var respOvrBody []byte
-		if or, err = extractOverrides(iversion); err != nil {
+		if or, err = extractOverrides(client.WithoutOutput(), iversion); err != nil {
 			return nil, err
 		}
 		if respOvrBody, err = json.Marshal(or); err != nil {
 			return nil, err
 		}
-		apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
-		apiclient.PrettyPrint(respOvrBody)
+		client.PrettyPrint(respOvrBody)
 		return respOvrBody, nil
 	}
 	return respBody, err
 }
 
 // GetBySnapshot
-func GetBySnapshot(name string, snapshot string, basicInfo bool, minimal bool, override bool) ([]byte, error) {
-	apiclient.ClientPrintHttpResponse.Set(false)
-
-	listBody, err := ListVersions(name, -1, "", "snapshotNumber="+snapshot, "", false, false, true)
+func GetBySnapshot(client *apiclient.Client, name string, snapshot string, basicInfo bool, minimal bool, override bool) ([]byte, error) {
+	listBody, err := ListVersions(client.WithoutOutput(), name, -1, "", "snapshotNumber="+snapshot, "", false, false, true)
 	if err != nil {
 		return nil, err
 	}
 
-	apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
-
 	listBasicVersions := listbasicIntegrationVersions{}
 	err = json.Unmarshal(listBody, &listBasicVersions)
 	if err != nil {
@@ -617,20 +636,16 @@ func GetBySnapshot(name string, snapshot string, basicInfo bool, minimal bool, o
 	}
 
 	version := getVersion(listBasicVersions.BasicIntegrationVersions[0].Version)
-	return Get(name, version, basicInfo, minimal, override)
+	return Get(client, name, version, basicInfo, minimal, override)
 }
 
 // GetByUserlabel
-func GetByUserlabel(name string, userLabel string, basicInfo bool, minimal bool, override bool) ([]byte, error) {
-	apiclient.ClientPrintHttpResponse.Set(false)
-
-	listBody, err := ListVersions(name, -1, "", "userLabel="+userLabel, "", false, false, true)
+func GetByUserlabel(client *apiclient.Client, name string, userLabel string, basicInfo bool, minimal bool, override bool) ([]byte, error) {
+	listBody, err := ListVersions(client.WithoutOutput(), name, -1, "", "userLabel="+userLabel, "", false, false, true)
 	if err != nil {
 		return nil, err
 	}
 
-	apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
-
 	listBasicVersions := listbasicIntegrationVersions{}
 	err = json.Unmarshal(listBody, &listBasicVersions)
 	if err != nil {
@@ -642,7 +657,7 @@ func GetByUserlabel(name string, userLabel string, basicInfo bool, minimal bool,
 	}
 
 	version := getVersion(listBasicVersions.BasicIntegrationVersions[0].Version)
-	return Get(name, version, false, minimal, override)
+	return Get(client, name, version, basicInfo, minimal, override)
 }
 
 // GetConfigVariables
@@ -690,25 +705,24 @@ func GetConfigVariables(contents []byte) (respBody []byte, err error) {
 }
 
 // Delete
-func Delete(name string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+func Delete(client *apiclient.Client, name string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	u.Path = path.Join(u.Path, "integrations", name)
-	respBody, err = apiclient.HttpClient(u.String(), "", "DELETE")
+	respBody, err = client.HttpClient(u.String(), "", "DELETE")
 	return respBody, err
 }
 
 // DeleteVersion
-func DeleteVersion(name string, version string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+func DeleteVersion(client *apiclient.Client, name string, version string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	u.Path = path.Join(u.Path, "integrations", name, "versions", version)
-	respBody, err = apiclient.HttpClient(u.String(), "", "DELETE")
+	respBody, err = client.HttpClient(u.String(), "", "DELETE")
 	return respBody, err
 }
 
 // DeleteByUserlabel
-func DeleteByUserlabel(name string, userLabel string) (respBody []byte, err error) {
-	apiclient.ClientPrintHttpResponse.Set(false)
-	iversionBytes, err := GetByUserlabel(name, userLabel, false, false, false)
+func DeleteByUserlabel(client *apiclient.Client, name string, userLabel string) (respBody []byte, err error) {
+	iversionBytes, err := GetByUserlabel(client.WithoutOutput(), name, userLabel, false, false, false)
 	if err != nil {
 		return nil, err
 	}
@@ -720,14 +734,12 @@ func DeleteByUserlabel(name string, userLabel string) (respBody []byte, err erro
 	}
 
 	version := getVersion(iversion.Name)
-	apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
-	return DeleteVersion(name, version)
+	return DeleteVersion(client, name, version)
 }
 
 // DeleteBySnapshot
-func DeleteBySnapshot(name string, snapshot string) (respBody []byte, err error) {
-	apiclient.ClientPrintHttpResponse.Set(false)
-	iversionBytes, err := GetBySnapshot(name, snapshot, false, false, false)
+func DeleteBySnapshot(client *apiclient.Client, name string, snapshot string) (respBody []byte, err error) {
+	iversionBytes, err := GetBySnapshot(client.WithoutOutput(), name, snapshot, false, false, false)
 	if err != nil {
 		return nil, err
 	}
@@ -739,95 +751,94 @@ func DeleteBySnapshot(name string, snapshot string) (respBody []byte, err error)
 	}
 
 	version := getVersion(iversion.Name)
-	apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
-	return DeleteVersion(name, version)
+	return DeleteVersion(client, name, version)
 }
 
 // Deactivate
-func Deactivate(name string, version string) (respBody []byte, err error) {
-	return changeState(name, version, "", nil, ":deactivate")
+func Deactivate(client *apiclient.Client, name string, version string) (respBody []byte, err error) {
+	return changeState(client, name, version, "", nil, ":deactivate")
 }
 
 // Archive
-func Archive(name string, version string) (respBody []byte, err error) {
-	return changeState(name, version, "", nil, ":archive")
+func Archive(client *apiclient.Client, name string, version string) (respBody []byte, err error) {
+	return changeState(client, name, version, "", nil, ":archive")
 }
 
 // Publish
-func Publish(name string, version string, configVariables []byte) (respBody []byte, err error) {
-	return changeState(name, version, "", configVariables, ":publish")
+func Publish(client *apiclient.Client, name string, version string, configVariables []byte) (respBody []byte, err error) {
+	return changeState(client, name, version, "", configVariables, ":publish")
 }
 
 // Unpublish
-func Unpublish(name string, version string) (respBody []byte, err error) {
-	return changeState(name, version, "", nil, ":unpublish")
+func Unpublish(client *apiclient.Client, name string, version string) (respBody []byte, err error) {
+	return changeState(client, name, version, "", nil, ":unpublish")
 }
 
 // UnpublishSnapshot
-func UnpublishSnapshot(name string, snapshot string) (respBody []byte, err error) {
-	return changeState(name, "", "snapshotNumber="+snapshot, nil, ":unpublish")
+func UnpublishSnapshot(client *apiclient.Client, name string, snapshot string) (respBody []byte, err error) {
+	return changeState(client, name, "", "snapshotNumber="+snapshot, nil, ":unpublish")
 }
 
 // UnpublishUserLabel
-func UnpublishUserLabel(name string, userLabel string) (respBody []byte, err error) {
-	return changeState(name, "", "userLabel="+userLabel, nil, ":unpublish")
+func UnpublishUserLabel(client *apiclient.Client, name string, userLabel string) (respBody []byte, err error) {
+	return changeState(client, name, "", "userLabel="+userLabel, nil, ":unpublish")
 }
 
 // Download
-func Download(name string, version string) (respBody []byte, err error) {
-	return changeState(name, version, "", nil, ":download")
+func Download(client *apiclient.Client, name string, version string) (respBody []byte, err error) {
+	return changeState(client, name, version, "", nil, ":download")
 }
 
 // ArchiveSnapshot
-func ArchiveSnapshot(name string, snapshot string) (respBody []byte, err error) {
-	return changeState(name, "", "snapshotNumber="+snapshot, nil, ":archive")
+func ArchiveSnapshot(client *apiclient.Client, name string, snapshot string) (respBody []byte, err error) {
+	return changeState(client, name, "", "snapshotNumber="+snapshot, nil, ":archive")
 }
 
 // DeactivateSnapshot
-func DeactivateSnapshot(name string, snapshot string) (respBody []byte, err error) {
-	return changeState(name, "", "snapshotNumber="+snapshot, nil, ":deactivate")
+func DeactivateSnapshot(client *apiclient.Client, name string, snapshot string) (respBody []byte, err error) {
+	return changeState(client, name, "", "snapshotNumber="+snapshot, nil, ":deactivate")
 }
 
 // ArchiveUserLabel
-func ArchiveUserLabel(name string, userLabel string) (respBody []byte, err error) {
-	return changeState(name, "", "userLabel="+userLabel, nil, ":archive")
+func ArchiveUserLabel(client *apiclient.Client, name string, userLabel string) (respBody []byte, err error) {
+	return changeState(client, name, "", "userLabel="+userLabel, nil, ":archive")
 }
 
 // DeactivateUserLabel
-func DeactivateUserLabel(name string, userLabel string) (respBody []byte, err error) {
-	return changeState(name, "", "userLabel="+userLabel, nil, ":deactivate")
+func DeactivateUserLabel(client *apiclient.Client, name string, userLabel string) (respBody []byte, err error) {
+	return changeState(client, name, "", "userLabel="+userLabel, nil, ":deactivate")
 }
 
 // PublishUserLabel
-func PublishUserLabel(name string, userlabel string, configVariables []byte) (respBody []byte, err error) {
-	return changeState(name, "", "userLabel="+userlabel, configVariables, ":publish")
+func PublishUserLabel(client *apiclient.Client, name string, userlabel string, configVariables []byte) (respBody []byte, err error) {
+	return changeState(client, name, "", "userLabel="+userlabel, configVariables, ":publish")
 }
 
 // PublishSnapshot
-func PublishSnapshot(name string, snapshot string, configVariables []byte) (respBody []byte, err error) {
-	return changeState(name, "", "snapshotNumber="+snapshot, configVariables, ":publish")
+func PublishSnapshot(client *apiclient.Client, name string, snapshot string, configVariables []byte) (respBody []byte, err error) {
+	return changeState(client, name, "", "snapshotNumber="+snapshot, configVariables, ":publish")
 }
 
 // DownloadSnapshot
-func DownloadSnapshot(name string, snapshot string) (respBody []byte, err error) {
+func DownloadSnapshot(client *apiclient.Client, name string, snapshot string) (respBody []byte, err error) {
 	var version string
-	if version, err = getVersionId(name, "snapshotNumber="+snapshot); err != nil {
+	if version, err = getVersionId(client, name, "snapshotNumber="+snapshot); err != nil {
 		return nil, err
 	}
-	return Download(name, version)
+	return Download(client, name, version)
 }
 
 // DownloadSnapshot
-func DownloadUserLabel(name string, userlabel string) (respBody []byte, err error) {
+func DownloadUserLabel(client *apiclient.Client, name string, userlabel string) (respBody []byte, err error) {
 	var version string
-	if version, err = getVersionId(name, "userLabel="+userlabel); err != nil {
+	if version, err = getVersionId(client, name, "userLabel="+userlabel); err != nil {
 		return nil, err
 	}
-	return Download(name, version)
+	return Download(client, name, version)
 }
 
 // GetAuthConfigs
-func GetAuthConfigs(integration []byte) (authcfgs []string, err error) {
+func GetAuthConfigs(client *apiclient.Client, integration []byte) (authcfgs []string, err error) {
 	iversion := integrationVersion{}
 
 	err = json.Unmarshal(integration, &iversion)
@@ -846,7 +857,7 @@ func GetAuthConfigs(integration []byte) (authcfgs []string, err error) {
 			}
 			authConfigNameParams := taskConfig.Parameters["authConfigName"]
 			if authConfigNameParams.Key == "authConfigName" && *authConfigNameParams.Value.StringValue != "" {
-				authConfigUuid, err := authconfigs.Find(*authConfigNameParams.Value.StringValue, "")
+				authConfigUuid, err := authconfigs.Find(client, *authConfigNameParams.Value.StringValue, "")
 				if err != nil {
 					return nil, fmt.Errorf("unable to find authconfig with name %s", *authConfigNameParams.Value.StringValue)
 				}
@@ -953,26 +964,24 @@ func GetConnectionsWithRegion(integration []byte) (connections []integrationConn
 }
 
 // GetVersion
-func GetVersion(name string, userLabel string, snapshot string) (version string, err error) {
+func GetVersion(client *apiclient.Client, name string, userLabel string, snapshot string) (version string, err error) {
 	var integrationBody []byte
 
-	apiclient.DisableCmdPrintHttpResponse()
-	defer apiclient.EnableCmdPrintHttpResponse()
+	client = client.WithoutOutput()
 
 	if userLabel != "" {
-		integrationBody, err = GetByUserlabel(name, userLabel, true, false, false)
+		integrationBody, err = GetByUserlabel(client, name, userLabel, true, false, false)
 		if err != nil {
 			return "", err
 		}
 	} else if snapshot != "" {
-		integrationBody, err = GetBySnapshot(name, snapshot, true, false, false)
+		integrationBody, err = GetBySnapshot(client, name, snapshot, true, false, false)
 		if err != nil {
 			return "", err
 		}
 	} else {
 		return "", fmt.Errorf("userLabel or snapshot must be passed")
 	}
-	apiclient.ClientPrintHttpResponse.Set(true)
 	return GetIntegrationVersion(integrationBody)
 }
 
@@ -998,18 +1007,18 @@ func GetIntegrationVersion(respBody []byte) (string, error) {
 }
 
 // changeState
-func changeState(name string, version string, filter string, configVars []byte, action string) (respBody []byte, err error) {
+func changeState(client *apiclient.Client, name string, version string, filter string, configVars []byte, action string) (respBody []byte, err error) {
 	// if a version is sent, use it, else try the filter
 	if version == "" {
-		if version, err = getVersionId(name, filter); err != nil {
+		if version, err = getVersionId(client, name, filter); err != nil {
 			return nil, err
 		}
 	}
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	u.Path = path.Join(u.Path, "integrations", name, "versions", version+action)
 	// download is a get, the rest are post
 	if action == ":download" {
-		respBody, err = apiclient.HttpClient(u.String())
+		respBody, err = client.HttpClient(u.String())
 	} else if action == ":publish" {
 		if configVars != nil {
 			contents := string(configVars)
@@ -1017,30 +1026,28 @@ func changeState(name string, version string, filter string, configVars []byte,
 			contents = strings.Replace(contents, "\t", "", -1)
 			contents = strings.Replace(contents, "\\", "", -1)
 			contents = fmt.Sprintf("{\"configParameters\":%s}", contents)
-			respBody, err = apiclient.HttpClient(u.String(), contents)
+			respBody, err = client.HttpClient(u.String(), contents)
 		} else {
-			respBody, err = apiclient.HttpClient(u.String(), "")
+			respBody, err = client.HttpClient(u.String(), "")
 		}
 	} else {
-		respBody, err = apiclient.HttpClient(u.String(), "")
+		respBody, err = client.HttpClient(u.String(), "")
 	}
 	return respBody, err
 }
 
 // getVersionId
-func getVersionId(name string, filter string) (version string, err error) {
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+func getVersionId(client *apiclient.Client, name string, filter string) (version string, err error) {
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	q := u.Query()
 	q.Set("filter", filter)
 
 	u.RawQuery = q.Encode()
 	u.Path = path.Join(u.Path, "integrations", name, "versions")
-	apiclient.ClientPrintHttpResponse.Set(false)
-	respBody, err := apiclient.HttpClient(u.String())
+	respBody, err := client.WithoutOutput().HttpClient(u.String())
 	if err != nil {
 		return "", err
 	}
-	apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
 
 	iversions := listIntegrationVersions{}
 	if err = json.Unmarshal(respBody, &iversions); err != nil {
@@ -1055,29 +1062,14 @@ func getVersionId(name string, filter string) (version string, err error) {
 }
 
 // ExportConcurrent exports all Integration Flows in the specified folder using a configurable number of connections
-func ExportConcurrent(folder string, numConnections int) error {
+func ExportConcurrent(client *apiclient.Client, folder string, numConnections int) error {
 	// Set export settings
-	apiclient.SetExportToFile(folder)
-	apiclient.ClientPrintHttpResponse.Set(false)
-	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
-
-	pageToken := ""
-	lintegrations := listintegrations{}
+	client = client.WithExportToFile(folder)
+	client = client.WithoutOutput()
 
-	for {
-		l := listintegrations{}
-		listRespBytes, err := List(maxPageSize, pageToken, "", "")
-		if err != nil {
-			return fmt.Errorf("failed to fetch Integrations: %w", err)
-		}
-		err = json.Unmarshal(listRespBytes, &l)
-		if err != nil {
-			return fmt.Errorf("failed to unmarshall: %w", err)
-		}
-		lintegrations.Integrations = append(lintegrations.Integrations, l.Integrations...)
-		if l.NextPageToken == "" {
-			break
-		}
+	lintegrations, err := listAllIntegrations(client)
+	if err != nil {
+		return err
 	}
 
 	errChan := make(chan error)
@@ -1102,7 +1094,7 @@ func ExportConcurrent(folder string, numConnections int) error {
 
 	for i := 0; i < numConnections; i++ {
 		fanOutWg.Add(1)
-		go exportWorker(&fanOutWg, workChan, errChan)
+		go exportWorker(client, &fanOutWg, workChan, errChan)
 	}
 
 	for _, i := range lintegrations.Integrations {
@@ -1121,7 +1113,7 @@ func ExportConcurrent(folder string, numConnections int) error {
 	return nil
 }
 
-func exportWorker(wg *sync.WaitGroup, workCh <-chan integration, errs chan<- error) {
+func exportWorker(client *apiclient.Client, wg *sync.WaitGroup, workCh <-chan integration, errs chan<- error) {
 	defer wg.Done()
 	for {
 		work, ok := <-workCh
@@ -1131,36 +1123,38 @@ func exportWorker(wg *sync.WaitGroup, workCh <-chan integration, errs chan<- err
 		integrationName := work.Name[strings.LastIndex(work.Name, "/")+1:]
 		clilog.Info.Printf("Exporting all the revisions for Integration Flow %s\n", integrationName)
 
-		if _, err := ListVersions(integrationName, maxPageSize, "", "", "", true, false, false); err != nil {
+		if _, err := ListVersions(client, integrationName, maxPageSize, "", "", "", true, false, false); err != nil {
 			errs <- err
 		}
 	}
 }
 
-// Export
-func Export(folder string) (err error) {
-	apiclient.SetExportToFile(folder)
-	apiclient.ClientPrintHttpResponse.Set(false)
-	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
-
-	pageToken := ""
-	lintegrations := listintegrations{}
-
-	for {
+// listAllIntegrations returns the integrations of every page
+func listAllIntegrations(client *apiclient.Client) (lintegrations listintegrations, err error) {
+	err = client.ForEachPage(func(client *apiclient.Client, pageToken string) ([]byte, error) {
+		return List(client, maxPageSize, pageToken, "", "")
+	}, func(respBody []byte) error {
 		l := listintegrations{}
-		listRespBytes, err := List(maxPageSize, pageToken, "", "")
-		if err != nil {
-			return fmt.Errorf("failed to fetch Integrations: %w", err)
-		}
-		err = json.Unmarshal(listRespBytes, &l)
-		if err != nil {
+		if err := json.Unmarshal(respBody, &l); err != nil {
 			return fmt.Errorf("failed to unmarshall: %w", err)
 		}
 		lintegrations.Integrations = append(lintegrations.Integrations, l.Integrations...)
-		pageToken = l.NextPageToken
-		if l.NextPageToken == "" {
-			break
-		}
+		return nil
+	})
+	if err != nil {
+		return lintegrations, fmt.Errorf("failed to fetch Integrations: %w", err)
+	}
+	return lintegrations, nil
+}
+
+// Export
+func Export(client *apiclient.Client, folder string) (err error) {
+	client = client.WithExportToFile(folder)
+	client = client.WithoutOutput()
+
+	lintegrations, err := listAllIntegrations(client)
+	if err != nil {
+		return err
 	}
 
 	// no integrations where found
@@ -1171,7 +1165,7 @@ func Export(folder string) (err error) {
 	for _, lintegration := range lintegrations.Integrations {
 		integrationName := lintegration.Name[strings.LastIndex(lintegration.Name, "/")+1:]
 		clilog.Info.Printf("Exporting all the revisions for Integration Flow %s\n", integrationName)
-		if _, err = ListVersions(integrationName, maxPageSize, "", "", "", true, false, false); err != nil {
+		if _, err = ListVersions(client, integrationName, maxPageSize, "", "", "", true, false, false); err != nil {
 			return err
 		}
 	}
@@ -1180,7 +1174,7 @@ func Export(folder string) (err error) {
 }
 
 // ImportFlow
-func ImportFlow(name string, folder string, numConnections int) (err error) {
+func ImportFlow(client *apiclient.Client, name string, folder string, numConnections int) (err error) {
 	var versions []string
 
 	rIntegrationFlowFiles := regexp.MustCompile(name + `\+[0-9]+\+[a-zA-Z0-9]{8}-[a-zA-Z0-9]{4}-[a-zA-Z0-9]{4}-[a-zA-Z0-9]{4}-[a-zA-Z0-9]{12}\.json`)
@@ -1211,8 +1205,7 @@ func ImportFlow(name string, folder string, numConnections int) (err error) {
 	clilog.Info.Printf("Found %d versions for integration %s in the folder\n", numEntities, name)
 	clilog.Debug.Printf("Importing versions with %d connections\n", numConnections)
 
-	apiclient.ClientPrintHttpResponse.Set(false)
-	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+	client = client.WithoutOutput()
 
 	errChan := make(chan error)
 	workChan := make(chan []string, numEntities)
@@ -1236,7 +1229,7 @@ func ImportFlow(name string, folder string, numConnections int) (err error) {
 
 	for i := 0; i < numConnections; i++ {
 		fanOutWg.Add(1)
-		go batchImport(&fanOutWg, name, workChan, errChan)
+		go batchImport(client, &fanOutWg, name, workChan, errChan)
 	}
 
 	workChan <- versions
@@ -1254,7 +1247,7 @@ func ImportFlow(name string, folder string, numConnections int) (err error) {
 }
 
 // importWorker
-func importWorker(wg *sync.WaitGroup, workCh <-chan string, folder string, numConnections int, errs chan<- error) {
+func importWorker(client *apiclient.Client, wg *sync.WaitGroup, workCh <-chan string, folder string, numConnections int, errs chan<- error) {
 	defer wg.Done()
 	for {
 		work, ok := <-workCh
@@ -1262,19 +1255,19 @@ func importWorker(wg *sync.WaitGroup, workCh <-chan string, folder string, numCo
 			return
 		}
 		integrationFlowName := extractIntegrationFlowName(work)
-		if err := uploadAsync(integrationFlowName, work); err != nil {
+		if err := uploadAsync(client, integrationFlowName, work); err != nil {
 			errs <- err
 		}
 	}
 }
 
 // batchImport creates a batch of integration flows to import
-func batchImport(wg *sync.WaitGroup, name string, workCh <-chan []string, errs chan<- error) {
+func batchImport(client *apiclient.Client, wg *sync.WaitGroup, name string, workCh <-chan []string, errs chan<- error) {
 	defer wg.Done()
 
 	for _, work := range <-workCh {
 		// could possibly extend this to use batchImport
-		err := uploadAsync(name, work)
+		err := uploadAsync(client, name, work)
 		if err != nil {
 			errs <- err
 			continue
@@ -1282,13 +1275,13 @@ func batchImport(wg *sync.WaitGroup, name string, workCh <-chan []string, errs c
 	}
 }
 
-func uploadAsync(name string, filePath string) error {
+func uploadAsync(client *apiclient.Client, name string, filePath string) error {
 	content, err := os.ReadFile(filePath)
 	if err != nil {
 		return err
 	}
 
-	if _, err := CreateVersion(name, content, nil, "", "", false, false); err != nil {
+	if _, err := CreateVersion(client, name, content, nil, "", "", false, false); err != nil {
 		return err
 	}
 
@@ -1297,13 +1290,12 @@ func uploadAsync(name string, filePath string) error {
 }
 
 // Import
-func Import(folder string, numConnections int) (err error) {
+func Import(client *apiclient.Client, folder string, numConnections int) (err error) {
 	var fileNames []string
 
 	rIntegrationFlowFiles := regexp.MustCompile(`[\w|-]+\+[0-9]+\+[a-zA-Z0-9]{8}-[a-zA-Z0-9]{4}-[a-zA-Z0-9]{4}-[a-zA-Z0-9]{4}-[a-zA-Z0-9]{12}\.json`)
 
-	apiclient.ClientPrintHttpResponse.Set(false)
-	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+	client = client.WithoutOutput()
 
 	err = filepath.Walk(folder, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -1353,7 +1345,7 @@ func Import(folder string, numConnections int) (err error) {
 
 	for i := 0; i < numConnections; i++ {
 		fanOutWg.Add(1)
-		go importWorker(&fanOutWg, workChan, folder, numConnections, errChan)
+		go importWorker(client, &fanOutWg, workChan, folder, numConnections, errChan)
 	}
 
 	for _, fileName := range fileNames {
@@ -1389,10 +1381,10 @@ func integrationFlowExists(name string, integrationFlowList []string) bool {
 }
 
 // asyncImportFlow
-func asyncImportFlow(name string, folder string, conn int, pwg *sync.WaitGroup) {
+func asyncImportFlow(client *apiclient.Client, name string, folder string, conn int, pwg *sync.WaitGroup) {
 	defer pwg.Done()
 
-	_ = ImportFlow(name, folder, conn)
+	_ = ImportFlow(client, name, folder, conn)
 }
 
 // getVersion
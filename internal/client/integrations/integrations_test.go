@@ -15,6 +15,7 @@
 package integrations
 
 import (
+	"encoding/json"
 	"internal/client/clienttest"
 	"internal/cmd/utils"
 	"os"
@@ -22,45 +23,88 @@ import (
 	"testing"
 )
 
-var cliPath = os.Getenv("INTEGRATIONCLI_PATH")
-
-func TestCreateVersionNoOverrides(t *testing.T) {
-	if err := clienttest.TestSetup(); err != nil {
-		t.Fatalf("TestSetup failed: %v", err)
-	}
-	contents, err := utils.ReadFile(path.Join(cliPath, "test", "sample.json"))
+// readSample reads a file of the test folder
+func readSample(t *testing.T, name string) []byte {
+	contents, err := utils.ReadFile(path.Join("..", "..", "..", "test", name))
 	if err != nil {
-		t.Fatalf("unable to read authConfig failed: %v", err)
+		t.Fatalf("unable to read %s: %v", name, err)
 	}
-	_, err = CreateVersion("name", contents, nil, "", "", false, false)
-	if err != nil {
+	return contents
+}
+
+func TestCreateVersionNoOverrides(t *testing.T) {
+	_, client := clienttest.FakeSetup(t)
+	if _, err := CreateVersion(client, "name", readSample(t, "sample.json"), nil, "", "", false, false); err != nil {
 		t.Fatalf("Create failed: %v", err)
 	}
 }
 
 func TestCreateVersionOverrides(t *testing.T) {
-	if err := clienttest.TestSetup(); err != nil {
-		t.Fatalf("TestSetup failed: %v", err)
+	_, client := clienttest.FakeSetup(t)
+	if _, err := CreateVersion(client, "name", readSample(t, "sample.json"), readSample(t, "sample_overrides.json"),
+		"2", "2", false, false); err != nil {
+		t.Fatalf("Create failed: %v", err)
 	}
-	contents, err := utils.ReadFile(path.Join(cliPath, "test", "sample.json"))
+}
+
+func TestVersions(t *testing.T) {
+	_, client := clienttest.FakeSetup(t)
+	contents := readSample(t, "sample.json")
+	_, err := CreateVersion(client, "sample", contents, nil, "1", "prod", false, false)
 	if err != nil {
-		t.Fatalf("unable to read authConfig failed: %v", err)
+		t.Fatalf("CreateVersion failed: %v", err)
 	}
-	overrides, err := utils.ReadFile(path.Join(cliPath, "test", "sample_overrides.json"))
-	if err != nil {
-		t.Fatalf("unable to read authConfig failed: %v", err)
+	if _, err = CreateVersion(client, "sample", contents, nil, "2", "", false, false); err != nil {
+		t.Fatalf("CreateVersion failed: %v", err)
+	}
+	if _, err = PublishUserLabel(client, "sample", "prod", nil); err != nil {
+		t.Fatalf("PublishUserLabel failed: %v", err)
 	}
-	_, err = CreateVersion("name", contents, overrides, "2", "2", false, false)
+
+	respBody, err := GetBySnapshot(client, "sample", "2", false, false, false)
 	if err != nil {
-		t.Fatalf("Create failed: %v", err)
+		t.Fatalf("GetBySnapshot failed: %v", err)
+	}
+	iversion := integrationVersion{}
+	if err = json.Unmarshal(respBody, &iversion); err != nil {
+		t.Fatalf("unable to parse version: %v", err)
+	}
+	if iversion.SnapshotNumber != "2" || iversion.State != "DRAFT" {
+		t.Errorf("unexpected snapshot %s in state %s", iversion.SnapshotNumber, iversion.State)
+	}
+
+	if respBody, err = ListVersions(client, "sample", 1, "", "state=ACTIVE", "snapshot_number", false, false, true); err != nil {
+		t.Fatalf("ListVersions failed: %v", err)
+	}
+	if version, err := GetIntegrationVersion(respBody); err != nil || version == "" {
+		t.Errorf("expected an active version, got %q: %v", version, err)
+	}
+
+	for _, limit := range []int{0, 1} {
+		if respBody, err = ListAllVersions(client, "sample", 1, "", "", true, limit); err != nil {
+			t.Fatalf("ListAllVersions failed: %v", err)
+		}
+		basic := listbasicIntegrationVersions{}
+		if err = json.Unmarshal(respBody, &basic); err != nil {
+			t.Fatalf("unable to parse versions: %v", err)
+		}
+		if expected := map[int]int{0: 2, 1: 1}[limit]; len(basic.BasicIntegrationVersions) != expected {
+			t.Errorf("expected %d versions with limit %d, got %d", expected, limit, len(basic.BasicIntegrationVersions))
+		}
 	}
-}
 
-func TestDelete(t *testing.T) {
-	if err := clienttest.TestSetup(); err != nil {
-		t.Fatalf("TestSetup failed: %v", err)
+	folder := t.TempDir()
+	if _, err = ListVersions(client.WithExportToFile(folder), "sample", 1, "", "", "", true, false, false); err != nil {
+		t.Fatalf("ListVersions failed to export: %v", err)
 	}
-	if _, err := Delete("test"); err != nil {
+	if files, _ := os.ReadDir(folder); len(files) != 2 {
+		t.Errorf("expected 2 exported versions, got %d", len(files))
+	}
+
+	if _, err = Delete(client, "sample"); err != nil {
 		t.Fatalf("Delete failed: %v", err)
 	}
+	if _, err = GetBySnapshot(client, "sample", "1", false, false, false); err == nil {
+		t.Errorf("expected an error for a deleted integration")
+	}
 }
@@ -0,0 +1,102 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dop251/goja"
+)
+
+// javaScriptTimeout stops scripts that do not return, such as infinite loops
+const javaScriptTimeout = 30 * time.Second
+
+// RunJavaScript runs a JavaScript task script locally. The script must define executeScript(event);
+// the event reads and writes the integration variables from the inputs. The variables after the
+// script ran are returned as json together with the messages passed to event.log
+func RunJavaScript(filename string, script []byte, inputs []byte) (output []byte, logs []string, err error) {
+	variables, err := ParseVariables(inputs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	getVariable := func(name string) (interface{}, bool) {
+		if value, ok := variables[name]; ok {
+			return value, true
+		}
+		value, ok := variables["`"+name+"`"]
+		return value, ok
+	}
+	getString := func(name string) string {
+		if value, ok := getVariable(name); ok {
+			return fmt.Sprint(value)
+		}
+		return ""
+	}
+
+	vm := goja.New()
+	timer := time.AfterFunc(javaScriptTimeout, func() {
+		vm.Interrupt(fmt.Sprintf("script did not complete in %s", javaScriptTimeout))
+	})
+	defer timer.Stop()
+
+	event := vm.NewObject()
+	_ = event.Set("getParameter", func(name string) interface{} {
+		if value, ok := getVariable(strings.Trim(name, "`")); ok {
+			return value
+		}
+		return nil
+	})
+	_ = event.Set("setParameter", func(name string, value goja.Value) {
+		name = strings.Trim(name, "`")
+		delete(variables, "`"+name+"`")
+		if goja.IsUndefined(value) {
+			variables[name] = nil
+			return
+		}
+		variables[name] = value.Export()
+	})
+	_ = event.Set("log", func(call goja.FunctionCall) goja.Value {
+		var messages []string
+		for _, arg := range call.Arguments {
+			messages = append(messages, arg.String())
+		}
+		logs = append(logs, strings.Join(messages, " "))
+		return goja.Undefined()
+	})
+	_ = event.Set("getEventExecutionInfoId", func() string { return getString("ExecutionId") })
+	_ = event.Set("getIntegrationName", func() string { return getString("IntegrationName") })
+	_ = event.Set("getGcpProjectId", func() string { return getString("ProjectId") })
+	_ = event.Set("getGcpLocation", func() string { return getString("Region") })
+
+	if _, err = vm.RunScript(filename, string(script)); err != nil {
+		return nil, logs, err
+	}
+	executeScript, ok := goja.AssertFunction(vm.Get("executeScript"))
+	if !ok {
+		return nil, logs, fmt.Errorf("%s does not define the function executeScript(event)", filename)
+	}
+	if _, err = executeScript(goja.Undefined(), event); err != nil {
+		return nil, logs, err
+	}
+
+	if output, err = json.Marshal(variables); err != nil {
+		return nil, logs, fmt.Errorf("unable to convert variables to json: %w", err)
+	}
+	return output, logs, nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"testing"
+)
+
+func TestRunJavaScript(t *testing.T) {
+	script := []byte(`
+function executeScript(event) {
+  var order = event.getParameter("order");
+  var total = 0;
+  for (var i = 0; i < order.items.length; i++) {
+    total += order.items[i].price;
+  }
+  event.setParameter("total", total);
+  event.setParameter("` + "`customer`" + `", event.getParameter("customer").toUpperCase());
+  event.setParameter("names", ["a", "b"]);
+  event.log("integration", event.getIntegrationName());
+}`)
+	inputs := []byte(`{"inputParameters": {
+		"order": {"jsonValue": "{\"items\": [{\"price\": 1.5}, {\"price\": 2}]}"},
+		"customer": {"stringValue": "acme"},
+		"IntegrationName": {"stringValue": "orders"}
+	}}`)
+
+	output, logs, err := RunJavaScript("javascript_1.js", script, inputs)
+	if err != nil {
+		t.Fatalf("RunJavaScript failed: %v", err)
+	}
+	if len(logs) != 1 || logs[0] != "integration orders" {
+		t.Errorf("unexpected logs %v", logs)
+	}
+
+	mismatches, err := AssertVariables(output, []byte(`{"total": 3.5, "customer": "ACME", "names": ["a", "b"]}`))
+	if err != nil {
+		t.Fatalf("AssertVariables failed: %v", err)
+	}
+	if len(mismatches) > 0 {
+		t.Errorf("unexpected output %s: %v", string(output), mismatches)
+	}
+
+	if mismatches, _ = AssertVariables(output, []byte(`{"total": 4}`)); len(mismatches) != 1 {
+		t.Errorf("expected one mismatch, got %v", mismatches)
+	}
+
+	if _, _, err = RunJavaScript("javascript_2.js", []byte(`var a = 1;`), nil); err == nil {
+		t.Errorf("expected an error for a script without executeScript")
+	}
+	if _, _, err = RunJavaScript("javascript_3.js", []byte(`function executeScript(event) { throw new Error("failed"); }`), nil); err == nil {
+		t.Errorf("expected an error for a script that throws")
+	}
+}
@@ -0,0 +1,263 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// severities reported by the linter
+const (
+	LintError   = "error"
+	LintWarning = "warning"
+)
+
+// LintRule describes a check performed by the linter
+type LintRule struct {
+	Id          string `json:"id"`
+	Severity    string `json:"severity"`
+	Description string `json:"description"`
+}
+
+// LintFinding is a problem found by the linter
+type LintFinding struct {
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Location string `json:"location,omitempty"`
+	Message  string `json:"message"`
+}
+
+// LintRules lists every check performed by Lint
+var LintRules = []LintRule{
+	{"duplicate-task-number", LintError, "Two or more tasks use the same task number"},
+	{"missing-next-task", LintError, "A trigger, task or error catcher points at a task that does not exist"},
+	{"trigger-without-start-tasks", LintWarning, "A trigger does not start any task"},
+	{"unreachable-task", LintWarning, "A task can't be reached from any trigger or error catcher"},
+	{"undeclared-variable", LintError, "A variable is referenced but not declared in integrationParameters or integrationConfigParameters"},
+	{"unused-error-catcher", LintWarning, "An error catcher is not referenced by any trigger or task"},
+	{"unused-config-variable", LintWarning, "A config variable is declared but never referenced"},
+}
+
+// variableReference matches $var$ and $`var`$ references in parameters and conditions
+var variableReference = regexp.MustCompile("\\$`?([A-Za-z_][A-Za-z0-9_.-]*)`?\\$")
+
+// codeParameters hold JavaScript and Jsonnet code and are not scanned for variable references
+var codeParameters = map[string]bool{"script": true, "template": true}
+
+// Lint performs offline checks on an integration version and returns the problems found
+func Lint(content []byte) (findings []LintFinding, err error) {
+	iversion := integrationVersion{}
+	if err = json.Unmarshal(content, &iversion); err != nil {
+		return nil, err
+	}
+
+	report := func(rule string, severity string, location string, format string, a ...interface{}) {
+		findings = append(findings, LintFinding{
+			Rule:     rule,
+			Severity: severity,
+			Location: location,
+			Message:  fmt.Sprintf(format, a...),
+		})
+	}
+
+	tasks := make(map[string]taskconfig)
+	for _, task := range iversion.TaskConfigs {
+		if _, ok := tasks[task.TaskId]; ok {
+			report("duplicate-task-number", LintError, taskLocation(task.TaskId),
+				"task number %s is used by more than one task", task.TaskId)
+			continue
+		}
+		tasks[task.TaskId] = task
+	}
+
+	// walk the task graph from triggers and error catchers
+	reachable := make(map[string]bool)
+	var visit func(taskId string)
+	visit = func(taskId string) {
+		task, ok := tasks[taskId]
+		if !ok || reachable[taskId] {
+			return
+		}
+		reachable[taskId] = true
+		for _, next := range task.NextTasks {
+			visit(next.TaskId)
+		}
+	}
+
+	for _, trigger := range iversion.TriggerConfigs {
+		location := "triggerConfigs[triggerNumber=" + trigger.TriggerNumber + "]"
+		if len(trigger.StartTasks) == 0 {
+			report("trigger-without-start-tasks", LintWarning, location,
+				"trigger %s does not start any task", triggerName(trigger))
+		}
+		for _, startTask := range trigger.StartTasks {
+			if _, ok := tasks[startTask.TaskId]; !ok {
+				report("missing-next-task", LintError, location,
+					"trigger %s starts task %s which does not exist", triggerName(trigger), startTask.TaskId)
+			}
+			visit(startTask.TaskId)
+		}
+	}
+
+	for _, errorCatcher := range iversion.ErrorCatcherConfigs {
+		for _, startTask := range errorCatcher.StartErrorTasks {
+			if _, ok := tasks[startTask.TaskId]; !ok {
+				report("missing-next-task", LintError, errorCatcherLocation(errorCatcher.ErrorCatcherId),
+					"error catcher %s starts task %s which does not exist", errorCatcher.ErrorCatcherId, startTask.TaskId)
+			}
+			visit(startTask.TaskId)
+		}
+	}
+
+	for _, task := range iversion.TaskConfigs {
+		for _, next := range task.NextTasks {
+			if _, ok := tasks[next.TaskId]; !ok {
+				report("missing-next-task", LintError, taskLocation(task.TaskId),
+					"task %s points at task %s which does not exist", task.TaskId, next.TaskId)
+			}
+		}
+	}
+
+	for _, task := range iversion.TaskConfigs {
+		if !reachable[task.TaskId] {
+			report("unreachable-task", LintWarning, taskLocation(task.TaskId),
+				"task %s (%s) can't be reached from any trigger or error catcher", task.TaskId, task.DisplayName)
+			// report duplicates once
+			reachable[task.TaskId] = true
+		}
+	}
+
+	// variables
+	declared := make(map[string]bool)
+	for _, p := range iversion.IntegrationParameters {
+		declared[strings.Trim(p.Key, "`")] = true
+	}
+	for _, p := range iversion.IntegrationConfigParameters {
+		declared[strings.Trim(p.Parameter.Key, "`")] = true
+	}
+
+	for _, task := range iversion.TaskConfigs {
+		var references []string
+		for key, p := range task.Parameters {
+			if codeParameters[key] {
+				continue
+			}
+			references = append(references, getValueStrings(p.Value)...)
+		}
+		for _, next := range task.NextTasks {
+			references = append(references, next.Condition)
+		}
+		if task.FailurePolicy != nil {
+			references = append(references, task.FailurePolicy.Condition)
+		}
+		if task.ConditionalFailurePolicies != nil {
+			for _, policy := range task.ConditionalFailurePolicies.FailurePolicies {
+				references = append(references, policy.Condition)
+			}
+		}
+		for _, name := range getVariableReferences(references) {
+			// $payload.field$ reads a field of the json variable payload
+			variable, _, _ := strings.Cut(name, ".")
+			if !declared[name] && !declared[variable] {
+				report("undeclared-variable", LintError, taskLocation(task.TaskId),
+					"task %s references variable %s which is not declared", task.TaskId, name)
+			}
+		}
+	}
+
+	// error catchers
+	usedErrorCatchers := make(map[string]bool)
+	for _, trigger := range iversion.TriggerConfigs {
+		usedErrorCatchers[trigger.ErrorCatcherId] = true
+	}
+	for _, task := range iversion.TaskConfigs {
+		usedErrorCatchers[task.ErrorCatcherId] = true
+	}
+	for _, errorCatcher := range iversion.ErrorCatcherConfigs {
+		if !usedErrorCatchers[errorCatcher.ErrorCatcherId] {
+			report("unused-error-catcher", LintWarning, errorCatcherLocation(errorCatcher.ErrorCatcherId),
+				"error catcher %s is not referenced by any trigger or task", errorCatcher.ErrorCatcherId)
+		}
+	}
+
+	// config variables can also be referenced from code
+	for _, p := range iversion.IntegrationConfigParameters {
+		key := strings.Trim(p.Parameter.Key, "`")
+		if !strings.Contains(string(content), "$"+key+"$") && !strings.Contains(string(content), "$`"+key+"`$") {
+			report("unused-config-variable", LintWarning, "integrationConfigParameters["+key+"]",
+				"config variable %s is never referenced", key)
+		}
+	}
+
+	return findings, nil
+}
+
+// LintFailed returns true if any finding is at or above the severity, error or warning
+func LintFailed(findings []LintFinding, severity string) bool {
+	for _, finding := range findings {
+		if finding.Severity == severity || (severity == LintWarning && finding.Severity == LintError) {
+			return true
+		}
+	}
+	return false
+}
+
+func taskLocation(taskId string) string {
+	return "taskConfigs[taskId=" + taskId + "]"
+}
+
+func errorCatcherLocation(errorCatcherId string) string {
+	return "errorCatcherConfigs[errorCatcherId=" + errorCatcherId + "]"
+}
+
+func triggerName(trigger triggerconfig) string {
+	if trigger.Label != "" {
+		return trigger.TriggerNumber + " (" + trigger.Label + ")"
+	}
+	return trigger.TriggerNumber
+}
+
+// getValueStrings returns the string content of a parameter value
+func getValueStrings(v valueType) (values []string) {
+	if v.StringValue != nil {
+		values = append(values, *v.StringValue)
+	}
+	if v.JsonValue != nil {
+		values = append(values, *v.JsonValue)
+	}
+	if v.StringArray != nil {
+		values = append(values, v.StringArray.StringValues...)
+	}
+	return values
+}
+
+// getVariableReferences returns the sorted, unique variable names referenced in the values
+func getVariableReferences(values []string) (names []string) {
+	found := make(map[string]bool)
+	for _, value := range values {
+		for _, match := range variableReference.FindAllStringSubmatch(value, -1) {
+			if !found[match[1]] {
+				found[match[1]] = true
+				names = append(names, match[1])
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
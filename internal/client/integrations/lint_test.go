@@ -0,0 +1,82 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"os"
+	"path"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	content := []byte(`{
+		"triggerConfigs": [
+			{"triggerNumber": "1", "triggerType": "API", "startTasks": [{"taskId": "1"}, {"taskId": "9"}]},
+			{"triggerNumber": "2", "triggerType": "API"}
+		],
+		"taskConfigs": [
+			{"taskId": "1", "task": "GenericRestV2Task", "nextTasks": [{"taskId": "2", "condition": "$count$ > 1 && $payload.items$ != \"\""}],
+				"parameters": {"url": {"key": "url", "value": {"stringValue": "$` + "`CONFIG_url`" + `$/$path$"}}}},
+			{"taskId": "2", "task": "JavaScriptTask", "parameters": {"script": {"key": "script", "value": {"stringValue": "const a = $missing$;"}}}},
+			{"taskId": "3", "task": "FieldMappingTask", "errorCatcherId": "e1",
+				"parameters": {"body": {"key": "body", "value": {"stringValue": "$undeclared.field$"}}}},
+			{"taskId": "3", "task": "FieldMappingTask"}
+		],
+		"errorCatcherConfigs": [{"errorCatcherId": "e1"}, {"errorCatcherId": "e2", "startErrorTasks": [{"taskId": "3"}]}],
+		"integrationParameters": [{"key": "count"}, {"key": "payload"}],
+		"integrationConfigParameters": [{"parameter": {"key": "` + "`CONFIG_url`" + `"}}, {"parameter": {"key": "` + "`CONFIG_unused`" + `"}}]
+	}`)
+
+	findings, err := Lint(content)
+	if err != nil {
+		t.Fatalf("Lint failed: %v", err)
+	}
+
+	got := make(map[string]int)
+	for _, finding := range findings {
+		got[finding.Rule]++
+	}
+	want := map[string]int{
+		"duplicate-task-number":       1,
+		"missing-next-task":           1,
+		"trigger-without-start-tasks": 1,
+		"unreachable-task":            0,
+		"undeclared-variable":         2,
+		"unused-error-catcher":        1,
+		"unused-config-variable":      1,
+	}
+	for rule, count := range want {
+		if got[rule] != count {
+			t.Errorf("expected %d %s findings, got %d: %v", count, rule, got[rule], findings)
+		}
+	}
+	if !LintFailed(findings, LintError) {
+		t.Errorf("expected lint to fail on errors")
+	}
+}
+
+func TestLintSample(t *testing.T) {
+	content, err := os.ReadFile(path.Join("..", "..", "..", "samples", "scaffold-sample", "src", "sample.json"))
+	if err != nil {
+		t.Skipf("sample not found: %v", err)
+	}
+	findings, err := Lint(content)
+	if err != nil {
+		t.Fatalf("Lint failed: %v", err)
+	}
+	if len(findings) > 0 {
+		t.Errorf("expected no findings for the sample, got %v", findings)
+	}
+}
@@ -15,6 +15,7 @@
 package integrations
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -32,6 +33,7 @@ type overrides struct {
 	ConnectionOverrides  []connectionoverrides `json:"connection_overrides,omitempty"`
 	ParamOverrides       []parameterExternal   `json:"param_overrides,omitempty"`
 	IntegrationOverrides integrationoverrides  `json:"integration_overrides,omitempty"`
+	Patches              []patchOperation      `json:"patches,omitempty"`
 }
 
 type integrationoverrides struct {
@@ -52,6 +54,9 @@ type triggeroverrides struct {
 	CloudSchedulerServiceAccount *string           `json:"cloudSchedulerServiceAccount,omitempty"`
 	CloudSchedulerLocation       *string           `json:"cloudSchedulerLocation,omitempty"`
 	CloudSchedulerCronTab        *string           `json:"cloudSchedulerCronTab,omitempty"`
+	SfdcInstanceName             *string           `json:"sfdcInstanceName,omitempty"`
+	SfdcChannelName              *string           `json:"sfdcChannelName,omitempty"`
+	TriggerId                    *string           `json:"triggerId,omitempty"`
 }
 
 type connectionoverrides struct {
@@ -79,16 +84,38 @@ type connectionparams struct {
 }
 
 const (
-	pubsubTrigger = "cloud_pubsub_external_trigger/projects/%s/subscriptions/%s_%s"
-	apiTrigger    = "api_trigger/"
+	pubsubTrigger        = "cloud_pubsub_external_trigger/projects/%s/subscriptions/%s_%s"
+	privatePubsubTrigger = "cloud_pubsub_trigger/projects/%s/topics/%s"
+	pubsubTopic          = "projects/%s/topics/%s"
+	apiTrigger           = "api_trigger/"
+	connectorTrigger     = "integration_connector_trigger/projects/%s/locations/%s/connections/%s/eventSubscriptions/%s"
+	sfdcTrigger          = "%s/%s_%s"
+	sfdcTriggerPrefix    = "sfdc_trigger"
 )
 
+// trigger types supported by Application Integration
+const (
+	pubsubExternalTriggerType = "CLOUD_PUBSUB_EXTERNAL"
+	pubsubTriggerType         = "CLOUD_PUBSUB"
+	apiTriggerType            = "API"
+	schedulerTriggerType      = "CLOUD_SCHEDULER"
+	connectorTriggerType      = "INTEGRATION_CONNECTOR_TRIGGER"
+	sfdcTriggerType           = "SFDC_CHANNEL"
+	sfdcCdcTriggerType        = "SFDC_CDC_CHANNEL"
+	cronTriggerType           = "CRON"
+	privateTriggerType        = "PRIVATE_TRIGGER"
+	eventarcTriggerType       = "EVENTARC_TRIGGER"
+)
+
+// properties that must be set when overriding an integration connector trigger
+var connectorTriggerProperties = []string{"Project name", "Region", "Connection name", "Subscription name"}
+
 const authConfigValue = "{  \"@type\": \"type.googleapis.com/enterprise.crm.eventbus.authconfig.AuthConfigTaskParam\",\"authConfigId\": \""
 
 const configVarPrefix = "$`CONFIG_"
 
 // mergeOverrides
-func mergeOverrides(eversion integrationVersionExternal, o overrides, grantPermission bool) (integrationVersionExternal, error) {
+func mergeOverrides(client *apiclient.Client, eversion integrationVersionExternal, o overrides, grantPermission bool) (integrationVersionExternal, error) {
 	var err error
 	var serviceAccountName string
 	userDefineSA := true
@@ -98,12 +125,19 @@ func mergeOverrides(eversion integrationVersionExternal, o overrides, grantPermi
 		foundOverride := false
 		for triggerIndex, trigger := range eversion.TriggerConfigs {
 			if triggerOverride.TriggerNumber == trigger.TriggerNumber {
+				if triggerOverride.TriggerType != "" && triggerOverride.TriggerType != trigger.TriggerType {
+					clilog.Warning.Printf("trigger override %s has type %s, but the trigger in the integration json is %s\n",
+						triggerOverride.TriggerNumber, triggerOverride.TriggerType, trigger.TriggerType)
+				}
+				if err = checkTriggerOverride(trigger.TriggerType, triggerOverride); err != nil {
+					return eversion, err
+				}
 				switch trigger.TriggerType {
-				case "CLOUD_PUBSUB_EXTERNAL":
-					if triggerOverride.ProjectId == nil || triggerOverride.TopicName == nil {
-						return eversion, fmt.Errorf("projectid and topicName are mandatory in the overrides")
-					}
+				case pubsubExternalTriggerType:
 					trigger.TriggerId = fmt.Sprintf(pubsubTrigger, *triggerOverride.ProjectId, *triggerOverride.ProjectId, *triggerOverride.TopicName)
+					if trigger.Properties == nil {
+						trigger.Properties = map[string]string{}
+					}
 					trigger.Properties["Subscription name"] = *triggerOverride.ProjectId + "_" + *triggerOverride.TopicName
 					trigger.Properties["IP Project name"] = *triggerOverride.ProjectId
 
@@ -118,7 +152,7 @@ func mergeOverrides(eversion integrationVersionExternal, o overrides, grantPermi
 							clilog.Debug.Printf("config variable detected, skipping grantPermission\n")
 						}
 					} else {
-						serviceAccountName, err = apiclient.GetComputeEngineDefaultServiceAccount(apiclient.GetProjectID())
+						serviceAccountName, err = client.GetComputeEngineDefaultServiceAccount(client.GetProjectID())
 						if err != nil {
 							return eversion, fmt.Errorf("Unable to get default comput engine service account: %v\n", err)
 						}
@@ -128,23 +162,32 @@ func mergeOverrides(eversion integrationVersionExternal, o overrides, grantPermi
 					if grantPermission {
 						if userDefineSA {
 							// create the SA if it doesn't exist
-							if err := apiclient.CreateServiceAccount(serviceAccountName); err != nil {
+							if err := client.CreateServiceAccount(serviceAccountName); err != nil {
 								return eversion, err
 							}
 						}
-						if err := apiclient.SetIntegrationInvokerPermission(*triggerOverride.ProjectId, serviceAccountName); err != nil {
+						if err := client.SetIntegrationInvokerPermission(*triggerOverride.ProjectId, serviceAccountName); err != nil {
 							clilog.Warning.Printf("Unable to update permissions for the service account: %v\n", err)
 						}
 					}
-				case "API":
-					if triggerOverride.APIPath == nil {
-						return eversion, fmt.Errorf("the field apiPath is missing from the API Trigger in overrides")
+				case pubsubTriggerType:
+					trigger.TriggerId = fmt.Sprintf(privatePubsubTrigger, *triggerOverride.ProjectId, *triggerOverride.TopicName)
+					if trigger.Properties == nil {
+						trigger.Properties = map[string]string{}
+					}
+					trigger.Properties["Topic name"] = fmt.Sprintf(pubsubTopic, *triggerOverride.ProjectId, *triggerOverride.TopicName)
+					if triggerOverride.ServiceAccount != nil {
+						trigger.Properties["Service account"] = *triggerOverride.ServiceAccount
 					}
+				case apiTriggerType:
 					trigger.TriggerId = apiTrigger + *triggerOverride.APIPath
 					if len(triggerOverride.Properties) > 0 {
 						trigger.Properties = triggerOverride.Properties
 					}
-				case "CLOUD_SCHEDULER":
+				case schedulerTriggerType:
+					if trigger.CloudSchedulerConfig == nil {
+						trigger.CloudSchedulerConfig = &cloudSchedulerConfig{}
+					}
 					if triggerOverride.CloudSchedulerServiceAccount != nil {
 						trigger.CloudSchedulerConfig.ServiceAccountEmail = *triggerOverride.CloudSchedulerServiceAccount
 					}
@@ -154,14 +197,27 @@ func mergeOverrides(eversion integrationVersionExternal, o overrides, grantPermi
 					if triggerOverride.CloudSchedulerLocation != nil {
 						trigger.CloudSchedulerConfig.Location = *triggerOverride.CloudSchedulerLocation
 					}
-				case "INTEGRATION_CONNECTOR_TRIGGER":
+				case connectorTriggerType:
 					if len(triggerOverride.Properties) > 0 {
-						trigger.TriggerId = fmt.Sprintf("integration_connector_trigger/projects/%s/locations/%s/connections/%s/eventSubscriptions/%s",
+						trigger.TriggerId = fmt.Sprintf(connectorTrigger,
 							triggerOverride.Properties["Project name"],
 							triggerOverride.Properties["Region"], triggerOverride.Properties["Connection name"],
 							triggerOverride.Properties["Subscription name"])
 						trigger.Properties = triggerOverride.Properties
 					}
+				case sfdcTriggerType, sfdcCdcTriggerType:
+					if trigger.Properties == nil {
+						trigger.Properties = map[string]string{}
+					}
+					trigger.TriggerId = getSfdcTriggerId(trigger.TriggerId,
+						*triggerOverride.SfdcInstanceName, *triggerOverride.SfdcChannelName)
+					trigger.Properties["SFDC instance name"] = *triggerOverride.SfdcInstanceName
+					trigger.Properties["Channel name"] = *triggerOverride.SfdcChannelName
+				case cronTriggerType, privateTriggerType, eventarcTriggerType:
+					trigger.TriggerId = *triggerOverride.TriggerId
+					if len(triggerOverride.Properties) > 0 {
+						trigger.Properties = triggerOverride.Properties
+					}
 				default:
 					clilog.Warning.Printf("unsupported trigger type %s\n", trigger.TriggerType)
 				}
@@ -180,7 +236,7 @@ func mergeOverrides(eversion integrationVersionExternal, o overrides, grantPermi
 		foundOverride := false
 		for taskIndex, task := range eversion.TaskConfigs {
 			if taskOverride.TaskId == task.TaskId && taskOverride.Task == task.Task && task.Task != "GenericConnectorTask" {
-				task.Parameters = overrideParameters(taskOverride.Parameters, task.Parameters)
+				task.Parameters = overrideParameters(client, taskOverride.Parameters, task.Parameters)
 				eversion.TaskConfigs[taskIndex] = task
 				foundOverride = true
 			}
@@ -196,10 +252,9 @@ func mergeOverrides(eversion integrationVersionExternal, o overrides, grantPermi
 		for ipIndex, ip := range eversion.IntegrationParameters {
 			if paramOverride.Key == ip.Key {
 				ip.DefaultValue = paramOverride.DefaultValue
+				eversion.IntegrationParameters[ipIndex] = ip
+				foundOverride = true
 			}
-			eversion.IntegrationParameters[ipIndex] = ip
-			foundOverride = true
-
 		}
 		if !foundOverride {
 			clilog.Warning.Printf("param override key %s with dataTpe %s was not found in the integration json\n",
@@ -213,7 +268,7 @@ func mergeOverrides(eversion integrationVersionExternal, o overrides, grantPermi
 		for _, connectionOverride := range o.ConnectionOverrides {
 			for taskIndex, task := range eversion.TaskConfigs {
 				if connectionOverride.TaskId == task.TaskId && connectionOverride.Task == task.Task {
-					newcp, err := getNewConnectionParams(connectionOverride.Parameters.ConnectionName,
+					newcp, err := getNewConnectionParams(client, connectionOverride.Parameters.ConnectionName,
 						connectionOverride.Parameters.ConnectionLocation)
 					if err != nil {
 						return eversion, err
@@ -250,7 +305,7 @@ func mergeOverrides(eversion integrationVersionExternal, o overrides, grantPermi
 					cversion := task.Parameters["connectionVersion"]
 					// custom connector
 					if cversion.Value.StringValue != nil {
-						newcp, err := getNewConnectionParams(connectionOverride.Parameters.ConnectionName,
+						newcp, err := getNewConnectionParams(client, connectionOverride.Parameters.ConnectionName,
 							connectionOverride.Parameters.ConnectionLocation)
 						if err != nil {
 							return eversion, err
@@ -292,7 +347,173 @@ func mergeOverrides(eversion integrationVersionExternal, o overrides, grantPermi
 	return eversion, nil
 }
 
-func extractOverrides(iversion integrationVersion) (overrides, error) {
+// ValidateOverrides checks an overrides file against the integration without calling any APIs.
+// It returns the list of problems found; an empty list means the overrides can be applied.
+func ValidateOverrides(content []byte, overridesContent []byte) (problems []string, err error) {
+	iversion := integrationVersion{}
+	if err = json.Unmarshal(content, &iversion); err != nil {
+		return nil, fmt.Errorf("unable to parse integration: %w", err)
+	}
+
+	o := overrides{}
+	decoder := json.NewDecoder(bytes.NewReader(overridesContent))
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(&o); err != nil {
+		// report schema problems, then continue with a lenient parse
+		problems = append(problems, fmt.Sprintf("overrides file does not match the schema: %v", err))
+		o = overrides{}
+		if err = json.Unmarshal(overridesContent, &o); err != nil {
+			return problems, nil
+		}
+	}
+
+	for _, triggerOverride := range o.TriggerOverrides {
+		trigger, found := findTrigger(iversion.TriggerConfigs, triggerOverride.TriggerNumber)
+		if !found {
+			problems = append(problems, fmt.Sprintf("trigger override with triggerNumber %s was not found in the integration",
+				triggerOverride.TriggerNumber))
+			continue
+		}
+		if triggerOverride.TriggerType != "" && triggerOverride.TriggerType != trigger.TriggerType {
+			problems = append(problems, fmt.Sprintf("trigger override %s has type %s, but the trigger in the integration is %s",
+				triggerOverride.TriggerNumber, triggerOverride.TriggerType, trigger.TriggerType))
+		}
+		if err = checkTriggerOverride(trigger.TriggerType, triggerOverride); err != nil {
+			problems = append(problems, fmt.Sprintf("trigger override %s: %v", triggerOverride.TriggerNumber, err))
+		}
+	}
+
+	for _, taskOverride := range o.TaskOverrides {
+		task, found := findTask(iversion.TaskConfigs, taskOverride.TaskId)
+		if !found {
+			problems = append(problems, fmt.Sprintf("task override with taskId %s was not found in the integration",
+				taskOverride.TaskId))
+			continue
+		}
+		if taskOverride.Task != task.Task {
+			problems = append(problems, fmt.Sprintf("task override %s has task %s, but the task in the integration is %s",
+				taskOverride.TaskId, taskOverride.Task, task.Task))
+			continue
+		}
+		for paramName, param := range taskOverride.Parameters {
+			taskParam, found := task.Parameters[paramName]
+			if !found {
+				problems = append(problems, fmt.Sprintf("task override %s parameter %s was not found in the integration",
+					taskOverride.TaskId, paramName))
+				continue
+			}
+			// authConfig overrides are set by display name and resolved at apply time
+			if param.Key == "authConfig" {
+				continue
+			}
+			overrideType, taskType := getValueType(param.Value), getValueType(taskParam.Value)
+			if overrideType != "" && taskType != "" && overrideType != taskType {
+				problems = append(problems, fmt.Sprintf("task override %s parameter %s is of type %s, but the integration uses %s",
+					taskOverride.TaskId, paramName, overrideType, taskType))
+			}
+		}
+	}
+
+	for _, connectionOverride := range o.ConnectionOverrides {
+		task, found := findTask(iversion.TaskConfigs, connectionOverride.TaskId)
+		if !found {
+			problems = append(problems, fmt.Sprintf("connection override with taskId %s was not found in the integration",
+				connectionOverride.TaskId))
+			continue
+		}
+		if connectionOverride.Task != task.Task {
+			problems = append(problems, fmt.Sprintf("connection override %s has task %s, but the task in the integration is %s",
+				connectionOverride.TaskId, connectionOverride.Task, task.Task))
+		}
+		if connectionOverride.Parameters.ConnectionName == "" {
+			problems = append(problems, fmt.Sprintf("connection override %s is missing the connectionName",
+				connectionOverride.TaskId))
+		}
+	}
+
+	for _, paramOverride := range o.ParamOverrides {
+		foundOverride := false
+		for _, ip := range iversion.IntegrationParameters {
+			if paramOverride.Key != ip.Key {
+				continue
+			}
+			foundOverride = true
+			if paramOverride.DataType != "" && paramOverride.DataType != ip.DataType {
+				problems = append(problems, fmt.Sprintf("param override %s has dataType %s, but the integration uses %s",
+					paramOverride.Key, paramOverride.DataType, ip.DataType))
+			}
+			if paramOverride.DefaultValue != nil && ip.DefaultValue != nil {
+				overrideType, paramType := getValueType(*paramOverride.DefaultValue), getValueType(*ip.DefaultValue)
+				if overrideType != "" && paramType != "" && overrideType != paramType {
+					problems = append(problems, fmt.Sprintf("param override %s is of type %s, but the integration uses %s",
+						paramOverride.Key, overrideType, paramType))
+				}
+			}
+		}
+		if !foundOverride {
+			problems = append(problems, fmt.Sprintf("param override key %s was not found in the integration",
+				paramOverride.Key))
+		}
+	}
+
+	// patches are applied to the external representation of the integration
+	if len(o.Patches) > 0 {
+		externalContent, err := json.Marshal(convertInternalToExternal(iversion))
+		if err != nil {
+			return problems, err
+		}
+		if _, err = applyPatches(externalContent, o.Patches); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	return problems, nil
+}
+
+func findTrigger(triggerConfigs []triggerconfig, triggerNumber string) (triggerconfig, bool) {
+	for _, trigger := range triggerConfigs {
+		if trigger.TriggerNumber == triggerNumber {
+			return trigger, true
+		}
+	}
+	return triggerconfig{}, false
+}
+
+func findTask(taskConfigs []taskconfig, taskId string) (taskconfig, bool) {
+	for _, task := range taskConfigs {
+		if task.TaskId == taskId {
+			return task, true
+		}
+	}
+	return taskconfig{}, false
+}
+
+// getValueType returns the name of the field set in the value
+func getValueType(v valueType) string {
+	switch {
+	case v.StringValue != nil:
+		return "stringValue"
+	case v.IntValue != nil:
+		return "intValue"
+	case v.BooleanValue != nil:
+		return "booleanValue"
+	case v.JsonValue != nil:
+		return "jsonValue"
+	case v.StringArray != nil:
+		return "stringArray"
+	case v.IntArray != nil:
+		return "intArray"
+	case v.DoubleArray != nil:
+		return "doubleArray"
+	case v.BooleanArray != nil:
+		return "booleanArray"
+	case v.DoubleValue != 0:
+		return "doubleValue"
+	}
+	return ""
+}
+
+func extractOverrides(client *apiclient.Client, iversion integrationVersion) (overrides, error) {
 	taskOverrides := overrides{
 		IntegrationOverrides: integrationoverrides{
 			RunAsServiceAccount:       nil,
@@ -311,11 +532,11 @@ func extractOverrides(iversion integrationVersion) (overrides, error) {
 				return taskOverrides, err
 			}
 		} else if task.Task == "GenericRestV2Task" {
-			if err := handleGenericRestV2Task(task, &taskOverrides); err != nil {
+			if err := handleGenericRestV2Task(client, task, &taskOverrides); err != nil {
 				return taskOverrides, err
 			}
 		} else if task.Task == "CloudFunctionTask" {
-			if err := handleCloudFunctionTask(task, &taskOverrides); err != nil {
+			if err := handleCloudFunctionTask(client, task, &taskOverrides); err != nil {
 				return taskOverrides, err
 			}
 		}
@@ -331,18 +552,19 @@ func extractOverrides(iversion integrationVersion) (overrides, error) {
 		}
 	}
 	for _, triggerConfig := range iversion.TriggerConfigs {
+		triggerOverride := triggeroverrides{}
+		triggerOverride.TriggerNumber = triggerConfig.TriggerNumber
+		triggerOverride.TriggerType = triggerConfig.TriggerType
+
 		switch triggerConfig.TriggerType {
-		case "CLOUD_PUBSUB_EXTERNAL":
+		case pubsubExternalTriggerType:
 			subscription := triggerConfig.Properties["Subscription name"]
-			triggerOverride := triggeroverrides{}
 			triggerOverride.ProjectId = new(string)
 			triggerOverride.TopicName = new(string)
-			*triggerOverride.ProjectId = strings.Split(subscription, "_")[0]
-			*triggerOverride.TopicName = strings.Split(subscription, "_")[1]
-			triggerOverride.TriggerNumber = triggerConfig.TriggerNumber
+			*triggerOverride.ProjectId, *triggerOverride.TopicName, _ = strings.Cut(subscription, "_")
 			triggerSA := triggerConfig.Properties["Service account"]
 			if triggerSA != "" {
-				if defaultSA, err := apiclient.GetComputeEngineDefaultServiceAccount(apiclient.GetProjectID()); err == nil {
+				if defaultSA, err := client.GetComputeEngineDefaultServiceAccount(client.GetProjectID()); err == nil {
 					if defaultSA != triggerSA {
 						triggerOverride.ServiceAccount = new(string)
 						*triggerOverride.ServiceAccount = strings.Split(triggerConfig.Properties["Service account"], "@")[0]
@@ -351,23 +573,51 @@ func extractOverrides(iversion integrationVersion) (overrides, error) {
 					clilog.Warning.Printf("unable to get default Compute Engine Service Account, %v\n", err)
 				}
 			}
-			taskOverrides.TriggerOverrides = append(taskOverrides.TriggerOverrides, triggerOverride)
-		case "CLOUD_SCHEDULER":
-			triggerOverride := triggeroverrides{}
+		case pubsubTriggerType:
+			// topic name is in the format projects/{project}/topics/{topic}
+			topic := strings.Split(triggerConfig.Properties["Topic name"], "/")
+			if len(topic) != 4 {
+				clilog.Warning.Printf("unable to parse topic name for trigger %s\n", triggerConfig.TriggerNumber)
+				continue
+			}
+			triggerOverride.ProjectId = new(string)
+			triggerOverride.TopicName = new(string)
+			*triggerOverride.ProjectId = topic[1]
+			*triggerOverride.TopicName = topic[3]
+			if triggerSA := triggerConfig.Properties["Service account"]; triggerSA != "" {
+				triggerOverride.ServiceAccount = new(string)
+				*triggerOverride.ServiceAccount = triggerSA
+			}
+		case apiTriggerType:
+			triggerOverride.APIPath = new(string)
+			*triggerOverride.APIPath = strings.TrimPrefix(triggerConfig.TriggerId, apiTrigger)
+			triggerOverride.Properties = triggerConfig.Properties
+		case schedulerTriggerType:
+			if triggerConfig.CloudSchedulerConfig == nil {
+				continue
+			}
 			triggerOverride.CloudSchedulerServiceAccount = new(string)
 			triggerOverride.CloudSchedulerLocation = new(string)
 			triggerOverride.CloudSchedulerCronTab = new(string)
 			*triggerOverride.CloudSchedulerServiceAccount = triggerConfig.CloudSchedulerConfig.ServiceAccountEmail
 			*triggerOverride.CloudSchedulerLocation = triggerConfig.CloudSchedulerConfig.Location
 			*triggerOverride.CloudSchedulerCronTab = triggerConfig.CloudSchedulerConfig.CronTab
-			taskOverrides.TriggerOverrides = append(taskOverrides.TriggerOverrides, triggerOverride)
-		case "INTEGRATION_CONNECTOR_TRIGGER":
-			triggerOverride := triggeroverrides{}
+		case connectorTriggerType:
 			triggerOverride.Properties = triggerConfig.Properties
-			triggerOverride.TriggerNumber = triggerConfig.TriggerNumber
-			triggerOverride.TriggerType = triggerConfig.TriggerType
-			taskOverrides.TriggerOverrides = append(taskOverrides.TriggerOverrides, triggerOverride)
+		case sfdcTriggerType, sfdcCdcTriggerType:
+			triggerOverride.SfdcInstanceName = new(string)
+			triggerOverride.SfdcChannelName = new(string)
+			*triggerOverride.SfdcInstanceName = triggerConfig.Properties["SFDC instance name"]
+			*triggerOverride.SfdcChannelName = triggerConfig.Properties["Channel name"]
+		case cronTriggerType, privateTriggerType, eventarcTriggerType:
+			triggerOverride.TriggerId = new(string)
+			*triggerOverride.TriggerId = triggerConfig.TriggerId
+			triggerOverride.Properties = triggerConfig.Properties
+		default:
+			clilog.Debug.Printf("trigger type %s does not support overrides\n", triggerConfig.TriggerType)
+			continue
 		}
+		taskOverrides.TriggerOverrides = append(taskOverrides.TriggerOverrides, triggerOverride)
 	}
 
 	// handle integration overrides
@@ -394,6 +644,47 @@ func extractOverrides(iversion integrationVersion) (overrides, error) {
 	return taskOverrides, nil
 }
 
+// checkTriggerOverride validates the fields mandatory for each trigger type are set in the override
+func checkTriggerOverride(triggerType string, triggerOverride triggeroverrides) error {
+	switch triggerType {
+	case pubsubExternalTriggerType, pubsubTriggerType:
+		if triggerOverride.ProjectId == nil || triggerOverride.TopicName == nil {
+			return fmt.Errorf("projectid and topicName are mandatory in the overrides")
+		}
+	case apiTriggerType:
+		if triggerOverride.APIPath == nil {
+			return fmt.Errorf("the field apiPath is missing from the API Trigger in overrides")
+		}
+	case connectorTriggerType:
+		if len(triggerOverride.Properties) > 0 {
+			for _, property := range connectorTriggerProperties {
+				if triggerOverride.Properties[property] == "" {
+					return fmt.Errorf("the property %s is missing from the Integration Connector Trigger in overrides", property)
+				}
+			}
+		}
+	case sfdcTriggerType, sfdcCdcTriggerType:
+		if triggerOverride.SfdcInstanceName == nil || triggerOverride.SfdcChannelName == nil {
+			return fmt.Errorf("sfdcInstanceName and sfdcChannelName are mandatory in the overrides")
+		}
+	case cronTriggerType, privateTriggerType, eventarcTriggerType:
+		if triggerOverride.TriggerId == nil {
+			return fmt.Errorf("the field triggerId is missing from the %s Trigger in overrides", triggerType)
+		}
+	}
+	return nil
+}
+
+// getSfdcTriggerId returns the trigger id for the instance and channel, the prefix of
+// the current trigger id is kept
+func getSfdcTriggerId(triggerId string, instanceName string, channelName string) string {
+	prefix := sfdcTriggerPrefix
+	if i := strings.Index(triggerId, "/"); i > 0 {
+		prefix = triggerId[:i]
+	}
+	return fmt.Sprintf(sfdcTrigger, prefix, instanceName, channelName)
+}
+
 func inputOutputVariable(variable string) bool {
 	if variable == "IN" || variable == "OUT" || variable == "IN_OUT" {
 		return true
@@ -401,7 +692,7 @@ func inputOutputVariable(variable string) bool {
 	return false
 }
 
-func handleGenericRestV2Task(taskConfig taskconfig, taskOverrides *overrides) error {
+func handleGenericRestV2Task(client *apiclient.Client, taskConfig taskconfig, taskOverrides *overrides) error {
 	tc := taskconfig{}
 	tc.TaskId = taskConfig.TaskId
 	tc.Task = taskConfig.Task
@@ -416,7 +707,7 @@ func handleGenericRestV2Task(taskConfig taskconfig, taskOverrides *overrides) er
 	}
 
 	if _, ok := taskConfig.Parameters["authConfig"]; ok {
-		displayName, err := authconfigs.GetDisplayName(getAuthConfigUuid(*taskConfig.Parameters["authConfig"].Value.JsonValue))
+		displayName, err := authconfigs.GetDisplayName(client, getAuthConfigUuid(*taskConfig.Parameters["authConfig"].Value.JsonValue))
 		if err != nil {
 			return err
 		}
@@ -436,14 +727,14 @@ func handleGenericRestV2Task(taskConfig taskconfig, taskOverrides *overrides) er
 	return nil
 }
 
-func handleCloudFunctionTask(taskConfig taskconfig, taskOverrides *overrides) error {
+func handleCloudFunctionTask(client *apiclient.Client, taskConfig taskconfig, taskOverrides *overrides) error {
 	tc := taskconfig{}
 	tc.TaskId = taskConfig.TaskId
 	tc.Task = taskConfig.Task
 	tc.Parameters = map[string]eventparameter{}
 	tc.Parameters["TriggerUrl"] = taskConfig.Parameters["TriggerUrl"]
 	if _, ok := taskConfig.Parameters["authConfig"]; ok {
-		displayName, err := authconfigs.GetDisplayName(getAuthConfigUuid(*taskConfig.Parameters["authConfig"].Value.JsonValue))
+		displayName, err := authconfigs.GetDisplayName(client, getAuthConfigUuid(*taskConfig.Parameters["authConfig"].Value.JsonValue))
 		if err != nil {
 			return err
 		}
@@ -499,14 +790,12 @@ func handleGenericConnectorTask(taskConfig taskconfig, taskOverrides *overrides,
 }
 
 // overrideParameters
-func overrideParameters(overrideParameters map[string]eventparameter,
+func overrideParameters(client *apiclient.Client, overrideParameters map[string]eventparameter,
 	taskParameters map[string]eventparameter,
 ) map[string]eventparameter {
 	for overrideParamName, overrideParam := range overrideParameters {
 		if overrideParam.Key == "authConfig" {
-			apiclient.ClientPrintHttpResponse.Set(false)
-			acversion, err := authconfigs.Find(*overrideParam.Value.StringValue, "")
-			apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+			acversion, err := authconfigs.Find(client.WithoutOutput(), *overrideParam.Value.StringValue, "")
 			if err != nil {
 				clilog.Warning.Println(err)
 				return taskParameters
@@ -524,28 +813,17 @@ func overrideParameters(overrideParameters map[string]eventparameter,
 	return taskParameters
 }
 
-func getNewConnectionParams(connectionName string, connectionLocation string) (cp connectionparams, err error) {
+func getNewConnectionParams(client *apiclient.Client, connectionName string, connectionLocation string) (cp connectionparams, err error) {
 	cp = connectionparams{}
 	var connectionVersionResponse map[string]interface{}
-	var integrationRegion string
 
-	apiclient.ClientPrintHttpResponse.Set(false)
-	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+	client = client.WithoutOutput()
 
+	connClient := client
 	if connectionLocation != "" {
-		integrationRegion = apiclient.GetRegion()     // store the integration location
-		err = apiclient.SetRegion(connectionLocation) // set the connector region
-		if err != nil {
-			return cp, err
-		}
-	}
-	connResp, err := connections.Get(connectionName, "BASIC", false, false) // get connector details
-	if connectionLocation != "" {
-		err = apiclient.SetRegion(integrationRegion) // set the integration region back
-		if err != nil {
-			return cp, err
-		}
+		connClient = client.WithRegion(connectionLocation) // use the connector region
 	}
+	connResp, err := connections.Get(connClient, connectionName, "BASIC", false, false) // get connector details
 	if err != nil {
 		return cp, err
 	}
@@ -0,0 +1,153 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"internal/apiclient"
+	"internal/clilog"
+	"testing"
+)
+
+func TestMergeTriggerOverrides(t *testing.T) {
+	client := apiclient.DefaultClient()
+	clilog.Init(false, false, true, true)
+
+	instance, channel, triggerId := "prod-instance", "prod-channel", "private_trigger/prod"
+	eversion := integrationVersionExternal{
+		TriggerConfigs: []triggerconfig{
+			{
+				TriggerNumber: "1",
+				TriggerType:   sfdcTriggerType,
+				TriggerId:     "sfdc_trigger/dev-instance_dev-channel",
+				Properties: map[string]string{
+					"SFDC instance name": "dev-instance",
+					"Channel name":       "dev-channel",
+				},
+			},
+			{
+				TriggerNumber: "2",
+				TriggerType:   privateTriggerType,
+				TriggerId:     "private_trigger/dev",
+			},
+		},
+	}
+	o := overrides{
+		TriggerOverrides: []triggeroverrides{
+			{TriggerNumber: "1", SfdcInstanceName: &instance, SfdcChannelName: &channel},
+			{TriggerNumber: "2", TriggerId: &triggerId},
+		},
+	}
+
+	merged, err := mergeOverrides(client, eversion, o, false)
+	if err != nil {
+		t.Fatalf("mergeOverrides failed: %v", err)
+	}
+	if got := merged.TriggerConfigs[0].TriggerId; got != "sfdc_trigger/prod-instance_prod-channel" {
+		t.Errorf("unexpected sfdc triggerId %s", got)
+	}
+	if got := merged.TriggerConfigs[0].Properties["SFDC instance name"]; got != instance {
+		t.Errorf("unexpected sfdc instance %s", got)
+	}
+	if got := merged.TriggerConfigs[1].TriggerId; got != triggerId {
+		t.Errorf("unexpected private triggerId %s", got)
+	}
+}
+
+func TestSfdcTriggerId(t *testing.T) {
+	client := apiclient.DefaultClient()
+	clilog.Init(false, false, true, true)
+
+	tests := []struct {
+		triggerId  string
+		properties map[string]string
+		instance   string
+		channel    string
+		expected   string
+	}{
+		// the old instance name is a substring of the channel name
+		{"sfdc_trigger/dev_dev-orders", map[string]string{"SFDC instance name": "dev", "Channel name": "dev-orders"},
+			"prod", "prod-orders", "sfdc_trigger/prod_prod-orders"},
+		// the new instance name contains the old channel name
+		{"sfdc_trigger/dev-instance_orders", map[string]string{"SFDC instance name": "dev-instance", "Channel name": "orders"},
+			"orders-instance", "orders-prod", "sfdc_trigger/orders-instance_orders-prod"},
+		// the properties are missing
+		{"sfdc_trigger/dev-instance_dev-channel", nil, "prod-instance", "prod-channel",
+			"sfdc_trigger/prod-instance_prod-channel"},
+	}
+	for _, test := range tests {
+		instance, channel := test.instance, test.channel
+		eversion := integrationVersionExternal{
+			TriggerConfigs: []triggerconfig{{
+				TriggerNumber: "1", TriggerType: sfdcCdcTriggerType,
+				TriggerId: test.triggerId, Properties: test.properties,
+			}},
+		}
+		o := overrides{
+			TriggerOverrides: []triggeroverrides{{TriggerNumber: "1", SfdcInstanceName: &instance, SfdcChannelName: &channel}},
+		}
+		merged, err := mergeOverrides(client, eversion, o, false)
+		if err != nil {
+			t.Fatalf("mergeOverrides failed: %v", err)
+		}
+		if got := merged.TriggerConfigs[0].TriggerId; got != test.expected {
+			t.Errorf("triggerId for %s = %s, expected %s", test.triggerId, got, test.expected)
+		}
+	}
+}
+
+func TestCheckTriggerOverride(t *testing.T) {
+	project := "my-project"
+	tests := []struct {
+		triggerType string
+		override    triggeroverrides
+		wantErr     bool
+	}{
+		{pubsubTriggerType, triggeroverrides{ProjectId: &project}, true},
+		{apiTriggerType, triggeroverrides{}, true},
+		{sfdcCdcTriggerType, triggeroverrides{}, true},
+		{eventarcTriggerType, triggeroverrides{}, true},
+		{connectorTriggerType, triggeroverrides{Properties: map[string]string{"Region": "us-west1"}}, true},
+		{connectorTriggerType, triggeroverrides{}, false},
+		{schedulerTriggerType, triggeroverrides{}, false},
+	}
+	for _, test := range tests {
+		if err := checkTriggerOverride(test.triggerType, test.override); (err != nil) != test.wantErr {
+			t.Errorf("checkTriggerOverride(%s) error = %v, wantErr %v", test.triggerType, err, test.wantErr)
+		}
+	}
+}
+
+func TestValidateOverrides(t *testing.T) {
+	content := []byte(`{
+		"triggerConfigs": [{"triggerNumber": "1", "triggerType": "API", "triggerId": "api_trigger/test"}],
+		"taskConfigs": [{"taskId": "1", "task": "GenericRestV2Task", "parameters": {"url": {"key": "url", "value": {"stringValue": "https://example.com"}}}}],
+		"integrationParameters": [{"key": "_env", "dataType": "STRING_VALUE"}]
+	}`)
+	overridesContent := []byte(`{
+		"trigger_overrides": [{"triggerNumber": "1", "triggerType": "API"}, {"triggerNumber": "2"}],
+		"task_overrides": [{"taskId": "1", "task": "GenericRestV2Task", "parameters": {"url": {"key": "url", "value": {"intValue": "1"}}}}],
+		"param_overrides": [{"key": "_missing"}],
+		"unknown_overrides": []
+	}`)
+
+	problems, err := ValidateOverrides(content, overridesContent)
+	if err != nil {
+		t.Fatalf("ValidateOverrides failed: %v", err)
+	}
+	// schema, missing apiPath, unknown trigger, type mismatch and unknown param
+	if len(problems) != 5 {
+		t.Errorf("expected 5 problems, got %d: %v", len(problems), problems)
+	}
+}
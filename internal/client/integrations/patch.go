@@ -0,0 +1,277 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// patchOperation is an RFC 6902 JSON Patch operation
+type patchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// applyPatches applies RFC 6902 JSON Patch operations to a json document
+func applyPatches(content []byte, patches []patchOperation) ([]byte, error) {
+	var doc interface{}
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return nil, err
+	}
+
+	for i, p := range patches {
+		var err error
+		if doc, err = applyPatch(doc, p); err != nil {
+			return nil, fmt.Errorf("patch %d (%s %s) failed: %w", i, p.Op, p.Path, err)
+		}
+	}
+	return json.Marshal(doc)
+}
+
+func applyPatch(doc interface{}, p patchOperation) (interface{}, error) {
+	switch p.Op {
+	case "add", "replace", "test":
+		if len(p.Value) == 0 {
+			return nil, fmt.Errorf("value is mandatory")
+		}
+		var value interface{}
+		if err := json.Unmarshal(p.Value, &value); err != nil {
+			return nil, err
+		}
+		if p.Op == "test" {
+			current, err := getPointer(doc, p.Path)
+			if err != nil {
+				return nil, err
+			}
+			if !reflect.DeepEqual(current, value) {
+				return nil, fmt.Errorf("value does not match")
+			}
+			return doc, nil
+		}
+		if p.Op == "replace" {
+			if _, err := getPointer(doc, p.Path); err != nil {
+				return nil, err
+			}
+			return setPointer(doc, p.Path, value, true)
+		}
+		return setPointer(doc, p.Path, value, false)
+	case "remove":
+		doc, _, err := removePointer(doc, p.Path)
+		return doc, err
+	case "move":
+		if strings.HasPrefix(p.Path, p.From+"/") {
+			return nil, fmt.Errorf("cannot move a value into one of its children")
+		}
+		doc, value, err := removePointer(doc, p.From)
+		if err != nil {
+			return nil, err
+		}
+		return setPointer(doc, p.Path, value, false)
+	case "copy":
+		value, err := getPointer(doc, p.From)
+		if err != nil {
+			return nil, err
+		}
+		// deep copy the value so later operations don't modify both locations
+		b, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		var valueCopy interface{}
+		if err = json.Unmarshal(b, &valueCopy); err != nil {
+			return nil, err
+		}
+		return setPointer(doc, p.Path, valueCopy, false)
+	default:
+		return nil, fmt.Errorf("unsupported operation %s", p.Op)
+	}
+}
+
+// parsePointer splits an RFC 6901 JSON Pointer into its unescaped tokens
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid json pointer %s", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		token = strings.ReplaceAll(token, "~1", "/")
+		tokens[i] = strings.ReplaceAll(token, "~0", "~")
+	}
+	return tokens, nil
+}
+
+func arrayIndex(token string, length int, allowEnd bool) (int, error) {
+	if allowEnd && token == "-" {
+		return length, nil
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 {
+		return 0, fmt.Errorf("invalid array index %s", token)
+	}
+	if index > length || (!allowEnd && index == length) {
+		return 0, fmt.Errorf("array index %d out of bounds", index)
+	}
+	return index, nil
+}
+
+func getPointer(doc interface{}, pointer string) (interface{}, error) {
+	tokens, err := parsePointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	current := doc
+	for _, token := range tokens {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("path %s not found", pointer)
+			}
+			current = value
+		case []interface{}:
+			index, err := arrayIndex(token, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			current = node[index]
+		default:
+			return nil, fmt.Errorf("path %s not found", pointer)
+		}
+	}
+	return current, nil
+}
+
+// setPointer adds or replaces the value at pointer and returns the updated document
+func setPointer(doc interface{}, pointer string, value interface{}, replace bool) (interface{}, error) {
+	tokens, err := parsePointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	return setToken(doc, tokens, value, replace)
+}
+
+func setToken(node interface{}, tokens []string, value interface{}, replace bool) (interface{}, error) {
+	token := tokens[0]
+	last := len(tokens) == 1
+
+	switch n := node.(type) {
+	case map[string]interface{}:
+		if last {
+			n[token] = value
+			return n, nil
+		}
+		child, ok := n[token]
+		if !ok {
+			return nil, fmt.Errorf("path element %s not found", token)
+		}
+		updated, err := setToken(child, tokens[1:], value, replace)
+		if err != nil {
+			return nil, err
+		}
+		n[token] = updated
+		return n, nil
+	case []interface{}:
+		if last {
+			index, err := arrayIndex(token, len(n), !replace)
+			if err != nil {
+				return nil, err
+			}
+			if replace {
+				n[index] = value
+				return n, nil
+			}
+			n = append(n, nil)
+			copy(n[index+1:], n[index:])
+			n[index] = value
+			return n, nil
+		}
+		index, err := arrayIndex(token, len(n), false)
+		if err != nil {
+			return nil, err
+		}
+		updated, err := setToken(n[index], tokens[1:], value, replace)
+		if err != nil {
+			return nil, err
+		}
+		n[index] = updated
+		return n, nil
+	default:
+		return nil, fmt.Errorf("path element %s not found", token)
+	}
+}
+
+// removePointer removes the value at pointer and returns the updated document and the removed value
+func removePointer(doc interface{}, pointer string) (interface{}, interface{}, error) {
+	tokens, err := parsePointer(pointer)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, nil, fmt.Errorf("cannot remove the document root")
+	}
+	return removeToken(doc, tokens)
+}
+
+func removeToken(node interface{}, tokens []string) (interface{}, interface{}, error) {
+	token := tokens[0]
+	last := len(tokens) == 1
+
+	switch n := node.(type) {
+	case map[string]interface{}:
+		child, ok := n[token]
+		if !ok {
+			return nil, nil, fmt.Errorf("path element %s not found", token)
+		}
+		if last {
+			delete(n, token)
+			return n, child, nil
+		}
+		updated, removed, err := removeToken(child, tokens[1:])
+		if err != nil {
+			return nil, nil, err
+		}
+		n[token] = updated
+		return n, removed, nil
+	case []interface{}:
+		index, err := arrayIndex(token, len(n), false)
+		if err != nil {
+			return nil, nil, err
+		}
+		if last {
+			removed := n[index]
+			return append(n[:index], n[index+1:]...), removed, nil
+		}
+		updated, removed, err := removeToken(n[index], tokens[1:])
+		if err != nil {
+			return nil, nil, err
+		}
+		n[index] = updated
+		return n, removed, nil
+	default:
+		return nil, nil, fmt.Errorf("path element %s not found", token)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestApplyPatches(t *testing.T) {
+	content := []byte(`{"taskConfigs":[{"taskId":"1","failurePolicy":{"maxRetries":1},"nextTasks":[{"taskId":"2","condition":"a"}]}],"errorCatcherConfigs":[{"errorCatcherId":"e1"}]}`)
+	patches := []patchOperation{}
+	if err := json.Unmarshal([]byte(`[
+		{"op":"test","path":"/taskConfigs/0/taskId","value":"1"},
+		{"op":"replace","path":"/taskConfigs/0/failurePolicy/maxRetries","value":5},
+		{"op":"replace","path":"/taskConfigs/0/nextTasks/0/condition","value":"b"},
+		{"op":"add","path":"/taskConfigs/0/nextTasks/-","value":{"taskId":"3"}},
+		{"op":"copy","from":"/errorCatcherConfigs/0","path":"/errorCatcherConfigs/-"},
+		{"op":"remove","path":"/errorCatcherConfigs/0"}
+	]`), &patches); err != nil {
+		t.Fatalf("unable to parse patches: %v", err)
+	}
+
+	patched, err := applyPatches(content, patches)
+	if err != nil {
+		t.Fatalf("applyPatches failed: %v", err)
+	}
+	want := `{"errorCatcherConfigs":[{"errorCatcherId":"e1"}],"taskConfigs":[{"failurePolicy":{"maxRetries":5},"nextTasks":[{"condition":"b","taskId":"2"},{"taskId":"3"}],"taskId":"1"}]}`
+	if string(patched) != want {
+		t.Errorf("unexpected patch result %s", string(patched))
+	}
+
+	failing := []patchOperation{
+		{Op: "replace", Path: "/missing", Value: json.RawMessage(`1`)},
+		{Op: "test", Path: "/taskConfigs/0/taskId", Value: json.RawMessage(`"2"`)},
+		{Op: "remove", Path: "/taskConfigs/5"},
+		{Op: "unknown", Path: "/taskConfigs"},
+	}
+	for _, p := range failing {
+		if _, err = applyPatches(content, []patchOperation{p}); err == nil {
+			t.Errorf("expected %s %s to fail", p.Op, p.Path)
+		}
+	}
+}
@@ -22,8 +22,8 @@ import (
 )
 
 // List all suspensions
-func ListSuspensions(name string, execution string, pageSize int, pageToken string, filter string, orderBy string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+func ListSuspensions(client *apiclient.Client, name string, execution string, pageSize int, pageToken string, filter string, orderBy string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	q := u.Query()
 	if pageSize != -1 {
 		q.Set("pageSize", strconv.Itoa(pageSize))
@@ -40,16 +40,16 @@ func ListSuspensions(name string, execution string, pageSize int, pageToken stri
 
 	u.RawQuery = q.Encode()
 	u.Path = path.Join(u.Path, "integrations", name, "executions", execution, "suspensions")
-	respBody, err = apiclient.HttpClient(u.String())
+	respBody, err = client.HttpClient(u.String())
 	return respBody, err
 }
 
 // Lift a suspension
-func Lift(name string, execution string, suspension string, result string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+func Lift(client *apiclient.Client, name string, execution string, suspension string, result string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	u.Path = path.Join(u.Path, "integrations", name, "executions", execution, "suspensions", suspension, ":lift")
 	payload := "{ \"suspension_result\":\"" + result + "\"}"
-	respBody, err = apiclient.HttpClient(u.String(), payload)
+	respBody, err = client.HttpClient(u.String(), payload)
 	return respBody, err
 }
 
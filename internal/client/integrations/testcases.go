@@ -76,47 +76,47 @@ type assertionResult struct {
 	FailureMessage string      `json:"failureMessage,omitempty"`
 }
 
-func CreateTestCase(name string, version string, content string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+func CreateTestCase(client *apiclient.Client, name string, version string, content string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	u.Path = path.Join(u.Path, "integrations", name, "versions", version, "testCases")
-	respBody, err = apiclient.HttpClient(u.String(), content)
+	respBody, err = client.HttpClient(u.String(), content)
 	return respBody, err
 }
 
-func CreateTestCaseBySnapshot(name string, snapshot string, content string) (respBody []byte, err error) {
-	version, err := getTestCaseIntegrationVersion(name, snapshot, "")
+func CreateTestCaseBySnapshot(client *apiclient.Client, name string, snapshot string, content string) (respBody []byte, err error) {
+	version, err := getTestCaseIntegrationVersion(client, name, snapshot, "")
 	if err != nil {
 		return nil, err
 	}
-	return CreateTestCase(name, version, content)
+	return CreateTestCase(client, name, version, content)
 }
 
-func CreateTestCaseByUserLabel(name string, userLabel string, content string) (respBody []byte, err error) {
-	version, err := getTestCaseIntegrationVersion(name, "", userLabel)
+func CreateTestCaseByUserLabel(client *apiclient.Client, name string, userLabel string, content string) (respBody []byte, err error) {
+	version, err := getTestCaseIntegrationVersion(client, name, "", userLabel)
 	if err != nil {
 		return nil, err
 	}
-	return CreateTestCase(name, version, content)
+	return CreateTestCase(client, name, version, content)
 }
 
-func DeleteTestCase(name string, version string, testCaseID string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+func DeleteTestCase(client *apiclient.Client, name string, version string, testCaseID string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	u.Path = path.Join(u.Path, "integrations", name, "versions", version, "testCases", testCaseID)
-	respBody, err = apiclient.HttpClient(u.String(), "", "DELETE")
+	respBody, err = client.HttpClient(u.String(), "", "DELETE")
 	return respBody, err
 }
 
-func GetTestCase(name string, version string, testCaseID string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+func GetTestCase(client *apiclient.Client, name string, version string, testCaseID string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	u.Path = path.Join(u.Path, "integrations", name, "versions", version, "testCases", testCaseID)
-	respBody, err = apiclient.HttpClient(u.String())
+	respBody, err = client.HttpClient(u.String())
 	return respBody, err
 }
 
-func ListTestCases(name string, version string, full bool, filter string,
+func ListTestCases(client *apiclient.Client, name string, version string, full bool, filter string,
 	pageSize int, pageToken string, orderBy string) (respBody []byte, err error) {
 
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	q := u.Query()
 	if pageSize != -1 {
 		q.Set("pageSize", strconv.Itoa(pageSize))
@@ -133,17 +133,31 @@ func ListTestCases(name string, version string, full bool, filter string,
 
 	u.RawQuery = q.Encode()
 	u.Path = path.Join(u.Path, "integrations", name, "versions", version, "testCases")
-	respBody, err = apiclient.HttpClient(u.String())
+	respBody, err = client.HttpClient(u.String())
 	if !full {
 		return getTestCases(respBody, full)
 	}
 	return respBody, err
 }
 
-func ExecuteTestCase(name string, version string, testCaseID string, content string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+// ListAllTestCases lists the test cases of every page, with a limit greater than 0
+// no more than limit test cases are returned
+func ListAllTestCases(client *apiclient.Client, name string, version string, full bool, filter string,
+	pageSize int, orderBy string, limit int) (respBody []byte, err error) {
+
+	respBody, err = client.ListAll(func(client *apiclient.Client, pageToken string, pageSize int) ([]byte, error) {
+		return ListTestCases(client, name, version, true, filter, pageSize, pageToken, orderBy)
+	}, pageSize, limit)
+	if err != nil {
+		return nil, err
+	}
+	return getTestCases(respBody, full)
+}
+
+func ExecuteTestCase(client *apiclient.Client, name string, version string, testCaseID string, content string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	u.Path = path.Join(u.Path, "integrations", name, "versions", version, "testCases", testCaseID, ":executeTest")
-	respBody, err = apiclient.HttpClient(u.String(), content)
+	respBody, err = client.HttpClient(u.String(), content)
 	return respBody, err
 }
 
@@ -159,32 +173,52 @@ func AssertTestExecutionResult(testBody []byte) error {
 	return fmt.Errorf("test failed with %d assertions", len(tr.AssertionResults))
 }
 
-func ListTestCasesByUserlabel(name string, userLabel string, full bool, filter string,
+func ListTestCasesByUserlabel(client *apiclient.Client, name string, userLabel string, full bool, filter string,
 	pageSize int, pageToken string, orderBy string) (respBody []byte, err error) {
 
-	version, err := getTestCaseIntegrationVersion(name, "", userLabel)
+	version, err := getTestCaseIntegrationVersion(client, name, "", userLabel)
 	if err != nil {
 		return nil, err
 	}
-	return ListTestCases(name, version, full, filter, pageSize, pageToken, orderBy)
+	return ListTestCases(client, name, version, full, filter, pageSize, pageToken, orderBy)
 }
 
-func ListTestCasesBySnapshot(name string, snapshot string, full bool, filter string,
+func ListTestCasesBySnapshot(client *apiclient.Client, name string, snapshot string, full bool, filter string,
 	pageSize int, pageToken string, orderBy string) (respBody []byte, err error) {
 
-	version, err := getTestCaseIntegrationVersion(name, snapshot, "")
+	version, err := getTestCaseIntegrationVersion(client, name, snapshot, "")
 	if err != nil {
 		return nil, err
 	}
-	return ListTestCases(name, version, full, filter, pageSize, pageToken, orderBy)
+	return ListTestCases(client, name, version, full, filter, pageSize, pageToken, orderBy)
+}
+
+func ListAllTestCasesByUserlabel(client *apiclient.Client, name string, userLabel string, full bool, filter string,
+	pageSize int, orderBy string, limit int) (respBody []byte, err error) {
+
+	version, err := getTestCaseIntegrationVersion(client, name, "", userLabel)
+	if err != nil {
+		return nil, err
+	}
+	return ListAllTestCases(client, name, version, full, filter, pageSize, orderBy, limit)
+}
+
+func ListAllTestCasesBySnapshot(client *apiclient.Client, name string, snapshot string, full bool, filter string,
+	pageSize int, orderBy string, limit int) (respBody []byte, err error) {
+
+	version, err := getTestCaseIntegrationVersion(client, name, snapshot, "")
+	if err != nil {
+		return nil, err
+	}
+	return ListAllTestCases(client, name, version, full, filter, pageSize, orderBy, limit)
 }
 
 // FindTestCase
-func FindTestCase(name string, integrationVersion string, displayName string, pageToken string) (version string, err error) {
+func FindTestCase(client *apiclient.Client, name string, integrationVersion string, displayName string, pageToken string) (version string, err error) {
 	lt := listTestCases{}
 	var respBody []byte
 
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	if pageToken != "" {
 		q := u.Query()
 		q.Set("pageToken", pageToken)
@@ -192,7 +226,7 @@ func FindTestCase(name string, integrationVersion string, displayName string, pa
 	}
 
 	u.Path = path.Join(u.Path, "integrations", name, "versions", integrationVersion, "testCases")
-	if respBody, err = apiclient.HttpClient(u.String()); err != nil {
+	if respBody, err = client.HttpClient(u.String()); err != nil {
 		return "", err
 	}
 
@@ -207,38 +241,13 @@ func FindTestCase(name string, integrationVersion string, displayName string, pa
 		}
 	}
 	if lt.NextPageToken != "" {
-		return FindTestCase(name, integrationVersion, displayName, lt.NextPageToken)
+		return FindTestCase(client, name, integrationVersion, displayName, lt.NextPageToken)
 	}
 	return "", fmt.Errorf("testCase not found")
 }
 
-func ListAllTestCases(name string, version string) (respBody []byte, err error) {
-	l := listTestCases{}
-	for {
-		newltc := listTestCases{}
-		respBody, err = ListTestCases(name, version, true, "", -1, "", "")
-		if err != nil {
-			return nil, err
-		}
-		err = json.Unmarshal(respBody, &newltc)
-		if err != nil {
-			return nil, err
-		}
-
-		l.TestCases = append(l.TestCases, newltc.TestCases...)
-
-		if newltc.NextPageToken == "" {
-			break
-		}
-	}
-
-	respBody, err = json.Marshal(l)
-
-	return respBody, err
-}
-
-func DeleteAllTestCases(name string, version string) (err error) {
-	respBody, err := ListAllTestCases(name, version)
+func DeleteAllTestCases(client *apiclient.Client, name string, version string) (err error) {
+	respBody, err := ListAllTestCases(client.WithoutOutput(), name, version, true, "", -1, "", 0)
 	if err != nil {
 		return err
 	}
@@ -250,7 +259,7 @@ func DeleteAllTestCases(name string, version string) (err error) {
 	}
 
 	for _, tc := range l.TestCases {
-		_, err = DeleteTestCase(name, version, filepath.Base(tc.Name))
+		_, err = DeleteTestCase(client, name, version, filepath.Base(tc.Name))
 		if err != nil {
 			return err
 		}
@@ -259,19 +268,19 @@ func DeleteAllTestCases(name string, version string) (err error) {
 	return nil
 }
 
-func getTestCaseIntegrationVersion(name string, snapshot string, userLabel string) (version string, err error) {
+func getTestCaseIntegrationVersion(client *apiclient.Client, name string, snapshot string, userLabel string) (version string, err error) {
 
 	var iversionBytes []byte
 
-	apiclient.ClientPrintHttpResponse.Set(false)
+	client = client.WithoutOutput()
 
 	if snapshot != "" {
-		iversionBytes, err = GetBySnapshot(name, snapshot, false, false, false)
+		iversionBytes, err = GetBySnapshot(client, name, snapshot, false, false, false)
 		if err != nil {
 			return "", err
 		}
 	} else {
-		iversionBytes, err = GetByUserlabel(name, userLabel, false, false, false)
+		iversionBytes, err = GetByUserlabel(client, name, userLabel, false, false, false)
 		if err != nil {
 			return "", err
 		}
@@ -285,8 +294,6 @@ func getTestCaseIntegrationVersion(name string, snapshot string, userLabel strin
 
 	version = getVersion(iversion.Name)
 
-	apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
-
 	return version, nil
 
 }
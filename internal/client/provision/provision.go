@@ -24,8 +24,8 @@ import (
 )
 
 // Provision
-func Provision(cloudkms string, samples bool, gmek bool, serviceAccount string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+func Provision(client *apiclient.Client, cloudkms string, samples bool, gmek bool, serviceAccount string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	provStr := []string{}
 
 	if serviceAccount != "" {
@@ -43,7 +43,7 @@ func Provision(cloudkms string, samples bool, gmek bool, serviceAccount string)
 	u.Path = path.Join(u.Path, "clients:provision")
 
 	payload := "{" + strings.Join(provStr, ",") + "}"
-	respBody, err = apiclient.HttpClient(u.String(), payload)
+	respBody, err = client.HttpClient(u.String(), payload)
 	return respBody, err
 }
 
@@ -46,24 +46,24 @@ type channelExternal struct {
 }
 
 // CreateChannelFromContent
-func CreateChannelFromContent(instanceVersion string, content []byte) (respBody []byte, err error) {
+func CreateChannelFromContent(client *apiclient.Client, instanceVersion string, content []byte) (respBody []byte, err error) {
 	c := channel{}
 
 	if err = json.Unmarshal(content, &c); err != nil {
 		return nil, err
 	}
 
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	u.Path = path.Join(u.Path, "sfdcInstances")
 
 	u.Path = path.Join(u.Path, "sfdcInstances", instanceVersion, "sfdcChannels")
-	respBody, err = apiclient.HttpClient(u.String(), string(content))
+	respBody, err = client.HttpClient(u.String(), string(content))
 	return respBody, err
 }
 
 // CreateChannel
-func CreateChannel(name string, instance string, description string, channelTopic string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+func CreateChannel(client *apiclient.Client, name string, instance string, description string, channelTopic string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 
 	channelStr := []string{}
 
@@ -74,19 +74,20 @@ func CreateChannel(name string, instance string, description string, channelTopi
 	payload := "{" + strings.Join(channelStr, ",") + "}"
 
 	u.Path = path.Join(u.Path, "sfdcInstances", instance, "sfdcChannels")
-	respBody, err = apiclient.HttpClient(u.String(), payload)
+	respBody, err = client.HttpClient(u.String(), payload)
 	return respBody, err
 }
 
 // GetChannel
-func GetChannel(name string, instance string, minimal bool) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+func GetChannel(client *apiclient.Client, name string, instance string, minimal bool) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	u.Path = path.Join(u.Path, "sfdcInstances", instance, "sfdcChannels", name)
 
+	httpClient := client
 	if minimal {
-		apiclient.ClientPrintHttpResponse.Set(false)
+		httpClient = client.WithoutOutput()
 	}
-	respBody, err = apiclient.HttpClient(u.String())
+	respBody, err = httpClient.HttpClient(u.String())
 	if minimal {
 		iversion := channel{}
 		err := json.Unmarshal(respBody, &iversion)
@@ -98,28 +99,27 @@ func GetChannel(name string, instance string, minimal bool) (respBody []byte, er
 		if err != nil {
 			return nil, err
 		}
-		apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
-		apiclient.PrettyPrint(respBody)
+		client.PrettyPrint(respBody)
 
 	}
 	return respBody, err
 }
 
 // ListChannels
-func ListChannels(instance string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+func ListChannels(client *apiclient.Client, instance string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	u.Path = path.Join(u.Path, "sfdcInstances", instance, "sfdcChannels")
-	respBody, err = apiclient.HttpClient(u.String())
+	respBody, err = client.HttpClient(u.String())
 	return respBody, err
 }
 
 // FindChannel
-func FindChannel(name string, instance string) (version string, respBody []byte, err error) {
+func FindChannel(client *apiclient.Client, name string, instance string) (version string, respBody []byte, err error) {
 	clist := channels{}
 
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	u.Path = path.Join(u.Path, "sfdcInstances", instance, "sfdcChannels")
-	if respBody, err = apiclient.HttpClient(u.String()); err != nil {
+	if respBody, err = client.HttpClient(u.String()); err != nil {
 		return "", nil, err
 	}
 
@@ -138,15 +138,15 @@ func FindChannel(name string, instance string) (version string, respBody []byte,
 }
 
 // GetInstancesAndChannels
-func GetInstancesAndChannels(instances map[string]string) (instancesContent map[string]string, err error) {
+func GetInstancesAndChannels(client *apiclient.Client, instances map[string]string) (instancesContent map[string]string, err error) {
 	instancesContent = make(map[string]string)
 
 	for instance, channel := range instances {
-		instanceUuid, instancesResp, err := FindInstance(instance)
+		instanceUuid, instancesResp, err := FindInstance(client, instance)
 		if err != nil {
 			return instancesContent, err
 		}
-		_, channelResp, err := FindChannel(channel, instanceUuid)
+		_, channelResp, err := FindChannel(client, channel, instanceUuid)
 		if err != nil {
 			return instancesContent, err
 		}
@@ -49,23 +49,23 @@ type instanceExternal struct {
 }
 
 // CreateInstanceFromContent
-func CreateInstanceFromContent(content []byte) (respBody []byte, err error) {
+func CreateInstanceFromContent(client *apiclient.Client, content []byte) (respBody []byte, err error) {
 	i := instance{}
 
 	if err = json.Unmarshal(content, &i); err != nil {
 		return nil, err
 	}
 
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	u.Path = path.Join(u.Path, "sfdcInstances")
 
-	respBody, err = apiclient.HttpClient(u.String(), string(content))
+	respBody, err = client.HttpClient(u.String(), string(content))
 	return respBody, err
 }
 
 // CreateInstance
-func CreateInstance(name string, description string, sfdcOrgId string, serviceAuthority string, authConfig []string) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+func CreateInstance(client *apiclient.Client, name string, description string, sfdcOrgId string, serviceAuthority string, authConfig []string) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 
 	if len(authConfig) < 1 {
 		return nil, fmt.Errorf("at least one authConfig must be sent")
@@ -83,20 +83,21 @@ func CreateInstance(name string, description string, sfdcOrgId string, serviceAu
 
 	payload := "{" + strings.Join(instanceStr, ",") + "}"
 	u.Path = path.Join(u.Path, "sfdcInstances")
-	respBody, err = apiclient.HttpClient(u.String(), payload)
+	respBody, err = client.HttpClient(u.String(), payload)
 
 	return respBody, err
 }
 
 // GetInstance
-func GetInstance(name string, minimal bool) (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+func GetInstance(client *apiclient.Client, name string, minimal bool) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	u.Path = path.Join(u.Path, "sfdcInstances", name)
 
+	httpClient := client
 	if minimal {
-		apiclient.ClientPrintHttpResponse.Set(false)
+		httpClient = client.WithoutOutput()
 	}
-	respBody, err = apiclient.HttpClient(u.String())
+	respBody, err = httpClient.HttpClient(u.String())
 	if minimal {
 		iversion := instance{}
 		err := json.Unmarshal(respBody, &iversion)
@@ -108,25 +109,24 @@ func GetInstance(name string, minimal bool) (respBody []byte, err error) {
 		if err != nil {
 			return nil, err
 		}
-		apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
-		apiclient.PrettyPrint(respBody)
+		client.PrettyPrint(respBody)
 	}
 	return respBody, err
 }
 
 // ListInstances
-func ListInstances() (respBody []byte, err error) {
-	u, _ := url.Parse(apiclient.GetBaseIntegrationURL())
+func ListInstances(client *apiclient.Client) (respBody []byte, err error) {
+	u, _ := url.Parse(client.GetBaseIntegrationURL())
 	u.Path = path.Join(u.Path, "sfdcInstances")
-	respBody, err = apiclient.HttpClient(u.String())
+	respBody, err = client.HttpClient(u.String())
 	return respBody, err
 }
 
 // FindInstance
-func FindInstance(name string) (version string, respBody []byte, err error) {
+func FindInstance(client *apiclient.Client, name string) (version string, respBody []byte, err error) {
 	ilist := instances{}
 
-	respBody, err = ListInstances()
+	respBody, err = ListInstances(client)
 	if err != nil {
 		return "", nil, err
 	}
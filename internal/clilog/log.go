@@ -15,9 +15,11 @@
 package clilog
 
 import (
+	"fmt"
 	"io"
 	"log"
 	"os"
+	"time"
 )
 
 // log levels, default is error
@@ -30,9 +32,65 @@ var (
 	HTTPError    *log.Logger
 )
 
+// Format is the format of the log statements
+type Format string
+
+const (
+	TEXT Format = "text"
+	JSON Format = "json"
+)
+
+func (f *Format) String() string {
+	return string(*f)
+}
+
+func (f *Format) Set(r string) error {
+	switch r {
+	case "text", "json":
+		*f = Format(r)
+	default:
+		return fmt.Errorf("must be one of %s or %s", TEXT, JSON)
+	}
+	return nil
+}
+
+func (f *Format) Type() string {
+	return "format"
+}
+
+var (
+	logFormat   = TEXT
+	logFile     io.Writer // receives the debug logs and a copy of warnings and errors
+	debugHandle io.Writer = io.Discard
+)
+
+// SetFormat sets the format of the debug, info, warning and error statements.
+// Responses are always printed as is. Call before Init
+func SetFormat(format Format) {
+	if format == "" {
+		format = TEXT
+	}
+	logFormat = format
+}
+
+// SetLogFile sends the debug logs to the file instead of stdout, and copies
+// the warnings and errors to it. Call before Init
+func SetLogFile(name string) error {
+	if name == "" {
+		logFile = nil
+		return nil
+	}
+	f, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("unable to open the log file: %w", err)
+	}
+	logFile = f
+	return nil
+}
+
 // Init function initializes the logger objects
 func Init(debug bool, print bool, noOutput bool, suppressWarnings bool) {
-	debugHandle := io.Discard
+	debugHandle = io.Discard
 	infoHandle := io.Discard
 	var warningHandle, errorHandle, responseHandle io.Writer
 
@@ -60,24 +118,58 @@ func Init(debug bool, print bool, noOutput bool, suppressWarnings bool) {
 		warningHandle = io.Discard
 	}
 
-	Debug = log.New(debugHandle,
-		"DEBUG: ",
-		log.Ldate|log.Ltime|log.Lshortfile)
+	// the log file always gets the debug logs, stdout stays clean
+	if logFile != nil {
+		debugHandle = logFile
+		warningHandle = io.MultiWriter(warningHandle, logFile)
+		errorHandle = io.MultiWriter(errorHandle, logFile)
+	}
 
-	Info = log.New(infoHandle,
-		"", 0)
+	Debug = newLogger(debugHandle, "DEBUG", true)
 
-	Warning = log.New(warningHandle,
-		"WARNING: ",
-		log.Ldate|log.Ltime|log.Lshortfile)
+	Info = newLogger(infoHandle, "INFO", false)
 
-	Error = log.New(errorHandle,
-		"ERROR: ",
-		log.Ldate|log.Ltime|log.Lshortfile)
+	Warning = newLogger(warningHandle, "WARNING", true)
+
+	Error = newLogger(errorHandle, "ERROR", true)
 
 	HTTPResponse = log.New(responseHandle,
 		"", 0)
 
-	HTTPError = log.New(errorHandle,
+	HTTPError = log.New(&redactWriter{errorHandle},
 		"", 0)
 }
+
+// newLogger returns a logger for the level in the log format. Secrets are
+// redacted from the statements, except for info statements which may print
+// values requested by the user, such as the generated access token
+func newLogger(w io.Writer, level string, redact bool) *log.Logger {
+	if logFormat == JSON {
+		w = &recordWriter{w: w, level: level}
+	}
+	if redact {
+		w = &redactWriter{w}
+	}
+	if logFormat == JSON || level == "INFO" {
+		return log.New(w, "", 0)
+	}
+	return log.New(w, level+": ", log.Ldate|log.Ltime|log.Lshortfile)
+}
+
+// HTTPStatus logs the status code and the duration of a request in the debug logs
+func HTTPStatus(method string, url string, status int, elapsed time.Duration) {
+	if debugHandle == io.Discard {
+		return
+	}
+	if logFormat == JSON {
+		_ = writeRecord(debugHandle, record{
+			Level:      "DEBUG",
+			Message:    Redact(method + " " + url),
+			HTTPMethod: method,
+			HTTPStatus: status,
+			Latency:    elapsed.String(),
+		})
+		return
+	}
+	Debug.Printf("%s %s returned status code %d in %s\n", method, url, status, elapsed)
+}
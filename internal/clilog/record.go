@@ -0,0 +1,87 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clilog
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// record is a structured log statement
+type record struct {
+	Time       string `json:"time"`
+	Level      string `json:"level"`
+	Message    string `json:"message"`
+	Command    string `json:"command,omitempty"`
+	Resource   string `json:"resource,omitempty"`
+	HTTPMethod string `json:"httpMethod,omitempty"`
+	HTTPStatus int    `json:"httpStatus,omitempty"`
+	Latency    string `json:"latency,omitempty"`
+}
+
+// attributes added to every record
+var attributes struct {
+	sync.Mutex
+	command  string
+	resource string
+}
+
+// SetCommand sets the command added to the records, for example "integrationcli integrations list"
+func SetCommand(command string) {
+	attributes.Lock()
+	defer attributes.Unlock()
+	attributes.command = command
+}
+
+// SetResource sets the name of the resource the command works on, added to the records
+func SetResource(resource string) {
+	attributes.Lock()
+	defer attributes.Unlock()
+	attributes.resource = resource
+}
+
+// recordWriter writes each statement of a logger as a json record on a line
+type recordWriter struct {
+	w     io.Writer
+	level string
+}
+
+func (r *recordWriter) Write(p []byte) (int, error) {
+	if err := writeRecord(r.w, record{
+		Level:   r.level,
+		Message: strings.TrimSuffix(string(p), "\n"),
+	}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func writeRecord(w io.Writer, rec record) error {
+	rec.Time = time.Now().UTC().Format(time.RFC3339Nano)
+	attributes.Lock()
+	rec.Command = attributes.command
+	rec.Resource = attributes.resource
+	attributes.Unlock()
+
+	b, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
@@ -0,0 +1,128 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clilog
+
+import (
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// redacted replaces secrets in the logs
+const redacted = "REDACTED"
+
+// secretPatterns match credentials in log statements
+var secretPatterns = []*regexp.Regexp{
+	// Authorization headers
+	regexp.MustCompile(`(?i)bearer\s+[A-Za-z0-9._~+/=-]+`),
+	// Google OAuth access tokens and JWTs
+	regexp.MustCompile(`ya29\.[A-Za-z0-9._-]+`),
+	regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`),
+	// PEM private keys
+	regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`),
+}
+
+// jsonStringField matches json fields with a string value, the secret ones are redacted
+var jsonStringField = regexp.MustCompile(`("([A-Za-z0-9_.-]+)"\s*:\s*)"(?:\\.|[^"\\])*"`)
+
+// secretKeys are parts of the keys that hold credentials
+var secretKeys = []string{
+	"password", "passphrase", "secret", "token", "privatekey", "apikey", "credential", "authorization",
+}
+
+// secretKeySuffixes end the keys that hold credentials, for example sshClientCertPass
+var secretKeySuffixes = []string{"pass", "passwd", "sshclientcert", "jwt"}
+
+// publicKeys end the keys that contain a secret key but do not hold credentials
+var publicKeys = []string{"pagetoken", "tokenuri", "tokenendpoint", "tokentype", "credentialtype"}
+
+// IsSecretKey returns true for json keys, query parameters and flags that hold credentials,
+// for example clientSecret, authToken.token, private_key or passphrase. Page tokens are not secret
+func IsSecretKey(key string) bool {
+	key = strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(key))
+	for _, public := range publicKeys {
+		if strings.HasSuffix(key, public) {
+			return false
+		}
+	}
+	for _, secret := range secretKeys {
+		if strings.Contains(key, secret) {
+			return true
+		}
+	}
+	for _, suffix := range secretKeySuffixes {
+		if strings.HasSuffix(key, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+var secrets struct {
+	sync.RWMutex
+	values []string
+}
+
+// AddSecret redacts the value, for example an access token, from the logs
+func AddSecret(value string) {
+	if len(value) < 8 {
+		return
+	}
+	secrets.Lock()
+	defer secrets.Unlock()
+	for _, v := range secrets.values {
+		if v == value {
+			return
+		}
+	}
+	secrets.values = append(secrets.values, value)
+}
+
+// Redact replaces the secrets in the statement
+func Redact(s string) string {
+	secrets.RLock()
+	for _, v := range secrets.values {
+		s = strings.ReplaceAll(s, v, redacted)
+	}
+	secrets.RUnlock()
+	for _, p := range secretPatterns {
+		s = p.ReplaceAllStringFunc(s, func(m string) string {
+			if i := strings.IndexAny(m, " \t"); i > 0 && !strings.HasPrefix(m, "-----") {
+				return m[:i+1] + redacted
+			}
+			return redacted
+		})
+	}
+	return jsonStringField.ReplaceAllStringFunc(s, func(m string) string {
+		field := jsonStringField.FindStringSubmatch(m)
+		if !IsSecretKey(field[2]) {
+			return m
+		}
+		return field[1] + `"` + redacted + `"`
+	})
+}
+
+// redactWriter redacts the secrets in the statements written to the writer
+type redactWriter struct {
+	w io.Writer
+}
+
+func (r *redactWriter) Write(p []byte) (int, error) {
+	if _, err := r.w.Write([]byte(Redact(string(p)))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
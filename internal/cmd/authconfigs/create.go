@@ -57,6 +57,7 @@ var CreateCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		var content []byte
 
@@ -96,7 +97,7 @@ var CreateCmd = &cobra.Command{
 			}
 		}
 
-		_, err = authconfigs.Create(content)
+		_, err = authconfigs.Create(client, content)
 		return err
 	},
 	Example: `Create a new user name auth config: ` + GetExample(0) + `
@@ -43,13 +43,14 @@ var ExportCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		folder := utils.GetStringParam(cmd.Flag("folder"))
 		if err = apiclient.FolderExists(folder); err != nil {
 			return err
 		}
 
-		return authconfigs.Export(folder)
+		return authconfigs.Export(client, folder)
 	},
 }
 
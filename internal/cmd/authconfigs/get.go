@@ -53,23 +53,22 @@ var GetCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		name := utils.GetStringParam(cmd.Flag("name"))
 		id := utils.GetStringParam(cmd.Flag("id"))
 		minimal := utils.GetBasicInfo(cmd, "minimal")
 
 		if name != "" {
-			apiclient.DisableCmdPrintHttpResponse()
-			version, err := authconfigs.Find(name, "")
+			version, err := authconfigs.Find(client.WithoutOutput(), name, "")
 			if err != nil {
 				return err
 			}
-			apiclient.EnableCmdPrintHttpResponse()
-			_, err = authconfigs.Get(path.Base(version), minimal)
+			_, err = authconfigs.Get(client, path.Base(version), minimal)
 			return err
 		}
 
-		_, err = authconfigs.Get(id, minimal)
+		_, err = authconfigs.Get(client, id, minimal)
 
 		return err
 	},
@@ -43,11 +43,20 @@ var ListCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		pageToken := utils.GetStringParam(cmd.Flag("pageToken"))
 		filter := utils.GetStringParam(cmd.Flag("filter"))
+		all, limit, err := utils.GetListAllFlags(cmd)
+		if err != nil {
+			return err
+		}
+		if all {
+			_, err = authconfigs.ListAll(client, pageSize, filter, limit)
+			return err
+		}
 
-		_, err = authconfigs.List(pageSize, pageToken, filter)
+		_, err = authconfigs.List(client, pageSize, pageToken, filter)
 		return err
 	},
 }
@@ -63,4 +72,6 @@ func init() {
 		"", "A page token, received from a previous call")
 	ListCmd.Flags().StringVarP(&filter, "filter", "",
 		"", "Filter results")
+
+	utils.AddListAllFlags(ListCmd)
 }
@@ -44,6 +44,7 @@ var PatchCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		name := utils.GetStringParam(cmd.Flag("name"))
 
@@ -56,7 +57,7 @@ var PatchCmd = &cobra.Command{
 			return err
 		}
 
-		_, err = authconfigs.Patch(name, content, updateMask)
+		_, err = authconfigs.Patch(client, name, content, updateMask)
 		return err
 	},
 }
@@ -51,6 +51,7 @@ var CreateCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		var sslCertContent, privateKeyCertContent []byte
 		name := utils.GetStringParam(cmd.Flag("name"))
@@ -81,7 +82,7 @@ var CreateCmd = &cobra.Command{
 			}
 		}
 
-		_, err = certificates.Create(name, description, string(sslCertContent), string(privateKeyCertContent), passphrase)
+		_, err = certificates.Create(client, name, description, string(sslCertContent), string(privateKeyCertContent), passphrase)
 		return err
 	},
 }
@@ -43,9 +43,10 @@ var DelCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		name := utils.GetStringParam(cmd.Flag("name"))
-		_, err = certificates.Delete(name)
+		_, err = certificates.Delete(client, name)
 		return
 	},
 }
@@ -52,22 +52,21 @@ var GetCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		name := utils.GetStringParam(cmd.Flag("name"))
 		id := utils.GetStringParam(cmd.Flag("id"))
 
 		if name != "" {
-			apiclient.DisableCmdPrintHttpResponse()
-			version, err := certificates.Find(name)
+			version, err := certificates.Find(client.WithoutOutput(), name)
 			if err != nil {
 				return err
 			}
-			apiclient.EnableCmdPrintHttpResponse()
-			_, err = certificates.Get(version)
+			_, err = certificates.Get(client, version)
 			return err
 		}
 
-		_, err = certificates.Get(id)
+		_, err = certificates.Get(client, id)
 		return err
 	},
 }
@@ -43,10 +43,11 @@ var ListCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		pageToken := utils.GetStringParam(cmd.Flag("pageToken"))
 		filter := utils.GetStringParam(cmd.Flag("filter"))
-		_, err = certificates.List(pageSize, pageToken, filter)
+		_, err = certificates.List(client, pageSize, pageToken, filter)
 		return
 	},
 }
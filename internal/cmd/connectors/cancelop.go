@@ -43,9 +43,10 @@ var CancelOperationCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		name := utils.GetStringParam(cmd.Flag("name"))
-		_, err = connections.CancelOperation(name)
+		_, err = connections.CancelOperation(client, name)
 		return
 	},
 }
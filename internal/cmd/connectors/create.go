@@ -47,6 +47,7 @@ var CreateCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		createSecret, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("create-secret")))
 		grantPermission, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("grant-permission")))
@@ -71,7 +72,7 @@ var CreateCmd = &cobra.Command{
 			}
 		}
 
-		_, err = connections.Create(name, content, serviceAccountName,
+		_, err = connections.Create(client, name, content, serviceAccountName,
 			serviceAccountProject, encryptionKey, grantPermission, createSecret, wait)
 
 		return err
@@ -48,13 +48,14 @@ var CrtCustomCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		name := utils.GetStringParam(cmd.Flag("name"))
 		description := utils.GetStringParam(cmd.Flag("description"))
 		displayName := utils.GetStringParam(cmd.Flag("display-name"))
 		connType := utils.GetStringParam(cmd.Flag("type"))
 
-		_, err = connections.CreateCustom(name, description, displayName, connType, labels)
+		_, err = connections.CreateCustom(client, name, description, displayName, connType, labels)
 
 		return err
 	},
@@ -45,6 +45,7 @@ var CrtCustomVerCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		name := utils.GetStringParam(cmd.Flag("name"))
 		id := utils.GetStringParam(cmd.Flag("id"))
@@ -58,7 +59,7 @@ var CrtCustomVerCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("unable to open file %w", err)
 		}
-		_, err = connections.CreateCustomVersion(name, id, content, serviceAccountName, serviceAccountProject)
+		_, err = connections.CreateCustomVersion(client, name, id, content, serviceAccountName, serviceAccountProject)
 		return err
 	},
 	Example: `Create a custom connection version: ` + GetExample(2),
@@ -45,6 +45,7 @@ var CrtEventSubCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		name := utils.GetStringParam(cmd.Flag("name"))
 		id := utils.GetStringParam(cmd.Flag("id"))
@@ -58,7 +59,7 @@ var CrtEventSubCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("unable to open file %w", err)
 		}
-		_, err = connections.CreateEventSubscription(name, id, contents)
+		_, err = connections.CreateEventSubscription(client, name, id, contents)
 		return err
 	},
 }
@@ -44,6 +44,7 @@ var CreateManagedZonesCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		description := utils.GetStringParam(cmd.Flag("description"))
 		targetProject := utils.GetStringParam(cmd.Flag("target-project"))
@@ -60,7 +61,7 @@ var CreateManagedZonesCmd = &cobra.Command{
 
 		payload := "{" + strings.Join(zone, ",") + "}"
 
-		_, err = connections.CreateZone(utils.GetStringParam(cmd.Flag("name")),
+		_, err = connections.CreateZone(client, utils.GetStringParam(cmd.Flag("name")),
 			[]byte(payload))
 		return err
 	},
@@ -43,9 +43,10 @@ var DelCustomCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		name := utils.GetStringParam(cmd.Flag("name"))
-		_, err = connections.DeleteCustom(name, force)
+		_, err = connections.DeleteCustom(client, name, force)
 		return err
 	},
 }
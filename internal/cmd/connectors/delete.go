@@ -47,9 +47,10 @@ var DelCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		name := utils.GetStringParam(cmd.Flag("name"))
-		_, err = connections.Delete(name)
+		_, err = connections.Delete(client, name)
 		return
 	},
 }
@@ -43,10 +43,11 @@ var DelEventSubCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		name := utils.GetStringParam(cmd.Flag("name"))
 		conn := utils.GetStringParam(cmd.Flag("conn"))
-		_, err = connections.DeleteEventSubscription(name, conn)
+		_, err = connections.DeleteEventSubscription(client, name, conn)
 		return err
 	},
 }
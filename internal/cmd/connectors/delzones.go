@@ -43,10 +43,11 @@ var DelManagedZonesCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		name := utils.GetStringParam(cmd.Flag("name"))
 
-		_, err = connections.DeleteZone(name)
+		_, err = connections.DeleteZone(client, name)
 		return
 	},
 }
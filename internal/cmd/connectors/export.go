@@ -43,12 +43,13 @@ var ExportCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		if err = apiclient.FolderExists(folder); err != nil {
 			return err
 		}
 
-		return connections.Export(folder)
+		return connections.Export(client, folder)
 	},
 }
 
@@ -48,6 +48,7 @@ var GetCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		name := utils.GetStringParam(cmd.Flag("name"))
 		minimal := utils.GetBasicInfo(cmd, "minimal")
@@ -55,7 +56,7 @@ var GetCmd = &cobra.Command{
 		if overrides {
 			minimal = true
 		}
-		_, err = connections.Get(name, view, minimal, overrides)
+		_, err = connections.Get(client, name, view, minimal, overrides)
 		return err
 	},
 }
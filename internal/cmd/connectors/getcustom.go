@@ -43,9 +43,10 @@ var GetCustomCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		name := utils.GetStringParam(cmd.Flag("name"))
-		_, err = connections.GetCustom(name)
+		_, err = connections.GetCustom(client, name)
 		return err
 	},
 }
@@ -43,10 +43,11 @@ var GetCustomVerCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		name := utils.GetStringParam(cmd.Flag("name"))
 		id := utils.GetStringParam(cmd.Flag("id"))
-		_, err = connections.GetCustomVersion(name, id, overrides)
+		_, err = connections.GetCustomVersion(client, name, id, overrides)
 		return err
 	},
 }
@@ -43,10 +43,11 @@ var GetEventSubCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		name := utils.GetStringParam(cmd.Flag("name"))
 		conn := utils.GetStringParam(cmd.Flag("conn"))
-		_, err = connections.GetEventSubscription(name, conn, overrides)
+		_, err = connections.GetEventSubscription(client, name, conn, overrides)
 		return err
 	},
 }
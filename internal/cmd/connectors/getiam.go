@@ -43,9 +43,10 @@ var GetIamCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		name := utils.GetStringParam(cmd.Flag("name"))
-		_, err = connections.GetIAM(name)
+		_, err = connections.GetIAM(client, name)
 		return
 	},
 }
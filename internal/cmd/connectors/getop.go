@@ -43,9 +43,10 @@ var GetOperationCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		name := utils.GetStringParam(cmd.Flag("name"))
-		_, err = connections.GetOperation(name)
+		_, err = connections.GetOperation(client, name)
 		return
 	},
 }
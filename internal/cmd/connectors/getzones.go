@@ -44,11 +44,12 @@ var GetManagedZonesCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		name := utils.GetStringParam(cmd.Flag("name"))
 		overrides, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("overrides")))
 
-		_, err = connections.GetZone(name, overrides)
+		_, err = connections.GetZone(client, name, overrides)
 		return err
 	},
 }
@@ -44,6 +44,7 @@ var ImportCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		createSecret, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("create-secret")))
 		wait, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("wait")))
@@ -52,7 +53,7 @@ var ImportCmd = &cobra.Command{
 			return err
 		}
 
-		return connections.Import(folder, createSecret, wait)
+		return connections.Import(client, folder, createSecret, wait)
 	},
 }
 
@@ -43,8 +43,20 @@ var ListCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
-		_, err = connections.List(pageSize,
+		all, limit, err := utils.GetListAllFlags(cmd)
+		if err != nil {
+			return err
+		}
+		if all {
+			_, err = connections.ListAll(client, pageSize,
+				utils.GetStringParam(cmd.Flag("filter")),
+				utils.GetStringParam(cmd.Flag("orderBy")), limit)
+			return err
+		}
+
+		_, err = connections.List(client, pageSize,
 			utils.GetStringParam(cmd.Flag("pageToken")),
 			utils.GetStringParam(cmd.Flag("filter")),
 			utils.GetStringParam(cmd.Flag("orderBy")))
@@ -65,4 +77,6 @@ func init() {
 		"", "Filter results")
 	ListCmd.Flags().StringVarP(&orderBy, "orderBy", "",
 		"", "The results would be returned in order")
+
+	utils.AddListAllFlags(ListCmd)
 }
@@ -43,8 +43,9 @@ var ListCustomCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
-		_, err = connections.ListCustom(pageSize,
+		_, err = connections.ListCustom(client, pageSize,
 			utils.GetStringParam(cmd.Flag("pageToken")),
 			utils.GetStringParam(cmd.Flag("filter")))
 		return err
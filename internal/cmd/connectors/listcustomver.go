@@ -43,9 +43,10 @@ var ListCustomVerCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		name := utils.GetStringParam(cmd.Flag("name"))
-		_, err = connections.ListCustomVersions(name, pageSize,
+		_, err = connections.ListCustomVersions(client, name, pageSize,
 			utils.GetStringParam(cmd.Flag("pageToken")))
 		return err
 	},
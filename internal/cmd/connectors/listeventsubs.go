@@ -43,9 +43,10 @@ var ListEventSubCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		name := utils.GetStringParam(cmd.Flag("name"))
-		_, err = connections.ListEventSubscriptions(name, pageSize,
+		_, err = connections.ListEventSubscriptions(client, name, pageSize,
 			utils.GetStringParam(cmd.Flag("pageToken")), utils.GetStringParam(cmd.Flag("filter")),
 			utils.GetStringParam(cmd.Flag("orderBy")))
 		return err
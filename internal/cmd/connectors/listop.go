@@ -43,8 +43,9 @@ var ListOperationsCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
-		_, err = connections.ListOperations(pageSize,
+		_, err = connections.ListOperations(client, pageSize,
 			utils.GetStringParam(cmd.Flag("pageToken")),
 			utils.GetStringParam(cmd.Flag("filter")),
 			utils.GetStringParam(cmd.Flag("orderBy")))
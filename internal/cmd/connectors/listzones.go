@@ -43,8 +43,9 @@ var ListManagedZonesCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
-		_, err = connections.ListZones(pageSize,
+		_, err = connections.ListZones(client, pageSize,
 			utils.GetStringParam(cmd.Flag("pageToken")),
 			utils.GetStringParam(cmd.Flag("filter")),
 			utils.GetStringParam(cmd.Flag("orderBy")))
@@ -44,6 +44,7 @@ var PatchCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		name := utils.GetStringParam(cmd.Flag("name"))
 
@@ -64,7 +65,7 @@ var PatchCmd = &cobra.Command{
 			}
 		}
 
-		_, err = connections.Patch(name, content, updateMask)
+		_, err = connections.Patch(client, name, content, updateMask)
 		return err
 	},
 }
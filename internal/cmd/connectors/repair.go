@@ -44,11 +44,12 @@ var RepairCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		name := utils.GetStringParam(cmd.Flag("name"))
 		wait, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("wait")))
 
-		err = connections.RepairEvent(name, wait)
+		err = connections.RepairEvent(client, name, wait)
 		return err
 	},
 }
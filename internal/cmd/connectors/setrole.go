@@ -41,12 +41,13 @@ var SetRoleCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		name := utils.GetStringParam(cmd.Flag("name"))
 		role := utils.GetStringParam(cmd.Flag("role"))
 		memberName := utils.GetStringParam(cmd.Flag("member"))
 		memberType := utils.GetStringParam(cmd.Flag("member-type"))
-		return connections.SetIAM(name, memberName, role, memberType)
+		return connections.SetIAM(client, name, memberName, role, memberType)
 	},
 }
 
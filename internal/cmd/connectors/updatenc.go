@@ -54,6 +54,7 @@ var UpdateNodeCountCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		nodeConfig := []string{}
 		var nodeCount string
@@ -74,7 +75,7 @@ var UpdateNodeCountCmd = &cobra.Command{
 		}
 
 		content = content + nodeCount + "}}"
-		_, err = connections.Patch(name, []byte(content), nodeConfig)
+		_, err = connections.Patch(client, name, []byte(content), nodeConfig)
 		return err
 	},
 }
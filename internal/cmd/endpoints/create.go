@@ -46,6 +46,7 @@ var CreateCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		name := utils.GetStringParam(cmd.Flag("name"))
 		serviceAttachment := utils.GetStringParam(cmd.Flag("service-attachment"))
@@ -59,7 +60,7 @@ var CreateCmd = &cobra.Command{
 			return fmt.Errorf("The service attachment does not match the required format")
 		}
 
-		_, err = connections.CreateEndpoint(name, serviceAttachment, description, wait)
+		_, err = connections.CreateEndpoint(client, name, serviceAttachment, description, wait)
 		return err
 	},
 }
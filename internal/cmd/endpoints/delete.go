@@ -43,10 +43,11 @@ var DelCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		name := utils.GetStringParam(cmd.Flag("name"))
 
-		_, err = connections.DeleteEndpoint(name)
+		_, err = connections.DeleteEndpoint(client, name)
 		return
 	},
 }
@@ -44,11 +44,12 @@ var GetCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		name := utils.GetStringParam(cmd.Flag("name"))
 		overrides, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("overrides")))
 
-		_, err = connections.GetEndpoint(name, overrides)
+		_, err = connections.GetEndpoint(client, name, overrides)
 		return err
 	},
 }
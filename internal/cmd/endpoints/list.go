@@ -43,11 +43,12 @@ var ListCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		pageToken := utils.GetStringParam(cmd.Flag("pageToken"))
 		filter := utils.GetStringParam(cmd.Flag("filter"))
 
-		_, err = connections.ListEndpoints(pageSize, pageToken, filter, "")
+		_, err = connections.ListEndpoints(client, pageSize, pageToken, filter, "")
 		return err
 	},
 }
@@ -41,7 +41,10 @@ import (
 var ApplyCmd = &cobra.Command{
 	Use:   "apply",
 	Short: "Apply configuration generated by scaffold to a region",
-	Long:  "Apply configuration generated by scaffold to a region",
+	Long: "Apply configuration generated by scaffold to a region. Every integration in the src folder " +
+		"is applied, sub-integrations are published before the integrations that call them. " +
+		"Overrides, tests and code for a sub-integration are read from <name>-overrides.json and " +
+		"folders named after the integration",
 	Args: func(cmd *cobra.Command, args []string) (err error) {
 		cmdProject := cmd.Flag("proj")
 		cmdRegion := cmd.Flag("reg")
@@ -63,6 +66,7 @@ var ApplyCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		var skaffoldConfigUri string
 
@@ -73,18 +77,18 @@ var ApplyCmd = &cobra.Command{
 		wait, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("wait")))
 		runTests, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("run-tests")))
 
-		apiclient.DisableCmdPrintHttpResponse()
+		client = client.WithoutOutput()
 
 		if cloudDeploy {
 			if err = storeCloudDeployVariables(); err != nil {
 				return err
 			}
 
-			skaffoldConfigUri, err = apiclient.GetCloudDeployGCSLocations(cloudDeployProjectId, cloudDeployLocation, pipeline, release)
+			skaffoldConfigUri, err = client.GetCloudDeployGCSLocations(cloudDeployProjectId, cloudDeployLocation, pipeline, release)
 			if err != nil {
 				return err
 			}
-			folder, err = apiclient.ExtractTgz(skaffoldConfigUri)
+			folder, err = client.ExtractTgz(skaffoldConfigUri)
 			if err != nil {
 				return err
 			}
@@ -98,6 +102,10 @@ var ApplyCmd = &cobra.Command{
 			return fmt.Errorf("problem with supplied path, %w", err)
 		}
 
+		if err = loadApplyVars(srcFolder); err != nil {
+			return err
+		}
+
 		testsFolder := path.Join(folder, "tests")
 		testsConfigFolder := path.Join(folder, "test-configs")
 		authconfigFolder := path.Join(folder, "authconfigs")
@@ -113,42 +121,42 @@ var ApplyCmd = &cobra.Command{
 		integrationFolder := path.Join(srcFolder, "src")
 
 		if !skipAuthconfigs {
-			if err = processAuthConfigs(authconfigFolder); err != nil {
+			if err = processAuthConfigs(client, authconfigFolder); err != nil {
 				return err
 			}
 		} else {
 			clilog.Info.Printf("Skipping applying authconfigs configuration\n")
 		}
 
-		if err = processEndpoints(endpointsFolder); err != nil {
+		if err = processEndpoints(client, endpointsFolder); err != nil {
 			return err
 		}
 
-		if err = processManagedZones(zonesFolder); err != nil {
+		if err = processManagedZones(client, zonesFolder); err != nil {
 			return err
 		}
 
 		if !skipConnectors {
-			if err = processCustomConnectors(customConnectorsFolder); err != nil {
+			if err = processCustomConnectors(client, customConnectorsFolder); err != nil {
 				return err
 			}
 
-			if err = processConnectors(connectorsFolder, grantPermission, createSecret, wait); err != nil {
+			if err = processConnectors(client, connectorsFolder, grantPermission, createSecret, wait); err != nil {
 				return err
 			}
 		} else {
 			clilog.Info.Printf("Skipping applying connector configuration\n")
 		}
 
-		if err = processSfdcInstances(sfdcinstancesFolder); err != nil {
+		if err = processSfdcInstances(client, sfdcinstancesFolder); err != nil {
 			return err
 		}
 
-		if err = processSfdcChannels(sfdcchannelsFolder); err != nil {
+		if err = processSfdcChannels(client, sfdcchannelsFolder); err != nil {
 			return err
 		}
 
-		if err = processIntegration(overridesFile, integrationFolder, testsFolder,
+		if err = processIntegration(client, overridesFile, integrationFolder, testsFolder,
 			configVarsFolder, testsConfigFolder, pipeline, userLabel, grantPermission, runTests); err != nil {
 			return err
 		}
@@ -159,11 +167,19 @@ var ApplyCmd = &cobra.Command{
 Apply scaffold configuration for a specific environment: ` + GetExample(10) + `
 Apply scaffold configuration and grant permissions to the service account: ` + GetExample(11) + `
 Apply scaffold configuration, but skip connectors: ` + GetExample(12) + `
-Apply scaffold configuration and run functional tests: ` + GetExample(18),
+Apply scaffold configuration and run functional tests: ` + GetExample(18) + `
+Apply scaffold configuration and fail on invalid overrides: ` + GetExample(20) + `
+Apply scaffold configuration and replace ${VAR} references in the configuration: ` + GetExample(21),
 }
 
 var serviceAccountName, serviceAccountProject, encryptionKey, pipeline string
 var release, outputGCSPath, cloudDeployProjectId, cloudDeployLocation string
+var strictOverrides, interpolate bool
+var mainIntegrationName string
+var setVars []string
+
+// applyVars holds variables from the vars file and --set flags, --set takes precedence
+var applyVars map[string]string
 
 func init() {
 	var userLabel string
@@ -197,10 +213,105 @@ func init() {
 		false, "Skip applying testcases; default is false")
 	ApplyCmd.Flags().BoolVarP(&useUnderscore, "use-underscore", "",
 		false, "Use underscore as a file splitter; default is __")
+	ApplyCmd.Flags().BoolVarP(&strictOverrides, "strict-overrides", "",
+		false, "Validate overrides before applying and fail if any override does not match the integration; default is false")
+	ApplyCmd.Flags().StringArrayVarP(&setVars, "set", "",
+		[]string{}, "Set a variable used for ${VAR} interpolation, --set key1=value1 --set key2=value2")
+	ApplyCmd.Flags().BoolVarP(&interpolate, "interpolate", "",
+		false, "Interpolate ${VAR} references from environment variables in the configuration files. "+
+			"Enabled automatically when --set is used or a vars.json or vars.<env>.json file is found; default is false")
+	ApplyCmd.Flags().StringVarP(&mainIntegrationName, "main-integration", "",
+		"", "Integration the shared overrides, tests and code folders are applied to, when the src folder "+
+			"has integrations that are not called by the others")
 	ApplyCmd.Flags().BoolVarP(&runTests, "run-tests", "",
 		false, "Runs unit tests from config files in test-configs folder. See ./samples/test-config.json for an example config")
 }
 
+// loadApplyVars reads variables from vars.json in the folder, then from vars.<env>.json which
+// overrides them, and finally from the --set flags
+func loadApplyVars(baseFolder string) error {
+	applyVars = make(map[string]string)
+
+	varsFiles := []string{path.Join(baseFolder, "vars.json")}
+	if env != "" {
+		varsFiles = append(varsFiles, path.Join(baseFolder, "vars."+env+".json"))
+	}
+
+	for _, varsFile := range varsFiles {
+		if _, err := os.Stat(varsFile); err != nil {
+			continue
+		}
+		clilog.Info.Printf("Found variables file %s\n", varsFile)
+		varsBytes, err := utils.ReadFile(varsFile)
+		if err != nil {
+			return err
+		}
+		fileVars := map[string]interface{}{}
+		if err = json.Unmarshal(varsBytes, &fileVars); err != nil {
+			return fmt.Errorf("unable to parse variables file %s: %w", varsFile, err)
+		}
+		for key, value := range fileVars {
+			switch v := value.(type) {
+			case string:
+				applyVars[key] = v
+			case map[string]interface{}, []interface{}:
+				b, err := json.Marshal(v)
+				if err != nil {
+					return err
+				}
+				applyVars[key] = string(b)
+			default:
+				applyVars[key] = fmt.Sprint(v)
+			}
+		}
+		interpolate = true
+	}
+
+	for _, setVar := range setVars {
+		key, value, found := strings.Cut(setVar, "=")
+		if !found || key == "" {
+			return fmt.Errorf("invalid variable %s, must be in the format key=value", setVar)
+		}
+		applyVars[key] = value
+		interpolate = true
+	}
+	return nil
+}
+
+// lookupApplyVar resolves a variable from --set, the vars file, environment variables and finally
+// the project, region and environment used by the command
+func lookupApplyVar(name string) (string, bool) {
+	if value, ok := applyVars[name]; ok {
+		return value, true
+	}
+	if value, ok := os.LookupEnv(name); ok {
+		return value, true
+	}
+	switch name {
+	case "PROJECT_ID":
+		return apiclient.GetProjectID(), apiclient.GetProjectID() != ""
+	case "REGION":
+		return apiclient.GetRegion(), apiclient.GetRegion() != ""
+	case "ENV":
+		return env, env != ""
+	}
+	return "", false
+}
+
+// readApplyFile reads a configuration file and interpolates ${VAR} references when enabled.
+// Only the overrides, config variables, connectors, authconfigs, endpoints, zones and sfdc
+// files are interpolated, integrations and tests can contain code that uses ${...}
+func readApplyFile(filePath string) ([]byte, error) {
+	content, err := utils.ReadFile(filePath)
+	if err != nil || !interpolate {
+		return content, err
+	}
+	if content, err = utils.Interpolate(content, lookupApplyVar); err != nil {
+		return nil, fmt.Errorf("%s: %w", filePath, err)
+	}
+	return content, nil
+}
+
 func getFilenameWithoutExtension(filname string) string {
 	return strings.TrimSuffix(filname, filepath.Ext(filname))
 }
@@ -234,7 +345,7 @@ func getServiceAttachment(respBody []byte) (sa string, err error) {
 	return jsonMap["serviceAttachment"], nil
 }
 
-func processAuthConfigs(authconfigFolder string) (err error) {
+func processAuthConfigs(client *apiclient.Client, authconfigFolder string) (err error) {
 	var stat fs.FileInfo
 	rJSONFiles := regexp.MustCompile(`(\S*)\.json`)
 
@@ -248,15 +359,15 @@ func processAuthConfigs(authconfigFolder string) (err error) {
 				authConfigFile := filepath.Base(path)
 				if rJSONFiles.MatchString(authConfigFile) {
 					clilog.Info.Printf("Found configuration for authconfig: %s\n", authConfigFile)
-					version, _ := authconfigs.Find(getFilenameWithoutExtension(authConfigFile), "")
+					version, _ := authconfigs.Find(client, getFilenameWithoutExtension(authConfigFile), "")
 					// create the authconfig only if the version was not found
 					if version == "" {
-						authConfigBytes, err := utils.ReadFile(path)
+						authConfigBytes, err := readApplyFile(path)
 						if err != nil {
 							return err
 						}
 						clilog.Info.Printf("Creating authconfig: %s\n", authConfigFile)
-						if _, err = authconfigs.Create(authConfigBytes); err != nil {
+						if _, err = authconfigs.Create(client, authConfigBytes); err != nil {
 							return err
 						}
 					} else {
@@ -273,7 +384,7 @@ func processAuthConfigs(authconfigFolder string) (err error) {
 	return nil
 }
 
-func processEndpoints(endpointsFolder string) (err error) {
+func processEndpoints(client *apiclient.Client, endpointsFolder string) (err error) {
 	var stat fs.FileInfo
 	rJSONFiles := regexp.MustCompile(`(\S*)\.json`)
 
@@ -288,9 +399,9 @@ func processEndpoints(endpointsFolder string) (err error) {
 				if rJSONFiles.MatchString(endpointFile) {
 					clilog.Info.Printf("Found configuration for endpoint attachment: %s\n", endpointFile)
 				}
-				if !connections.FindEndpoint(getFilenameWithoutExtension(endpointFile)) {
+				if !connections.FindEndpoint(client, getFilenameWithoutExtension(endpointFile)) {
 					// the endpoint does not exist, try to create it
-					endpointBytes, err := utils.ReadFile(path)
+					endpointBytes, err := readApplyFile(path)
 					if err != nil {
 						return err
 					}
@@ -298,7 +409,7 @@ func processEndpoints(endpointsFolder string) (err error) {
 					if err != nil {
 						return err
 					}
-					if _, err = connections.CreateEndpoint(getFilenameWithoutExtension(endpointFile),
+					if _, err = connections.CreateEndpoint(client, getFilenameWithoutExtension(endpointFile),
 						serviceAccountName, "", false); err != nil {
 						return err
 					}
@@ -315,7 +426,7 @@ func processEndpoints(endpointsFolder string) (err error) {
 	return nil
 }
 
-func processManagedZones(zonesFolder string) (err error) {
+func processManagedZones(client *apiclient.Client, zonesFolder string) (err error) {
 	var stat fs.FileInfo
 	rJSONFiles := regexp.MustCompile(`(\S*)\.json`)
 
@@ -331,13 +442,13 @@ func processManagedZones(zonesFolder string) (err error) {
 				if rJSONFiles.MatchString(zoneFile) {
 					clilog.Info.Printf("Found configuration for managed zone: %s\n", zoneFile)
 				}
-				if _, err = connections.GetZone(getFilenameWithoutExtension(zoneFile), true); err != nil {
+				if _, err = connections.GetZone(client, getFilenameWithoutExtension(zoneFile), true); err != nil {
 					// the managed zone does not exist, try to create it
-					zoneBytes, err := utils.ReadFile(path)
+					zoneBytes, err := readApplyFile(path)
 					if err != nil {
 						return err
 					}
-					if _, err = connections.CreateZone(getFilenameWithoutExtension(zoneFile),
+					if _, err = connections.CreateZone(client, getFilenameWithoutExtension(zoneFile),
 						zoneBytes); err != nil {
 						return err
 					}
@@ -354,7 +465,7 @@ func processManagedZones(zonesFolder string) (err error) {
 	return nil
 }
 
-func processConnectors(connectorsFolder string, grantPermission bool, createSecret bool, wait bool) (err error) {
+func processConnectors(client *apiclient.Client, connectorsFolder string, grantPermission bool, createSecret bool, wait bool) (err error) {
 	var stat fs.FileInfo
 	rJSONFiles := regexp.MustCompile(`(\S*)\.json`)
 
@@ -368,16 +479,16 @@ func processConnectors(connectorsFolder string, grantPermission bool, createSecr
 				connectionFile := filepath.Base(path)
 				if rJSONFiles.MatchString(connectionFile) {
 					clilog.Info.Printf("Found configuration for connection: %s\n", connectionFile)
-					_, err = connections.Get(getFilenameWithoutExtension(connectionFile), "", true, false)
+					_, err = connections.Get(client, getFilenameWithoutExtension(connectionFile), "", true, false)
 					// create the connection only if the connection is not found
 					if err != nil {
-						connectionBytes, err := utils.ReadFile(path)
+						connectionBytes, err := readApplyFile(path)
 						if err != nil {
 							return err
 						}
 						clilog.Info.Printf("Creating connector: %s\n", connectionFile)
 
-						if _, err = connections.Create(getFilenameWithoutExtension(connectionFile),
+						if _, err = connections.Create(client, getFilenameWithoutExtension(connectionFile),
 							connectionBytes,
 							serviceAccountName,
 							serviceAccountProject,
@@ -401,7 +512,7 @@ func processConnectors(connectorsFolder string, grantPermission bool, createSecr
 	return nil
 }
 
-func processCustomConnectors(customConnectorsFolder string) (err error) {
+func processCustomConnectors(client *apiclient.Client, customConnectorsFolder string) (err error) {
 	var stat fs.FileInfo
 	var fileSplitter string
 	rJSONFiles := regexp.MustCompile(`(\S*)\.json`)
@@ -425,15 +536,15 @@ func processCustomConnectors(customConnectorsFolder string) (err error) {
 					// the file format is name-version.json
 					if len(customConnectionDetails) == 2 {
 						clilog.Info.Printf("Found configuration for custom connection: %v\n", customConnectionFile)
-						contents, err := utils.ReadFile(path)
+						contents, err := readApplyFile(path)
 						if err != nil {
 							return err
 						}
 						clilog.Info.Printf("Creating custom connector: %s\n", customConnectionFile)
-						if _, err := connections.GetCustomVersion(customConnectionDetails[0],
+						if _, err := connections.GetCustomVersion(client, customConnectionDetails[0],
 							customConnectionDetails[1], false); err != nil {
 							// didn't find the custom connector, create it
-							if err = connections.CreateCustomWithVersion(customConnectionDetails[0],
+							if err = connections.CreateCustomWithVersion(client, customConnectionDetails[0],
 								customConnectionDetails[1], contents, serviceAccountName, serviceAccountProject); err != nil {
 								return err
 							}
@@ -449,7 +560,7 @@ func processCustomConnectors(customConnectorsFolder string) (err error) {
 	return nil
 }
 
-func processSfdcInstances(sfdcinstancesFolder string) (err error) {
+func processSfdcInstances(client *apiclient.Client, sfdcinstancesFolder string) (err error) {
 	var stat fs.FileInfo
 	rJSONFiles := regexp.MustCompile(`(\S*)\.json`)
 
@@ -463,15 +574,15 @@ func processSfdcInstances(sfdcinstancesFolder string) (err error) {
 				instanceFile := filepath.Base(path)
 				if rJSONFiles.MatchString(instanceFile) {
 					clilog.Info.Printf("Found configuration for sfdc instance: %s\n", instanceFile)
-					_, err = sfdc.GetInstance(getFilenameWithoutExtension(instanceFile), true)
+					_, err = sfdc.GetInstance(client, getFilenameWithoutExtension(instanceFile), true)
 					// create the instance only if the sfdc instance is not found
 					if err != nil {
-						instanceBytes, err := utils.ReadFile(path)
+						instanceBytes, err := readApplyFile(path)
 						if err != nil {
 							return err
 						}
 						clilog.Info.Printf("Creating sfdc instance: %s\n", instanceFile)
-						_, err = sfdc.CreateInstanceFromContent(instanceBytes)
+						_, err = sfdc.CreateInstanceFromContent(client, instanceBytes)
 						if err != nil {
 							return nil
 						}
@@ -489,7 +600,7 @@ func processSfdcInstances(sfdcinstancesFolder string) (err error) {
 	return nil
 }
 
-func processSfdcChannels(sfdcchannelsFolder string) (err error) {
+func processSfdcChannels(client *apiclient.Client, sfdcchannelsFolder string) (err error) {
 	var stat fs.FileInfo
 	var fileSplitter string
 	rJSONFiles := regexp.MustCompile(`(\S*)\.json`)
@@ -514,15 +625,15 @@ func processSfdcChannels(sfdcchannelsFolder string) (err error) {
 					fileName := getFilenameWithoutExtension(channelFile) //instanceName_channelName or instanceName_channelName_e
 					sfdcNames := strings.Split(fileName, fileSplitter)
 					name := fileName[len(sfdcNames[0])+len(fileSplitter):] //channelName or channelName_e
-					version, _, err := sfdc.FindChannel(name, sfdcNames[0])
+					version, _, err := sfdc.FindChannel(client, name, sfdcNames[0])
 					// create the instance only if the sfdc channel is not found
 					if err != nil {
-						channelBytes, err := utils.ReadFile(path)
+						channelBytes, err := readApplyFile(path)
 						if err != nil {
 							return err
 						}
 						clilog.Info.Printf("Creating sfdc channel: %s\n", channelFile)
-						_, err = sfdc.CreateChannelFromContent(version, channelBytes)
+						_, err = sfdc.CreateChannelFromContent(client, version, channelBytes)
 						if err != nil {
 							return nil
 						}
@@ -540,30 +651,15 @@ func processSfdcChannels(sfdcchannelsFolder string) (err error) {
 	return nil
 }
 
-func processIntegration(overridesFile string, integrationFolder string, testsFolder string,
+func processIntegration(client *apiclient.Client, overridesFile string, integrationFolder string, testsFolder string,
 	configVarsFolder string, testConfigFolder string, pipeline string, userLabel string, grantPermission bool,
 	runTests bool,
 ) (err error) {
 	rJSONFiles := regexp.MustCompile(`(\S*)\.json$`)
 
 	var integrationNames []string
-	var overridesBytes []byte
-
-	javascriptFolder := path.Join(integrationFolder, "javascript")
-	jsonnetFolder := path.Join(integrationFolder, "datatransformer")
-
-	if _, err = os.Stat(overridesFile); err == nil {
-		overridesBytes, err = utils.ReadFile(overridesFile)
-		if err != nil {
-			return err
-		}
-	}
-
-	if len(overridesBytes) > 0 {
-		clilog.Info.Printf("Found overrides file %s\n", overridesFile)
-	}
 
-	// get the integration file
+	// get the integration files
 	_ = filepath.Walk(integrationFolder, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
@@ -578,77 +674,189 @@ func processIntegration(overridesFile string, integrationFolder string, testsFol
 		return nil
 	})
 
-	if len(integrationNames) > 0 {
-		// get only the first file
-		integrationBytes, err := utils.ReadFile(path.Join(integrationFolder, integrationNames[0]))
+	if len(integrationNames) == 0 {
+		clilog.Warning.Printf("No integration files were found\n")
+		return nil
+	}
+
+	integrationsBytes := make(map[string][]byte)
+	dependencies := make(map[string][]string)
+	for _, integrationName := range integrationNames {
+		name := getFilenameWithoutExtension(integrationName)
+		// the integration is not interpolated, code such as JavaScript template literals uses ${...}
+		integrationBytes, err := utils.ReadFile(path.Join(integrationFolder, integrationName))
 		if err != nil {
 			return err
 		}
-		// check for code files
-		codeMap, err := processCodeFolders(javascriptFolder, jsonnetFolder)
-		if err != nil {
+		if dependencies[name], err = integrations.GetSubIntegrations(integrationBytes); err != nil {
+			return fmt.Errorf("unable to parse integration %s: %w", integrationName, err)
+		}
+		integrationsBytes[name] = integrationBytes
+	}
+
+	// callees are published before the integrations that call them
+	sortedNames := integrations.SortByDependencies(dependencies)
+
+	mainIntegration := getMainIntegration(dependencies)
+	if mainIntegrationName != "" {
+		if _, ok := integrationsBytes[mainIntegrationName]; !ok {
+			return fmt.Errorf("main integration %s was not found in %s", mainIntegrationName, integrationFolder)
+		}
+		mainIntegration = mainIntegrationName
+	}
+	if len(sortedNames) > 1 {
+		clilog.Info.Printf("Applying integrations in the order: %s\n", strings.Join(sortedNames, ", "))
+		if mainIntegration == "" {
+			return fmt.Errorf("unable to determine the main integration of %s, the shared overrides, tests "+
+				"and code folders apply to it. Use --main-integration to name it", strings.Join(sortedNames, ", "))
+		}
+	}
+
+	for _, name := range sortedNames {
+		if err = applyIntegration(client, name, integrationsBytes[name], name == mainIntegration, overridesFile,
+			integrationFolder, testsFolder, configVarsFolder, testConfigFolder, userLabel,
+			grantPermission, runTests); err != nil {
 			return err
 		}
+	}
+
+	if pipeline != "" {
+		err = client.WriteResultsFile(outputGCSPath, "SUCCEEDED")
+	}
+	return err
+}
+
+// applyIntegration creates, tests and publishes a single integration. Overrides, tests and code
+// are read from folders named after the integration, the shared folders are only used for the
+// main integration
+func applyIntegration(client *apiclient.Client, name string, integrationBytes []byte, main bool, overridesFile string,
+	integrationFolder string, testsFolder string, configVarsFolder string, testConfigFolder string,
+	userLabel string, grantPermission bool, runTests bool,
+) (err error) {
+	var overridesBytes []byte
 
-		if len(codeMap) > 0 {
-			integrationBytes, err = integrations.SetCode(integrationBytes, codeMap)
+	integrationOverridesFile := path.Join(path.Dir(overridesFile), name+"-overrides.json")
+	if _, err = os.Stat(integrationOverridesFile); err == nil {
+		overridesFile = integrationOverridesFile
+	} else if !main {
+		overridesFile = ""
+	}
+
+	if overridesFile != "" {
+		if _, err = os.Stat(overridesFile); err == nil {
+			overridesBytes, err = readApplyFile(overridesFile)
 			if err != nil {
 				return err
 			}
 		}
+	}
+
+	if len(overridesBytes) > 0 {
+		clilog.Info.Printf("Found overrides file %s\n", overridesFile)
+	}
 
-		clilog.Info.Printf("Create integration %s\n", getFilenameWithoutExtension(integrationNames[0]))
-		respBody, err := integrations.CreateVersion(getFilenameWithoutExtension(integrationNames[0]),
-			integrationBytes, overridesBytes, "", userLabel, grantPermission, false)
+	// check for code files
+	codeMap, err := processCodeFolders(getIntegrationFolder(path.Join(integrationFolder, "javascript"), name, main),
+		getIntegrationFolder(path.Join(integrationFolder, "datatransformer"), name, main))
+	if err != nil {
+		return err
+	}
+
+	if len(codeMap) > 0 {
+		integrationBytes, err = integrations.SetCode(integrationBytes, codeMap)
 		if err != nil {
 			return err
 		}
-		version, err := getVersion(respBody)
-		if err != nil {
+	}
+
+	if strictOverrides && len(overridesBytes) > 0 {
+		if err = validateOverrides(integrationBytes, overridesBytes); err != nil {
 			return err
 		}
+	}
 
-		// create  test cases for integration
-		if !skipTestCases {
-			if err = processTestCases(testsFolder, getFilenameWithoutExtension(integrationNames[0]), version); err != nil {
-				return err
-			}
-		} else {
-			clilog.Info.Printf("Skipping applying testcases configuration\n")
-		}
+	clilog.Info.Printf("Create integration %s\n", name)
+	respBody, err := integrations.CreateVersion(client, name, integrationBytes, overridesBytes, "", userLabel,
+		grantPermission, false)
+	if err != nil {
+		return err
+	}
+	version, err := getVersion(respBody)
+	if err != nil {
+		return err
+	}
 
-		// publish the integration
-		clilog.Info.Printf("Publish integration %s with version %s\n",
-			getFilenameWithoutExtension(integrationNames[0]), version)
-		// read any config variables
-		configVarsFile := path.Join(configVarsFolder, getFilenameWithoutExtension(integrationNames[0])+"-config.json")
-		var configVarBytes []byte
-		if _, err = os.Stat(configVarsFile); err == nil {
-			configVarBytes, err = utils.ReadFile(configVarsFile)
-			if err != nil {
+	// create  test cases for integration
+	if !skipTestCases {
+		if integrationTestsFolder := getIntegrationFolder(testsFolder, name, main); integrationTestsFolder != "" {
+			if err = processTestCases(client, integrationTestsFolder, name, version); err != nil {
 				return err
 			}
 		}
-		_, err = integrations.Publish(getFilenameWithoutExtension(integrationNames[0]), version, configVarBytes)
+	} else {
+		clilog.Info.Printf("Skipping applying testcases configuration\n")
+	}
+
+	// publish the integration
+	clilog.Info.Printf("Publish integration %s with version %s\n", name, version)
+	// read any config variables
+	configVarsFile := path.Join(configVarsFolder, name+"-config.json")
+	var configVarBytes []byte
+	if _, err = os.Stat(configVarsFile); err == nil {
+		configVarBytes, err = readApplyFile(configVarsFile)
 		if err != nil {
 			return err
 		}
+	}
+	_, err = integrations.Publish(client, name, version, configVarBytes)
+	if err != nil {
+		return err
+	}
 
-		// Execute test cases
-		if runTests {
-			err = executeAllTestCases(testConfigFolder, getFilenameWithoutExtension(integrationNames[0]), version)
-			if err != nil {
+	// Execute test cases
+	if runTests {
+		if integrationTestConfigFolder := getIntegrationFolder(testConfigFolder, name, main); integrationTestConfigFolder != "" {
+			if err = executeAllTestCases(client, integrationTestConfigFolder, name, version); err != nil {
 				return err
 			}
 		}
+	}
+	return nil
+}
 
-		if pipeline != "" {
-			err = apiclient.WriteResultsFile(outputGCSPath, "SUCCEEDED")
+// getMainIntegration returns the only integration that is not called by another integration
+func getMainIntegration(dependencies map[string][]string) string {
+	called := make(map[string]bool)
+	for name, subIntegrations := range dependencies {
+		for _, subIntegration := range subIntegrations {
+			// an integration that calls itself can still be the main integration
+			if subIntegration != name {
+				called[subIntegration] = true
+			}
 		}
-		return err
 	}
-	clilog.Warning.Printf("No integration files were found\n")
-	return nil
+	mainIntegration := ""
+	for name := range dependencies {
+		if !called[name] {
+			if mainIntegration != "" {
+				return ""
+			}
+			mainIntegration = name
+		}
+	}
+	return mainIntegration
+}
+
+// getIntegrationFolder returns the sub-folder named after the integration if it exists,
+// otherwise the shared folder for the main integration
+func getIntegrationFolder(folder string, name string, main bool) string {
+	if stat, err := os.Stat(path.Join(folder, name)); err == nil && stat.IsDir() {
+		return path.Join(folder, name)
+	}
+	if main {
+		return folder
+	}
+	return ""
 }
 
 func processCodeFolders(javascriptFolder string, jsonnetFolder string) (codeMap map[string]map[string]string, err error) {
@@ -663,6 +871,9 @@ func processCodeFolders(javascriptFolder string, jsonnetFolder string) (codeMap
 		if err != nil {
 			return err
 		}
+		if info.IsDir() && path != javascriptFolder {
+			return filepath.SkipDir
+		}
 		if !info.IsDir() {
 			javascriptFile := filepath.Base(path)
 			if rJavaScriptFiles.MatchString(javascriptFile) {
@@ -687,6 +898,9 @@ func processCodeFolders(javascriptFolder string, jsonnetFolder string) (codeMap
 		if err != nil {
 			return err
 		}
+		if info.IsDir() && path != jsonnetFolder {
+			return filepath.SkipDir
+		}
 		if !info.IsDir() {
 			jsonnetFile := filepath.Base(path)
 			if rJsonnetFiles.MatchString(jsonnetFile) {
@@ -710,7 +924,7 @@ func processCodeFolders(javascriptFolder string, jsonnetFolder string) (codeMap
 	return codeMap, nil
 }
 
-func processTestCases(testsFolder string, integrationName string, version string) (err error) {
+func processTestCases(client *apiclient.Client, testsFolder string, integrationName string, version string) (err error) {
 	rJSONFiles := regexp.MustCompile(`(\S*)\.json`)
 
 	var testCaseFiles []string
@@ -719,6 +933,9 @@ func processTestCases(testsFolder string, integrationName string, version string
 		if err != nil {
 			return err
 		}
+		if info.IsDir() && path != testsFolder {
+			return filepath.SkipDir
+		}
 		if !info.IsDir() {
 			testCaseFile := filepath.Base(path)
 			if rJSONFiles.MatchString(testCaseFile) {
@@ -732,7 +949,7 @@ func processTestCases(testsFolder string, integrationName string, version string
 	if len(testCaseFiles) > 0 {
 
 		// delete any old test cases
-		err = integrations.DeleteAllTestCases(integrationName, version)
+		err = integrations.DeleteAllTestCases(client, integrationName, version)
 		if err != nil {
 			return err
 		}
@@ -742,7 +959,7 @@ func processTestCases(testsFolder string, integrationName string, version string
 			if err != nil {
 				return err
 			}
-			_, err = integrations.CreateTestCase(integrationName, version, string(testCaseBytes))
+			_, err = integrations.CreateTestCase(client, integrationName, version, string(testCaseBytes))
 			if err != nil {
 				return err
 			}
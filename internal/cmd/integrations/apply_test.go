@@ -0,0 +1,145 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"encoding/json"
+	"internal/client/clienttest"
+	"internal/client/integrations"
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+// javascriptIntegration has a JavaScriptTask with template literals that look like ${VAR} references
+const javascriptIntegration = `{
+	"triggerConfigs": [
+		{"label": "API Trigger", "triggerType": "API", "triggerNumber": "1", "triggerId": "api_trigger/js_API_1",
+			"startTasks": [{"taskId": "1"}]}
+	],
+	"taskConfigs": [
+		{"task": "JavaScriptTask", "taskId": "1", "parameters": {"script": {"key": "script", "value": {
+			"stringValue": "function executeScript(event) { event.setParameter('greeting', ` + "`" + `hello ${name} from ${HOME}` + "`" + `); }"
+		}}}}
+	]
+}`
+
+func TestApplyJavaScriptTask(t *testing.T) {
+	_, client := clienttest.FakeSetup(t)
+
+	folder := t.TempDir()
+	writeTestFile(t, path.Join(folder, "src", "js.json"), javascriptIntegration)
+	writeTestFile(t, path.Join(folder, "config-variables", "js-config.json"), `{"`+"`CONFIG_topic`"+`": "${TOPIC}"}`)
+
+	defer func(v []string, i bool) { setVars, interpolate = v, i }(setVars, interpolate)
+	setVars = []string{"TOPIC=orders"}
+	if err := loadApplyVars(folder); err != nil {
+		t.Fatalf("loadApplyVars failed: %v", err)
+	}
+
+	if err := processIntegration(client, path.Join(folder, "overrides", "overrides.json"), path.Join(folder, "src"),
+		path.Join(folder, "tests"), path.Join(folder, "config-variables"), path.Join(folder, "test-configs"),
+		"", "", false, false); err != nil {
+		t.Fatalf("processIntegration failed: %v", err)
+	}
+
+	respBody, err := integrations.ListVersions(client.WithoutOutput(), "js", -1, "", "", "", false, false, false)
+	if err != nil {
+		t.Fatalf("ListVersions failed: %v", err)
+	}
+	versions := struct {
+		IntegrationVersions []struct {
+			TaskConfigs []struct {
+				Parameters map[string]struct {
+					Value struct {
+						StringValue string `json:"stringValue"`
+					} `json:"value"`
+				} `json:"parameters"`
+			} `json:"taskConfigs"`
+		} `json:"integrationVersions"`
+	}{}
+	if err = json.Unmarshal(respBody, &versions); err != nil {
+		t.Fatalf("unable to parse versions: %v", err)
+	}
+	if len(versions.IntegrationVersions) != 1 || len(versions.IntegrationVersions[0].TaskConfigs) != 1 {
+		t.Fatalf("expected one version with one task, got %s", respBody)
+	}
+	script := versions.IntegrationVersions[0].TaskConfigs[0].Parameters["script"].Value.StringValue
+	if !strings.Contains(script, "`hello ${name} from ${HOME}`") {
+		t.Errorf("expected the template literal to be applied unchanged, got %s", script)
+	}
+}
+
+func TestApplyMainIntegration(t *testing.T) {
+	_, client := clienttest.FakeSetup(t)
+
+	// two integrations that do not call each other
+	folder := t.TempDir()
+	writeTestFile(t, path.Join(folder, "src", "orders.json"), javascriptIntegration)
+	writeTestFile(t, path.Join(folder, "src", "invoices.json"), javascriptIntegration)
+
+	apply := func() error {
+		return processIntegration(client, path.Join(folder, "overrides", "overrides.json"), path.Join(folder, "src"),
+			path.Join(folder, "tests"), path.Join(folder, "config-variables"), path.Join(folder, "test-configs"),
+			"", "", false, false)
+	}
+
+	defer func(name string) { mainIntegrationName = name }(mainIntegrationName)
+	mainIntegrationName = ""
+	if err := apply(); err == nil {
+		t.Errorf("expected an error when the main integration is unknown")
+	}
+	mainIntegrationName = "payments"
+	if err := apply(); err == nil {
+		t.Errorf("expected an error for a main integration that is not in the folder")
+	}
+	mainIntegrationName = "orders"
+	if err := apply(); err != nil {
+		t.Errorf("processIntegration with the main integration failed: %v", err)
+	}
+}
+
+func TestLoadApplyVars(t *testing.T) {
+	folder := t.TempDir()
+	writeTestFile(t, path.Join(folder, "vars.json"), `{"TOPIC": "orders", "BUCKET": "shared"}`)
+	writeTestFile(t, path.Join(folder, "vars.dev.json"), `{"TOPIC": "dev-orders"}`)
+
+	defer func(e string, v []string, i bool) { env, setVars, interpolate = e, v, i }(env, setVars, interpolate)
+	env, setVars = "dev", []string{"BUCKET=override"}
+	if err := loadApplyVars(folder); err != nil {
+		t.Fatalf("loadApplyVars failed: %v", err)
+	}
+	if applyVars["TOPIC"] != "dev-orders" || applyVars["BUCKET"] != "override" {
+		t.Errorf("unexpected variables %v", applyVars)
+	}
+
+	env, setVars = "prod", nil
+	if err := loadApplyVars(folder); err != nil {
+		t.Fatalf("loadApplyVars failed: %v", err)
+	}
+	if applyVars["TOPIC"] != "orders" || applyVars["BUCKET"] != "shared" {
+		t.Errorf("expected vars.json to be used without vars.prod.json, got %v", applyVars)
+	}
+}
+
+func writeTestFile(t *testing.T, name string, content string) {
+	if err := os.MkdirAll(path.Dir(name), 0o755); err != nil {
+		t.Fatalf("unable to create %s: %v", path.Dir(name), err)
+	}
+	if err := os.WriteFile(name, []byte(content), 0o600); err != nil {
+		t.Fatalf("unable to write %s: %v", name, err)
+	}
+}
@@ -43,11 +43,12 @@ var CancelExecCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		name := utils.GetStringParam(cmd.Flag("name"))
 		executionID := utils.GetStringParam(cmd.Flag("execution-id"))
 		cancelReason := utils.GetStringParam(cmd.Flag("cancel-reason"))
-		_, err = integrations.Cancel(name, executionID, cancelReason)
+		_, err = integrations.Cancel(client, name, executionID, cancelReason)
 		return err
 	},
 }
@@ -44,10 +44,11 @@ var CleanCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		name := utils.GetStringParam(cmd.Flag("name"))
 		reportOnly, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("report")))
-		return integrations.Clean(name, reportOnly, keepList)
+		return integrations.Clean(client, name, reportOnly, keepList)
 	},
 }
 
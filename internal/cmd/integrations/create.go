@@ -61,6 +61,7 @@ var CreateCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		var overridesContent, contents []byte
 
@@ -106,17 +107,17 @@ var CreateCmd = &cobra.Command{
 			}
 		}
 
+		createClient := client
 		if publish {
-			apiclient.DisableCmdPrintHttpResponse()
+			createClient = client.WithoutOutput()
 		}
-		respBody, err := integrations.CreateVersion(name, content, overridesContent, snapshot,
+		respBody, err := integrations.CreateVersion(createClient, name, content, overridesContent, snapshot,
 			userLabel, grantPermission, basic)
 		if err != nil {
 			return err
 		}
 
 		if publish {
-			apiclient.EnableCmdPrintHttpResponse()
 			var integrationMap map[string]interface{}
 			err = json.Unmarshal(respBody, &integrationMap)
 			if err != nil {
@@ -124,7 +125,7 @@ var CreateCmd = &cobra.Command{
 			}
 			version := integrationMap["name"].(string)[strings.LastIndex(integrationMap["name"].(string), "/")+1:]
 			if version != "" {
-				_, err = integrations.Publish(name, version, contents)
+				_, err = integrations.Publish(client, name, version, contents)
 			} else {
 				return fmt.Errorf("unable to extract version id from integration")
 			}
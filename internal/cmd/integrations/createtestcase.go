@@ -50,6 +50,7 @@ var CrtTestCaseCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		version := utils.GetStringParam(cmd.Flag("ver"))
 		name := utils.GetStringParam(cmd.Flag("name"))
@@ -67,11 +68,11 @@ var CrtTestCaseCmd = &cobra.Command{
 		}
 
 		if version != "" {
-			_, err = integrations.CreateTestCase(name, version, string(content))
+			_, err = integrations.CreateTestCase(client, name, version, string(content))
 		} else if userLabel != "" {
-			_, err = integrations.CreateTestCaseByUserLabel(name, userLabel, string(content))
+			_, err = integrations.CreateTestCaseByUserLabel(client, name, userLabel, string(content))
 		} else if snapshot != "" {
-			_, err = integrations.CreateTestCaseBySnapshot(name, snapshot, string(content))
+			_, err = integrations.CreateTestCaseBySnapshot(client, name, snapshot, string(content))
 		}
 
 		return err
@@ -59,6 +59,7 @@ var DelVerCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		version := utils.GetStringParam(cmd.Flag("ver"))
 		userLabel := utils.GetStringParam(cmd.Flag("user-label"))
@@ -66,11 +67,11 @@ var DelVerCmd = &cobra.Command{
 		name := utils.GetStringParam(cmd.Flag("name"))
 
 		if version != "" {
-			_, err = integrations.DeleteVersion(name, version)
+			_, err = integrations.DeleteVersion(client, name, version)
 		} else if snapshot != "" {
-			_, err = integrations.DeleteBySnapshot(name, snapshot)
+			_, err = integrations.DeleteBySnapshot(client, name, snapshot)
 		} else {
-			_, err = integrations.DeleteByUserlabel(name, userLabel)
+			_, err = integrations.DeleteByUserlabel(client, name, userLabel)
 		}
 		return err
 	},
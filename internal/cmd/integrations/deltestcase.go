@@ -37,10 +37,11 @@ var DelTestCaseCmd = &cobra.Command{
 		return apiclient.SetProjectID(cmdProject.Value.String())
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		client := apiclient.DefaultClient()
 		version := cmd.Flag("ver").Value.String()
 		name := cmd.Flag("name").Value.String()
 		testCaseID := cmd.Flag("test-case-id").Value.String()
-		_, err = integrations.DeleteTestCase(name, version, testCaseID)
+		_, err = integrations.DeleteTestCase(client, name, version, testCaseID)
 		return err
 	},
 }
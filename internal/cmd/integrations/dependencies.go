@@ -0,0 +1,147 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"internal/apiclient"
+	"internal/client/integrations"
+	"internal/clilog"
+	"internal/cmd/utils"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// DependenciesCmd to print the sub-integrations called by an integration flow
+var DependenciesCmd = &cobra.Command{
+	Use:   "dependencies",
+	Short: "Print the sub-integrations called by an integration flow",
+	Long:  "Print the tree of sub-integrations called by an integration flow through Call Integration tasks",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		cmdProject := utils.GetStringParam(cmd.Flag("proj"))
+		cmdRegion := utils.GetStringParam(cmd.Flag("reg"))
+		version := utils.GetStringParam(cmd.Flag("ver"))
+		userLabel := utils.GetStringParam(cmd.Flag("user-label"))
+		snapshot := utils.GetStringParam(cmd.Flag("snapshot"))
+		latest, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("latest")))
+
+		if err = apiclient.SetRegion(cmdRegion); err != nil {
+			return err
+		} else if err = validate(version, userLabel, snapshot, latest); err != nil {
+			return err
+		}
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			clilog.Debug.Printf("%s: %s\n", f.Name, f.Value)
+		})
+		return apiclient.SetProjectID(cmdProject)
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
+
+		version := utils.GetStringParam(cmd.Flag("ver"))
+		userLabel := utils.GetStringParam(cmd.Flag("user-label"))
+		snapshot := utils.GetStringParam(cmd.Flag("snapshot"))
+		name := utils.GetStringParam(cmd.Flag("name"))
+
+		if ignoreLatest(version, userLabel, snapshot) {
+			if version, err = getLatestVersion(client, name); err != nil {
+				return err
+			}
+		} else if version == "" {
+			if version, err = integrations.GetVersion(client, name, userLabel, snapshot); err != nil {
+				return err
+			}
+		}
+
+		client = client.WithoutOutput()
+
+		tree := strings.Builder{}
+		tree.WriteString(name + " (" + version + ")\n")
+		resolved := map[string]string{name: version}
+		if err = writeDependencyTree(client, &tree, name, version, userLabel, "",
+			map[string]bool{name: true}, resolved); err != nil {
+			return err
+		}
+		clilog.HTTPResponse.Print(tree.String())
+		return nil
+	},
+	Example: `Print the sub-integrations called by the latest version of an integration: ` + GetExample(23),
+}
+
+func init() {
+	var name, userLabel, snapshot, version string
+	var latest bool
+
+	DependenciesCmd.Flags().StringVarP(&name, "name", "n",
+		"", "Integration flow name")
+	DependenciesCmd.Flags().StringVarP(&version, "ver", "v",
+		"", "Integration flow version")
+	DependenciesCmd.Flags().StringVarP(&userLabel, "user-label", "u",
+		"", "Integration flow user label")
+	DependenciesCmd.Flags().StringVarP(&snapshot, "snapshot", "s",
+		"", "Integration flow snapshot number")
+	DependenciesCmd.Flags().BoolVarP(&latest, "latest", "",
+		true, "Uses the version with the highest snapshot number in SNAPSHOT state. If none found, selects the highest snapshot in DRAFT state; default is true")
+
+	_ = DependenciesCmd.MarkFlagRequired("name")
+}
+
+// writeDependencyTree writes the sub-integrations of an integration version to the tree.
+// path holds the integrations of the current branch to detect circular calls, resolved caches
+// the version used for each sub-integration
+func writeDependencyTree(client *apiclient.Client, tree *strings.Builder, name string, version string, userLabel string,
+	prefix string, path map[string]bool, resolved map[string]string,
+) error {
+	integrationBody, err := integrations.Get(client, name, version, false, true, false)
+	if err != nil {
+		return err
+	}
+	subIntegrations, err := integrations.GetSubIntegrations(integrationBody)
+	if err != nil {
+		return err
+	}
+
+	for i, subIntegration := range subIntegrations {
+		branch, childPrefix := "├── ", prefix+"│   "
+		if i == len(subIntegrations)-1 {
+			branch, childPrefix = "└── ", prefix+"    "
+		}
+
+		if path[subIntegration] {
+			tree.WriteString(prefix + branch + subIntegration + " (circular)\n")
+			continue
+		}
+
+		subVersion, ok := resolved[subIntegration]
+		if !ok {
+			if subVersion, err = getSubIntegrationVersion(client, subIntegration, userLabel); err != nil {
+				return err
+			}
+			resolved[subIntegration] = subVersion
+		}
+		tree.WriteString(prefix + branch + subIntegration + " (" + subVersion + ")\n")
+
+		path[subIntegration] = true
+		if err = writeDependencyTree(client, tree, subIntegration, subVersion, userLabel,
+			childPrefix, path, resolved); err != nil {
+			return err
+		}
+		delete(path, subIntegration)
+	}
+	return nil
+}
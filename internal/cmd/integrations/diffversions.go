@@ -0,0 +1,209 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"internal/apiclient"
+	"internal/client/integrations"
+	"internal/clilog"
+	"internal/cmd/utils"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// DiffVerCmd to compare two integration flow versions
+var DiffVerCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare two integration flow versions",
+	Long: "Compare two integration flow versions, snapshots, user labels or local files and report the tasks, " +
+		"triggers, edges, conditions and variables that were added, removed or changed",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			clilog.Debug.Printf("%s: %s\n", f.Name, f.Value)
+		})
+
+		if format := utils.GetStringParam(cmd.Flag("format")); format != "text" && format != "json" {
+			return errors.New("format must be one of text or json")
+		}
+
+		remote := false
+		for _, side := range []string{"from", "to"} {
+			file := utils.GetStringParam(cmd.Flag(side + "-file"))
+			selectors := 0
+			for _, selector := range []string{"-ver", "-snapshot", "-user-label"} {
+				if utils.GetStringParam(cmd.Flag(side+selector)) != "" {
+					selectors++
+				}
+			}
+			if (file == "" && selectors != 1) || (file != "" && selectors != 0) {
+				return fmt.Errorf("must pass oneOf --%s-file, --%s-ver, --%s-snapshot or --%s-user-label",
+					side, side, side, side)
+			}
+			remote = remote || file == ""
+		}
+		if !remote {
+			return nil
+		}
+
+		if utils.GetStringParam(cmd.Flag("name")) == "" {
+			return errors.New("name must be set to compare integration flow versions")
+		}
+		if err = apiclient.SetRegion(utils.GetStringParam(cmd.Flag("reg"))); err != nil {
+			return err
+		}
+		return apiclient.SetProjectID(utils.GetStringParam(cmd.Flag("proj")))
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
+
+		name := utils.GetStringParam(cmd.Flag("name"))
+		format := utils.GetStringParam(cmd.Flag("format"))
+
+		client = client.WithoutOutput()
+
+		fromContent, err := getDiffContent(client, cmd, name, "from")
+		if err != nil {
+			return err
+		}
+		toContent, err := getDiffContent(client, cmd, name, "to")
+		if err != nil {
+			return err
+		}
+
+		changes, err := integrations.Diff(fromContent, toContent)
+		if err != nil {
+			return err
+		}
+
+		if format == "json" {
+			if changes == nil {
+				changes = []integrations.DiffChange{}
+			}
+			output, err := json.MarshalIndent(changes, "", "\t")
+			if err != nil {
+				return err
+			}
+			clilog.HTTPResponse.Println(string(output))
+			return nil
+		}
+		clilog.HTTPResponse.Print(formatDiff(changes))
+		return nil
+	},
+	Example: `Compare two snapshots of an integration flow: ` + GetExample(28) + `
+Compare a local file with the version being promoted: ` + GetExample(29),
+}
+
+func init() {
+	var name, format string
+	var fromFile, fromVersion, fromSnapshot, fromUserLabel string
+	var toFile, toVersion, toSnapshot, toUserLabel string
+
+	DiffVerCmd.Flags().StringVarP(&name, "name", "n",
+		"", "Integration flow name")
+	DiffVerCmd.Flags().StringVarP(&fromFile, "from-file", "",
+		"", "Integration flow JSON file path to compare from")
+	DiffVerCmd.Flags().StringVarP(&fromVersion, "from-ver", "",
+		"", "Integration flow version to compare from")
+	DiffVerCmd.Flags().StringVarP(&fromSnapshot, "from-snapshot", "",
+		"", "Integration flow snapshot number to compare from")
+	DiffVerCmd.Flags().StringVarP(&fromUserLabel, "from-user-label", "",
+		"", "Integration flow user label to compare from")
+	DiffVerCmd.Flags().StringVarP(&toFile, "to-file", "",
+		"", "Integration flow JSON file path to compare to")
+	DiffVerCmd.Flags().StringVarP(&toVersion, "to-ver", "",
+		"", "Integration flow version to compare to")
+	DiffVerCmd.Flags().StringVarP(&toSnapshot, "to-snapshot", "",
+		"", "Integration flow snapshot number to compare to")
+	DiffVerCmd.Flags().StringVarP(&toUserLabel, "to-user-label", "",
+		"", "Integration flow user label to compare to")
+	DiffVerCmd.Flags().StringVarP(&format, "format", "",
+		"text", "Output format; text or json")
+}
+
+// getDiffContent reads one side of the comparison from a file or an integration flow version
+func getDiffContent(client *apiclient.Client, cmd *cobra.Command, name string, side string) ([]byte, error) {
+	if file := utils.GetStringParam(cmd.Flag(side + "-file")); file != "" {
+		return utils.ReadFile(file)
+	}
+	if version := utils.GetStringParam(cmd.Flag(side + "-ver")); version != "" {
+		return integrations.Get(client, name, version, false, true, false)
+	}
+	if snapshot := utils.GetStringParam(cmd.Flag(side + "-snapshot")); snapshot != "" {
+		return integrations.GetBySnapshot(client, name, snapshot, false, true, false)
+	}
+	return integrations.GetByUserlabel(client, name, utils.GetStringParam(cmd.Flag(side+"-user-label")), false, true, false)
+}
+
+// formatDiff formats the changes as one line per change
+func formatDiff(changes []integrations.DiffChange) string {
+	const maxValueLength = 120
+
+	formatValue := func(v interface{}) string {
+		if v == nil {
+			return "<none>"
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		if len(b) > maxValueLength {
+			return string(b[:maxValueLength]) + "..."
+		}
+		return string(b)
+	}
+
+	output := strings.Builder{}
+	for _, change := range changes {
+		var symbol string
+		switch change.Kind {
+		case integrations.DiffAdded:
+			symbol = "+"
+		case integrations.DiffRemoved:
+			symbol = "-"
+		default:
+			symbol = "~"
+		}
+
+		subject := change.Element
+		if change.Id != "" {
+			subject += " " + change.Id
+		}
+		if change.Name != "" {
+			subject += " (" + change.Name + ")"
+		}
+
+		switch {
+		case change.Field != "":
+			output.WriteString(fmt.Sprintf("%s %s: %s %s: %s -> %s\n", symbol, subject, change.Field,
+				change.Kind, formatValue(change.From), formatValue(change.To)))
+		case change.From != nil || change.To != nil:
+			condition := change.From
+			if condition == nil {
+				condition = change.To
+			}
+			output.WriteString(fmt.Sprintf("%s %s %s, condition %s\n", symbol, subject, change.Kind, formatValue(condition)))
+		default:
+			output.WriteString(fmt.Sprintf("%s %s %s\n", symbol, subject, change.Kind))
+		}
+	}
+	output.WriteString(fmt.Sprintf("%d change(s)\n", len(changes)))
+	return output.String()
+}
@@ -52,6 +52,7 @@ var DownloadVerCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		version := utils.GetStringParam(cmd.Flag("ver"))
 		userLabel := utils.GetStringParam(cmd.Flag("user-label"))
@@ -61,15 +62,14 @@ var DownloadVerCmd = &cobra.Command{
 		latest := ignoreLatest(version, userLabel, snapshot)
 
 		if latest {
-			apiclient.DisableCmdPrintHttpResponse()
 			// list integration versions, order by state=SNAPSHOT, page size = 1 and return basic info
-			respBody, err := integrations.ListVersions(name, 1, "", "state=SNAPSHOT",
+			respBody, err := integrations.ListVersions(client.WithoutOutput(), name, 1, "", "state=SNAPSHOT",
 				"snapshot_number", false, false, true)
 			if err != nil {
 				return fmt.Errorf("unable to list versions: %v", err)
 			}
 			if string(respBody) == "{}" {
-				if respBody, err = integrations.ListVersions(name, 1, "", "state=DRAFT",
+				if respBody, err = integrations.ListVersions(client.WithoutOutput(), name, 1, "", "state=DRAFT",
 					"snapshot_number", false, false, true); err != nil {
 					return fmt.Errorf("unable to list versions: %v", err)
 				}
@@ -78,14 +78,13 @@ var DownloadVerCmd = &cobra.Command{
 			if err != nil {
 				return err
 			}
-			apiclient.EnableCmdPrintHttpResponse()
-			_, err = integrations.Download(name, version)
+			_, err = integrations.Download(client, name, version)
 		} else if version != "" {
-			_, err = integrations.Download(name, version)
+			_, err = integrations.Download(client, name, version)
 		} else if userLabel != "" {
-			_, err = integrations.DownloadUserLabel(name, userLabel)
+			_, err = integrations.DownloadUserLabel(client, name, userLabel)
 		} else if snapshot != "" {
-			_, err = integrations.DownloadSnapshot(name, snapshot)
+			_, err = integrations.DownloadSnapshot(client, name, snapshot)
 		}
 		return err
 	},
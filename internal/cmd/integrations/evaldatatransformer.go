@@ -0,0 +1,120 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"fmt"
+	"internal/apiclient"
+	"internal/client/integrations"
+	"internal/clilog"
+	"internal/cmd/utils"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// EvalDataTransformerCmd to evaluate a Data Transformer template locally
+var EvalDataTransformerCmd = &cobra.Command{
+	Use:   "eval",
+	Short: "Evaluate a Data Transformer template locally",
+	Long: "Evaluate a Data Transformer Jsonnet template locally against a file of input variables " +
+		"and print the output variables",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			clilog.Debug.Printf("%s: %s\n", f.Name, f.Value)
+		})
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
+		var inputs []byte
+		templateFile := utils.GetStringParam(cmd.Flag("file"))
+		inputsFile := utils.GetStringParam(cmd.Flag("inputs"))
+		expectFile := utils.GetStringParam(cmd.Flag("expect"))
+		update, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("update")))
+
+		template, err := utils.ReadFile(templateFile)
+		if err != nil {
+			return err
+		}
+		if inputsFile != "" {
+			if inputs, err = utils.ReadFile(inputsFile); err != nil {
+				return err
+			}
+		}
+
+		output, err := integrations.EvaluateDataTransformer(templateFile, template, inputs)
+		if err != nil {
+			return err
+		}
+		if output, err = apiclient.PrettifyJson(output); err != nil {
+			return err
+		}
+		clilog.HTTPResponse.Println(string(output))
+
+		return assertExpected(output, expectFile, update)
+	},
+	Example: `Evaluate a Data Transformer template with input variables: ` + GetExample(30) + `
+Evaluate a Data Transformer template and compare the output variables with a snapshot: ` + GetExample(31),
+}
+
+func init() {
+	var templateFile, inputsFile, expectFile string
+	var update bool
+
+	EvalDataTransformerCmd.Flags().StringVarP(&templateFile, "file", "f",
+		"", "Data Transformer Jsonnet template file path")
+	EvalDataTransformerCmd.Flags().StringVarP(&inputsFile, "inputs", "i",
+		"", "JSON file with the input variables, either a map of names and values or a test configuration")
+	EvalDataTransformerCmd.Flags().StringVarP(&expectFile, "expect", "",
+		"", "JSON file with the expected output variables. The file is created if it does not exist")
+	EvalDataTransformerCmd.Flags().BoolVarP(&update, "update", "",
+		false, "Overwrite the expect file with the output variables; default is false")
+
+	_ = EvalDataTransformerCmd.MarkFlagRequired("file")
+}
+
+// assertExpected compares the output variables with the expect file. The expect file
+// is written when it does not exist or when update is set
+func assertExpected(output []byte, expectFile string, update bool) error {
+	if expectFile == "" {
+		return nil
+	}
+
+	if _, err := os.Stat(expectFile); os.IsNotExist(err) || update {
+		clilog.Info.Printf("Storing output variables in %s\n", expectFile)
+		return apiclient.WriteByteArrayToFile(expectFile, false, output)
+	}
+
+	expected, err := utils.ReadFile(expectFile)
+	if err != nil {
+		return err
+	}
+	mismatches, err := integrations.CompareVariables(output, expected)
+	if err != nil {
+		return err
+	}
+	for _, mismatch := range mismatches {
+		clilog.Error.Println(mismatch)
+	}
+	if len(mismatches) > 0 {
+		return fmt.Errorf("output variables do not match %s", expectFile)
+	}
+	clilog.Info.Printf("Output variables match %s\n", expectFile)
+	return nil
+}
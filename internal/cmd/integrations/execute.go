@@ -50,6 +50,7 @@ var ExecuteCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		var content []byte
 		name := utils.GetStringParam(cmd.Flag("name"))
@@ -72,7 +73,7 @@ var ExecuteCmd = &cobra.Command{
 				triggerID, doNotPropagateError, requestID))
 		}
 
-		_, err = integrations.Execute(name, content)
+		_, err = integrations.Execute(client, name, content)
 		return err
 	},
 }
@@ -72,6 +72,7 @@ var ExecuteTestCaseCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		version := utils.GetStringParam(cmd.Flag("ver"))
 		userLabel := utils.GetStringParam(cmd.Flag("user-label"))
@@ -82,14 +83,12 @@ var ExecuteTestCaseCmd = &cobra.Command{
 		inputFolder := utils.GetStringParam(cmd.Flag("input-folder"))
 
 		if version == "" {
-			version, err = integrations.GetVersion(name, userLabel, snapshot)
+			version, err = integrations.GetVersion(client, name, userLabel, snapshot)
 			if err != nil {
 				return err
 			}
 		}
 
-		apiclient.EnableCmdPrintHttpResponse()
-
 		if inputFile != "" {
 			if _, err := os.Stat(inputFile); os.IsNotExist(err) {
 				return err
@@ -100,7 +99,7 @@ var ExecuteTestCaseCmd = &cobra.Command{
 				return err
 			}
 			clilog.Info.Printf("Executing test cases from file %s for integration: %s\n", inputFile, name)
-			testCaseResp, err := integrations.ExecuteTestCase(name, version, testCaseID, string(content))
+			testCaseResp, err := integrations.ExecuteTestCase(client, name, version, testCaseID, string(content))
 			if err != nil {
 				return err
 			}
@@ -110,7 +109,7 @@ var ExecuteTestCaseCmd = &cobra.Command{
 			}
 		}
 		if inputFolder != "" {
-			return executeAllTestCases(inputFolder, name, version)
+			return executeAllTestCases(client, inputFolder, name, version)
 		}
 		return err
 	},
@@ -43,21 +43,22 @@ var ExportCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		if err = apiclient.FolderExists(folder); err != nil {
 			return err
 		}
 
-		apiclient.DisableCmdPrintHttpResponse()
+		client = client.WithoutOutput()
 		clilog.Warning.Println("API calls to integration.googleapis.com have a quota of 480 per min. " +
 			"Running this tool against large list of entities can exhaust the quota. Throttling to 360 per min.")
 
 		// check if connections argument was passed, use default value if not
 		numConnections, _ := cmd.Flags().GetInt("connections")
 		if numConnections > 0 {
-			return integrations.ExportConcurrent(folder, numConnections)
+			return integrations.ExportConcurrent(client, folder, numConnections)
 		}
-		return integrations.Export(folder)
+		return integrations.Export(client, folder)
 	},
 }
 
@@ -44,6 +44,7 @@ var ExportVerCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		name := utils.GetStringParam(cmd.Flag("name"))
 		allVersions, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("all-versions")))
@@ -51,12 +52,11 @@ var ExportVerCmd = &cobra.Command{
 			return err
 		}
 
-		apiclient.SetExportToFile(folder)
-		apiclient.DisableCmdPrintHttpResponse()
+		client = client.WithExportToFile(folder).WithoutOutput()
 		clilog.Warning.Println("API calls to integration.googleapis.com have a quota of 480 per min. " +
 			"Running this tool against large list of entities can exhaust the quota. Throttling to 360 per min.")
 
-		_, err = integrations.ListVersions(name, -1, "", "", "", allVersions, true, false)
+		_, err = integrations.ListVersions(client, name, -1, "", "", "", allVersions, true, false)
 		return err
 	},
 }
@@ -48,6 +48,7 @@ var GetTestCaseCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		var integrationBody []byte
 
@@ -57,20 +58,20 @@ var GetTestCaseCmd = &cobra.Command{
 		userLabel := utils.GetStringParam(cmd.Flag("user-label"))
 		snapshot := utils.GetStringParam(cmd.Flag("snapshot"))
 
-		apiclient.DisableCmdPrintHttpResponse()
+		getClient := client.WithoutOutput()
 
 		latest := ignoreLatest(version, userLabel, snapshot)
 		if latest {
-			if version, err = getLatestVersion(name); err != nil {
+			if version, err = getLatestVersion(getClient, name); err != nil {
 				return err
 			}
 		} else {
 			if version != "" {
-				integrationBody, err = integrations.Get(name, version, true, false, false)
+				integrationBody, err = integrations.Get(getClient, name, version, true, false, false)
 			} else if snapshot != "" {
-				integrationBody, err = integrations.GetBySnapshot(name, snapshot, true, false, false)
+				integrationBody, err = integrations.GetBySnapshot(getClient, name, snapshot, true, false, false)
 			} else if userLabel != "" {
-				integrationBody, err = integrations.GetByUserlabel(name, userLabel, true, false, false)
+				integrationBody, err = integrations.GetByUserlabel(getClient, name, userLabel, true, false, false)
 			} else {
 				return errors.New("latest version not found. Must pass oneOf version, snapshot or user-label or fix the integration name")
 			}
@@ -80,9 +81,7 @@ var GetTestCaseCmd = &cobra.Command{
 			}
 		}
 
-		apiclient.EnableCmdPrintHttpResponse()
-
-		_, err = integrations.GetTestCase(name, version, testCaseID)
+		_, err = integrations.GetTestCase(client, name, version, testCaseID)
 		return err
 	},
 }
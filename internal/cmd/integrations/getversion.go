@@ -64,6 +64,7 @@ var GetVerCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		var integrationBody, respBody []byte
 		var basic bool
@@ -77,23 +78,24 @@ var GetVerCmd = &cobra.Command{
 		userLabel := utils.GetStringParam(cmd.Flag("user-label"))
 		snapshot := utils.GetStringParam(cmd.Flag("snapshot"))
 
+		getClient := client
 		if configVar {
-			apiclient.DisableCmdPrintHttpResponse()
+			getClient = client.WithoutOutput()
 		}
 
 		latest := ignoreLatest(version, userLabel, snapshot)
 		if latest {
-			if version, err = getLatestVersion(name); err != nil {
+			if version, err = getLatestVersion(getClient, name); err != nil {
 				return err
 			}
 		}
 
 		if version != "" {
-			integrationBody, err = integrations.Get(name, version, basic, minimal, overrides)
+			integrationBody, err = integrations.Get(getClient, name, version, basic, minimal, overrides)
 		} else if snapshot != "" {
-			integrationBody, err = integrations.GetBySnapshot(name, snapshot, basic, minimal, overrides)
+			integrationBody, err = integrations.GetBySnapshot(getClient, name, snapshot, basic, minimal, overrides)
 		} else if userLabel != "" {
-			integrationBody, err = integrations.GetByUserlabel(name, userLabel, basic, minimal, overrides)
+			integrationBody, err = integrations.GetByUserlabel(getClient, name, userLabel, basic, minimal, overrides)
 		} else {
 			return errors.New("latest version not found. Must pass oneOf version, snapshot or user-label or fix the integration name")
 		}
@@ -102,14 +104,12 @@ var GetVerCmd = &cobra.Command{
 			return err
 		}
 		if configVar {
-			apiclient.EnableCmdPrintHttpResponse()
-			apiclient.ClientPrintHttpResponse.Set(true)
 			respBody, err = integrations.GetConfigVariables(integrationBody)
 			if err != nil {
 				return err
 			}
 			if respBody != nil {
-				apiclient.PrettyPrint(respBody)
+				client.PrettyPrint(respBody)
 			}
 			return nil
 		}
@@ -43,13 +43,14 @@ var ImportCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		const maxConnections = 4
 		if err = apiclient.FolderExists(folder); err != nil {
 			return err
 		}
-		apiclient.DisableCmdPrintHttpResponse()
-		return integrations.Import(folder, maxConnections)
+		client = client.WithoutOutput()
+		return integrations.Import(client, folder, maxConnections)
 	},
 }
 
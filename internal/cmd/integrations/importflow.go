@@ -43,6 +43,7 @@ var ImportflowCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		const maxConnections = 4
 		name := utils.GetStringParam(cmd.Flag("name"))
@@ -50,8 +51,8 @@ var ImportflowCmd = &cobra.Command{
 		if err = apiclient.FolderExists(folder); err != nil {
 			return err
 		}
-		apiclient.DisableCmdPrintHttpResponse()
-		return integrations.ImportFlow(name, folder, maxConnections)
+		client = client.WithoutOutput()
+		return integrations.ImportFlow(client, name, folder, maxConnections)
 	},
 }
 
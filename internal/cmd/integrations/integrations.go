@@ -57,6 +57,21 @@ var examples = []string{
 	`integrationcli integrations versions unpublish -n $name --default-token`,
 	`integrationcli integrations versions unpublish -n $name -u $userLabel --default-token`,
 	`integrationcli integrations apply -f . --env=dev --tests-folder=./test-configs --default-token`,
+	`integrationcli integrations overrides validate -f src/$name.json -o dev/overrides/overrides.json`,
+	`integrationcli integrations apply -f . --env=dev --strict-overrides=true --default-token`,
+	`integrationcli integrations apply -f . --env=dev --set BACKEND_URL=https://dev.example.com --default-token`,
+	`integrationcli integrations scaffold -n $name -s $snapshot -f . --env=dev --recursive=true --default-token`,
+	`integrationcli integrations dependencies -n $name --default-token`,
+	`integrationcli integrations lint -f src/$name.json`,
+	`integrationcli integrations lint --folder src --format sarif --fail-on warning`,
+	`integrationcli integrations render-graph -f src/$name.json`,
+	`integrationcli integrations render-graph -n $name -s $snapshot --format dot --default-token`,
+	`integrationcli integrations versions diff -n $name --from-snapshot 1 --to-snapshot 2 --default-token`,
+	`integrationcli integrations versions diff -n $name --from-user-label prod --to-file src/$name.json --default-token`,
+	`integrationcli integrations datatransformer eval -f src/datatransformer/datatransformer_1.jsonnet -i dev/test-configs/test1.json`,
+	`integrationcli integrations datatransformer eval -f src/datatransformer/datatransformer_1.jsonnet -i inputs.json --expect expected.json`,
+	`integrationcli integrations javascript run -f src/javascript/javascript_1.js -i dev/test-configs/test1.json`,
+	`integrationcli integrations javascript run -f src/javascript/javascript_1.js -i inputs.json --assert assertions.json`,
 }
 
 func init() {
@@ -81,20 +96,25 @@ func init() {
 	Cmd.AddCommand(ScaffoldCmd)
 	Cmd.AddCommand(ApplyCmd)
 	Cmd.AddCommand(TestCasesCmd)
+	Cmd.AddCommand(OverridesCmd)
+	Cmd.AddCommand(DependenciesCmd)
+	Cmd.AddCommand(LintCmd)
+	Cmd.AddCommand(RenderGraphCmd)
+	Cmd.AddCommand(DataTransformerCmd)
+	Cmd.AddCommand(JavaScriptCmd)
 }
 
 func GetExample(i int) string {
 	return examples[i]
 }
 
-func getLatestVersion(name string) (version string, err error) {
+func getLatestVersion(client *apiclient.Client, name string) (version string, err error) {
 	var listBody []byte
 
-	apiclient.DisableCmdPrintHttpResponse()
-	defer apiclient.EnableCmdPrintHttpResponse()
+	client = client.WithoutOutput()
 
 	// list integration versions, order by state=ACTIVE, page size = 1 and return basic info
-	if listBody, err = integrations.ListVersions(name, 1, "", "state=ACTIVE",
+	if listBody, err = integrations.ListVersions(client, name, 1, "", "state=ACTIVE",
 		"snapshot_number", false, false, true); err != nil {
 		return "", fmt.Errorf("unable to list versions: %v", err)
 	}
@@ -104,7 +124,7 @@ func getLatestVersion(name string) (version string, err error) {
 		}
 	} else {
 		// list integration versions, order by state=SNAPSHOT, page size = 1 and return basic info
-		if listBody, err = integrations.ListVersions(name, 1, "", "state=SNAPSHOT",
+		if listBody, err = integrations.ListVersions(client, name, 1, "", "state=SNAPSHOT",
 			"snapshot_number", false, false, true); err != nil {
 			return "", fmt.Errorf("unable to list versions: %v", err)
 		}
@@ -113,7 +133,7 @@ func getLatestVersion(name string) (version string, err error) {
 				return "", err
 			}
 		} else {
-			if listBody, err = integrations.ListVersions(name, 1, "", "state=DRAFT",
+			if listBody, err = integrations.ListVersions(client, name, 1, "", "state=DRAFT",
 				"snapshot_number", false, false, true); err != nil {
 				return "", fmt.Errorf("unable to list versions: %v", err)
 			}
@@ -127,7 +147,7 @@ func getLatestVersion(name string) (version string, err error) {
 	return version, nil
 }
 
-func executeAllTestCases(inputFolder string, name string, version string) (err error) {
+func executeAllTestCases(client *apiclient.Client, inputFolder string, name string, version string) (err error) {
 
 	if stat, err := os.Stat(inputFolder); stat == nil || (err != nil && !stat.IsDir()) {
 		return fmt.Errorf("supplied path is not a folder: %v", err)
@@ -140,6 +160,9 @@ func executeAllTestCases(inputFolder string, name string, version string) (err e
 		if err != nil {
 			return err
 		}
+		if info.IsDir() && path != inputFolder {
+			return filepath.SkipDir
+		}
 		if !info.IsDir() {
 			inputFileName := filepath.Base(path)
 			if rJSONFiles.MatchString(inputFileName) {
@@ -157,14 +180,12 @@ func executeAllTestCases(inputFolder string, name string, version string) (err e
 				return err
 			}
 			testDisplayName := strings.TrimSuffix(filepath.Base(inputFileName), filepath.Ext(filepath.Base(inputFileName)))
-			apiclient.ClientPrintHttpResponse.Set(false)
-			testCaseID, err := integrations.FindTestCase(name, version, testDisplayName, "")
-			apiclient.ClientPrintHttpResponse.Set(true)
+			testCaseID, err := integrations.FindTestCase(client.WithoutOutput(), name, version, testDisplayName, "")
 			if err != nil {
 				return err
 			}
 			clilog.Info.Printf("Executing test cases from file %s for integration: %s\n", inputFileName, name)
-			testCaseResp, err := integrations.ExecuteTestCase(name, version, testCaseID, string(content))
+			testCaseResp, err := integrations.ExecuteTestCase(client, name, version, testCaseID, string(content))
 			if err != nil {
 				return err
 			}
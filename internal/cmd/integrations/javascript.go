@@ -0,0 +1,30 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// JavaScriptCmd to work with JavaScript task scripts
+var JavaScriptCmd = &cobra.Command{
+	Use:   "javascript",
+	Short: "Work with JavaScript task scripts",
+	Long:  "Work with JavaScript task scripts extracted by scaffold",
+}
+
+func init() {
+	JavaScriptCmd.AddCommand(RunJavaScriptCmd)
+}
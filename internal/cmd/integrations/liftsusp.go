@@ -43,9 +43,10 @@ var LiftSuspCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		name := utils.GetStringParam(cmd.Flag("name"))
-		_, err = integrations.Lift(name, execution, suspension, result)
+		_, err = integrations.Lift(client, name, execution, suspension, result)
 		return err
 	},
 }
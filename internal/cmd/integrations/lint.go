@@ -0,0 +1,272 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"internal/apiclient"
+	"internal/client/integrations"
+	"internal/clilog"
+	"internal/cmd/utils"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// LintCmd to check integration flows offline
+var LintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Check integration flows for common problems",
+	Long: "Check integration flows for common problems without calling any APIs. Reports unreachable tasks, " +
+		"missing tasks, triggers without tasks, undeclared variables, duplicate task numbers, unused error " +
+		"catchers and unused config variables",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			clilog.Debug.Printf("%s: %s\n", f.Name, f.Value)
+		})
+		lintFile := utils.GetStringParam(cmd.Flag("file"))
+		lintFolder := utils.GetStringParam(cmd.Flag("folder"))
+		if (lintFile == "" && lintFolder == "") || (lintFile != "" && lintFolder != "") {
+			return errors.New("one of --file or --folder must be set")
+		}
+		switch utils.GetStringParam(cmd.Flag("format")) {
+		case "text", "json", "sarif":
+		default:
+			return errors.New("format must be one of text, json or sarif")
+		}
+		switch utils.GetStringParam(cmd.Flag("fail-on")) {
+		case integrations.LintError, integrations.LintWarning, "none":
+		default:
+			return errors.New("fail-on must be one of error, warning or none")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
+		var lintFiles []string
+		lintFile := utils.GetStringParam(cmd.Flag("file"))
+		lintFolder := utils.GetStringParam(cmd.Flag("folder"))
+		format := utils.GetStringParam(cmd.Flag("format"))
+		failOn := utils.GetStringParam(cmd.Flag("fail-on"))
+
+		if lintFile != "" {
+			lintFiles = append(lintFiles, lintFile)
+		} else {
+			if lintFiles, err = filepath.Glob(filepath.Join(lintFolder, "*.json")); err != nil {
+				return err
+			}
+			if len(lintFiles) == 0 {
+				return fmt.Errorf("no integration files were found in %s", lintFolder)
+			}
+		}
+
+		results := make(map[string][]integrations.LintFinding)
+		var allFindings []integrations.LintFinding
+		for _, lintFile = range lintFiles {
+			content, err := utils.ReadFile(lintFile)
+			if err != nil {
+				return err
+			}
+			findings, err := integrations.Lint(content)
+			if err != nil {
+				return fmt.Errorf("unable to parse %s: %w", lintFile, err)
+			}
+			results[lintFile] = findings
+			allFindings = append(allFindings, findings...)
+		}
+
+		output, err := formatLintResults(results, format)
+		if err != nil {
+			return err
+		}
+		clilog.HTTPResponse.Print(output)
+
+		if failOn != "none" && integrations.LintFailed(allFindings, failOn) {
+			return fmt.Errorf("found %d problem(s) in the integrations", len(allFindings))
+		}
+		return nil
+	},
+	Example: `Lint an integration flow: ` + GetExample(24) + `
+Lint the scaffold integrations and produce SARIF for code scanning: ` + GetExample(25),
+}
+
+func init() {
+	var lintFile, lintFolder, format, failOn string
+
+	LintCmd.Flags().StringVarP(&lintFile, "file", "f",
+		"", "Integration flow JSON file path")
+	LintCmd.Flags().StringVarP(&lintFolder, "folder", "",
+		"", "Folder containing integration flow JSON files, for example the scaffold src folder")
+	LintCmd.Flags().StringVarP(&format, "format", "",
+		"text", "Output format; text, json or sarif")
+	LintCmd.Flags().StringVarP(&failOn, "fail-on", "",
+		integrations.LintError, "Return an error when findings of this severity are found; error, warning or none")
+}
+
+// formatLintResults formats the findings per file as text, json or sarif
+func formatLintResults(results map[string][]integrations.LintFinding, format string) (string, error) {
+	files := make([]string, 0, len(results))
+	for file := range results {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	switch format {
+	case "json":
+		type lintFileResult struct {
+			File     string                     `json:"file"`
+			Findings []integrations.LintFinding `json:"findings"`
+		}
+		fileResults := []lintFileResult{}
+		for _, file := range files {
+			findings := results[file]
+			if findings == nil {
+				findings = []integrations.LintFinding{}
+			}
+			fileResults = append(fileResults, lintFileResult{File: file, Findings: findings})
+		}
+		output, err := json.MarshalIndent(fileResults, "", "\t")
+		return string(output) + "\n", err
+	case "sarif":
+		output, err := json.MarshalIndent(getSarifLog(files, results), "", "\t")
+		return string(output) + "\n", err
+	default:
+		output := strings.Builder{}
+		count := 0
+		for _, file := range files {
+			for _, finding := range results[file] {
+				output.WriteString(fmt.Sprintf("%s: %s: [%s] %s: %s\n", file, finding.Severity,
+					finding.Rule, finding.Location, finding.Message))
+				count++
+			}
+		}
+		output.WriteString(fmt.Sprintf("%d problem(s) found in %d file(s)\n", count, len(files)))
+		return output.String(), nil
+	}
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationUri string      `json:"informationUri,omitempty"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	Id                   string                 `json:"id"`
+	ShortDescription     sarifMessage           `json:"shortDescription"`
+	DefaultConfiguration sarifRuleConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifRuleConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleId    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	Uri string `json:"uri"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+// getSarifLog converts the findings to a SARIF 2.1.0 log
+func getSarifLog(files []string, results map[string][]integrations.LintFinding) sarifLog {
+	version, _, _ := apiclient.GetBuildParams()
+	driver := sarifDriver{
+		Name:           "integrationcli",
+		Version:        version,
+		InformationUri: "https://github.com/GoogleCloudPlatform/application-integration-management-toolkit",
+	}
+	for _, rule := range integrations.LintRules {
+		driver.Rules = append(driver.Rules, sarifRule{
+			Id:                   rule.Id,
+			ShortDescription:     sarifMessage{Text: rule.Description},
+			DefaultConfiguration: sarifRuleConfiguration{Level: rule.Severity},
+		})
+	}
+
+	run := sarifRun{Tool: sarifTool{Driver: driver}, Results: []sarifResult{}}
+	for _, file := range files {
+		uri := filepath.ToSlash(file)
+		if wd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(wd, file); err == nil && !strings.HasPrefix(rel, "..") {
+				uri = filepath.ToSlash(rel)
+			}
+		}
+		for _, finding := range results[file] {
+			location := sarifLocation{
+				PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{Uri: uri}},
+			}
+			if finding.Location != "" {
+				location.LogicalLocations = []sarifLogicalLocation{{FullyQualifiedName: finding.Location}}
+			}
+			run.Results = append(run.Results, sarifResult{
+				RuleId:    finding.Rule,
+				Level:     finding.Severity,
+				Message:   sarifMessage{Text: finding.Message},
+				Locations: []sarifLocation{location},
+			})
+		}
+	}
+
+	return sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	}
+}
@@ -43,9 +43,20 @@ var ListExecCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		name := utils.GetStringParam(cmd.Flag("name"))
-		_, err = integrations.ListExecutions(name, pageSize,
+		all, limit, err := utils.GetListAllFlags(cmd)
+		if err != nil {
+			return err
+		}
+		if all {
+			_, err = integrations.ListAllExecutions(client, name, pageSize,
+				utils.GetStringParam(cmd.Flag("filter")),
+				utils.GetStringParam(cmd.Flag("orderBy")), limit)
+			return err
+		}
+		_, err = integrations.ListExecutions(client, name, pageSize,
 			utils.GetStringParam(cmd.Flag("pageToken")),
 			utils.GetStringParam(cmd.Flag("filter")),
 			utils.GetStringParam(cmd.Flag("orderBy")))
@@ -67,5 +78,7 @@ func init() {
 	ListExecCmd.Flags().StringVarP(&orderBy, "orderBy", "",
 		"", "The results would be returned in order")
 
+	utils.AddListAllFlags(ListExecCmd)
+
 	_ = ListExecCmd.MarkFlagRequired("name")
 }
@@ -48,6 +48,7 @@ var ListTestCaseCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		version := utils.GetStringParam(cmd.Flag("ver"))
 		name := utils.GetStringParam(cmd.Flag("name"))
@@ -56,13 +57,25 @@ var ListTestCaseCmd = &cobra.Command{
 		pageToken := utils.GetStringParam(cmd.Flag("pageToken"))
 		filter := utils.GetStringParam(cmd.Flag("filter"))
 		orderBy := utils.GetStringParam(cmd.Flag("orderBy"))
+		all, limit, err := utils.GetListAllFlags(cmd)
+		if err != nil {
+			return err
+		}
 
-		if version != "" {
-			_, err = integrations.ListTestCases(name, version, full, filter, pageSize, pageToken, orderBy)
+		if all {
+			if version != "" {
+				_, err = integrations.ListAllTestCases(client, name, version, full, filter, pageSize, orderBy, limit)
+			} else if userLabel != "" {
+				_, err = integrations.ListAllTestCasesByUserlabel(client, name, userLabel, full, filter, pageSize, orderBy, limit)
+			} else if snapshot != "" {
+				_, err = integrations.ListAllTestCasesBySnapshot(client, name, snapshot, full, filter, pageSize, orderBy, limit)
+			}
+		} else if version != "" {
+			_, err = integrations.ListTestCases(client, name, version, full, filter, pageSize, pageToken, orderBy)
 		} else if userLabel != "" {
-			_, err = integrations.ListTestCasesByUserlabel(name, userLabel, full, filter, pageSize, pageToken, orderBy)
+			_, err = integrations.ListTestCasesByUserlabel(client, name, userLabel, full, filter, pageSize, pageToken, orderBy)
 		} else if snapshot != "" {
-			_, err = integrations.ListTestCasesBySnapshot(name, snapshot, full, filter, pageSize, pageToken, orderBy)
+			_, err = integrations.ListTestCasesBySnapshot(client, name, snapshot, full, filter, pageSize, pageToken, orderBy)
 		}
 
 		return err
@@ -93,5 +106,7 @@ func init() {
 	ListTestCaseCmd.Flags().StringVarP(&orderBy, "orderBy", "",
 		"", "The results would be returned in order")
 
+	utils.AddListAllFlags(ListTestCaseCmd)
+
 	_ = ListTestCaseCmd.MarkFlagRequired("name")
 }
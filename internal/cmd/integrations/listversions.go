@@ -43,10 +43,22 @@ var ListVerCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		name := utils.GetStringParam(cmd.Flag("name"))
 		basic := utils.GetBasicInfo(cmd, "basic")
-		_, err = integrations.ListVersions(name, pageSize,
+		all, limit, err := utils.GetListAllFlags(cmd)
+		if err != nil {
+			return err
+		}
+		if all {
+			_, err = integrations.ListAllVersions(client, name, pageSize,
+				utils.GetStringParam(cmd.Flag("filter")),
+				utils.GetStringParam(cmd.Flag("orderBy")),
+				basic, limit)
+			return err
+		}
+		_, err = integrations.ListVersions(client, name, pageSize,
 			utils.GetStringParam(cmd.Flag("pageToken")),
 			utils.GetStringParam(cmd.Flag("filter")),
 			utils.GetStringParam(cmd.Flag("orderBy")),
@@ -73,5 +85,7 @@ func init() {
 	ListVerCmd.Flags().StringVarP(&basic, "basic", "b",
 		"", "Returns snapshot and version only; default is false")
 
+	utils.AddListAllFlags(ListVerCmd)
+
 	_ = ListVerCmd.MarkFlagRequired("name")
 }
@@ -44,6 +44,7 @@ var PatchVerCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		version := utils.GetStringParam(cmd.Flag("ver"))
 		name := utils.GetStringParam(cmd.Flag("name"))
@@ -56,7 +57,7 @@ var PatchVerCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		_, err = integrations.Patch(name, version, content)
+		_, err = integrations.Patch(client, name, version, content)
 		return err
 	},
 }
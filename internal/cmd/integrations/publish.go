@@ -54,6 +54,7 @@ var PublishVerCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		version := utils.GetStringParam(cmd.Flag("ver"))
 		userLabel := utils.GetStringParam(cmd.Flag("user-label"))
@@ -83,15 +84,14 @@ var PublishVerCmd = &cobra.Command{
 		latest := ignoreLatest(version, userLabel, snapshot)
 
 		if latest {
-			apiclient.DisableCmdPrintHttpResponse()
 			// list integration versions, order by state=SNAPSHOT, page size = 1 and return basic info
-			respBody, err := integrations.ListVersions(name, 1, "", "state=SNAPSHOT",
+			respBody, err := integrations.ListVersions(client.WithoutOutput(), name, 1, "", "state=SNAPSHOT",
 				"snapshot_number", false, false, true)
 			if err != nil {
 				return fmt.Errorf("unable to list versions: %v", err)
 			}
 			if string(respBody) == "{}" {
-				if respBody, err = integrations.ListVersions(name, 1, "", "state=DRAFT",
+				if respBody, err = integrations.ListVersions(client.WithoutOutput(), name, 1, "", "state=DRAFT",
 					"snapshot_number", false, false, true); err != nil {
 					return fmt.Errorf("unable to list versions: %v", err)
 				}
@@ -100,17 +100,16 @@ var PublishVerCmd = &cobra.Command{
 			if err != nil {
 				return err
 			}
-			apiclient.EnableCmdPrintHttpResponse()
-			_, err = integrations.Publish(name, version, contents)
+			_, err = integrations.Publish(client, name, version, contents)
 			info = "version " + version
 		} else if version != "" {
-			_, err = integrations.Publish(name, version, contents)
+			_, err = integrations.Publish(client, name, version, contents)
 			info = "version " + version
 		} else if userLabel != "" {
-			_, err = integrations.PublishUserLabel(name, userLabel, contents)
+			_, err = integrations.PublishUserLabel(client, name, userLabel, contents)
 			info = "user label " + userLabel
 		} else if snapshot != "" {
-			_, err = integrations.PublishSnapshot(name, snapshot, contents)
+			_, err = integrations.PublishSnapshot(client, name, snapshot, contents)
 			info = "snapshot number " + snapshot
 		}
 		if err == nil {
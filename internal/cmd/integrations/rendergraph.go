@@ -0,0 +1,138 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"errors"
+	"internal/apiclient"
+	"internal/client/integrations"
+	"internal/clilog"
+	"internal/cmd/utils"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// RenderGraphCmd to render an integration flow as a diagram
+var RenderGraphCmd = &cobra.Command{
+	Use:   "render-graph",
+	Short: "Render an integration flow as a Mermaid or Graphviz diagram",
+	Long: "Render the triggers, tasks, conditions and error catchers of an integration flow " +
+		"from a local file or an integration flow version as a Mermaid or Graphviz DOT diagram",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			clilog.Debug.Printf("%s: %s\n", f.Name, f.Value)
+		})
+
+		graphFile := utils.GetStringParam(cmd.Flag("file"))
+		name := utils.GetStringParam(cmd.Flag("name"))
+		format := utils.GetStringParam(cmd.Flag("format"))
+
+		if format != integrations.MermaidGraph && format != integrations.DotGraph {
+			return errors.New("format must be one of mermaid or dot")
+		}
+		if (graphFile == "" && name == "") || (graphFile != "" && name != "") {
+			return errors.New("one of --file or --name must be set")
+		}
+		if graphFile != "" {
+			return nil
+		}
+
+		cmdProject := utils.GetStringParam(cmd.Flag("proj"))
+		cmdRegion := utils.GetStringParam(cmd.Flag("reg"))
+		version := utils.GetStringParam(cmd.Flag("ver"))
+		userLabel := utils.GetStringParam(cmd.Flag("user-label"))
+		snapshot := utils.GetStringParam(cmd.Flag("snapshot"))
+		latest, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("latest")))
+
+		if err = apiclient.SetRegion(cmdRegion); err != nil {
+			return err
+		}
+		if err = validate(version, userLabel, snapshot, latest); err != nil {
+			return err
+		}
+		return apiclient.SetProjectID(cmdProject)
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
+
+		var integrationBody []byte
+
+		graphFile := utils.GetStringParam(cmd.Flag("file"))
+		name := utils.GetStringParam(cmd.Flag("name"))
+		version := utils.GetStringParam(cmd.Flag("ver"))
+		userLabel := utils.GetStringParam(cmd.Flag("user-label"))
+		snapshot := utils.GetStringParam(cmd.Flag("snapshot"))
+		format := utils.GetStringParam(cmd.Flag("format"))
+
+		if graphFile != "" {
+			if integrationBody, err = utils.ReadFile(graphFile); err != nil {
+				return err
+			}
+		} else {
+			client = client.WithoutOutput()
+
+			if ignoreLatest(version, userLabel, snapshot) {
+				if version, err = getLatestVersion(client, name); err != nil {
+					return err
+				}
+			}
+
+			if version != "" {
+				integrationBody, err = integrations.Get(client, name, version, false, true, false)
+			} else if snapshot != "" {
+				integrationBody, err = integrations.GetBySnapshot(client, name, snapshot, false, true, false)
+			} else if userLabel != "" {
+				integrationBody, err = integrations.GetByUserlabel(client, name, userLabel, false, true, false)
+			} else {
+				return errors.New("latest version not found. Must pass oneOf version, snapshot or user-label or fix the integration name")
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		graph, err := integrations.RenderGraph(integrationBody, format)
+		if err != nil {
+			return err
+		}
+		clilog.HTTPResponse.Print(graph)
+		return nil
+	},
+	Example: `Render a local integration flow as a Mermaid diagram: ` + GetExample(26) + `
+Render an integration flow snapshot as a Graphviz diagram: ` + GetExample(27),
+}
+
+func init() {
+	var graphFile, name, userLabel, snapshot, version, format string
+	var latest bool
+
+	RenderGraphCmd.Flags().StringVarP(&graphFile, "file", "f",
+		"", "Integration flow JSON file path")
+	RenderGraphCmd.Flags().StringVarP(&name, "name", "n",
+		"", "Integration flow name")
+	RenderGraphCmd.Flags().StringVarP(&version, "ver", "v",
+		"", "Integration flow version")
+	RenderGraphCmd.Flags().StringVarP(&userLabel, "user-label", "u",
+		"", "Integration flow user label")
+	RenderGraphCmd.Flags().StringVarP(&snapshot, "snapshot", "s",
+		"", "Integration flow snapshot number")
+	RenderGraphCmd.Flags().BoolVarP(&latest, "latest", "",
+		true, "Renders the version with the highest snapshot number in SNAPSHOT state. If none found, selects the highest snapshot in DRAFT state; default is true")
+	RenderGraphCmd.Flags().StringVarP(&format, "format", "",
+		integrations.MermaidGraph, "Diagram format; mermaid or dot")
+}
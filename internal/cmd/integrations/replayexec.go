@@ -43,11 +43,12 @@ var ReplayExecCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		name := utils.GetStringParam(cmd.Flag("name"))
 		executionID := utils.GetStringParam(cmd.Flag("execution-id"))
 		replayReason := utils.GetStringParam(cmd.Flag("replay-reason"))
-		_, err = integrations.Replay(name, executionID, replayReason)
+		_, err = integrations.Replay(client, name, executionID, replayReason)
 		return err
 	},
 }
@@ -0,0 +1,105 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"fmt"
+	"internal/apiclient"
+	"internal/client/integrations"
+	"internal/clilog"
+	"internal/cmd/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// RunJavaScriptCmd to run a JavaScript task script locally
+var RunJavaScriptCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Run a JavaScript task script locally",
+	Long: "Run the executeScript(event) function of a JavaScript task script locally. The event reads and " +
+		"writes the integration variables from a JSON file and the resulting variables are printed",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			clilog.Debug.Printf("%s: %s\n", f.Name, f.Value)
+		})
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
+		var inputs []byte
+		scriptFile := utils.GetStringParam(cmd.Flag("file"))
+		inputsFile := utils.GetStringParam(cmd.Flag("inputs"))
+		assertFile := utils.GetStringParam(cmd.Flag("assert"))
+
+		script, err := utils.ReadFile(scriptFile)
+		if err != nil {
+			return err
+		}
+		if inputsFile != "" {
+			if inputs, err = utils.ReadFile(inputsFile); err != nil {
+				return err
+			}
+		}
+
+		output, logs, err := integrations.RunJavaScript(scriptFile, script, inputs)
+		for _, log := range logs {
+			clilog.Info.Printf("event.log: %s\n", log)
+		}
+		if err != nil {
+			return err
+		}
+		if output, err = apiclient.PrettifyJson(output); err != nil {
+			return err
+		}
+		clilog.HTTPResponse.Println(string(output))
+
+		if assertFile == "" {
+			return nil
+		}
+		expected, err := utils.ReadFile(assertFile)
+		if err != nil {
+			return err
+		}
+		mismatches, err := integrations.AssertVariables(output, expected)
+		if err != nil {
+			return err
+		}
+		for _, mismatch := range mismatches {
+			clilog.Error.Println(mismatch)
+		}
+		if len(mismatches) > 0 {
+			return fmt.Errorf("variables do not match the assertions in %s", assertFile)
+		}
+		clilog.Info.Printf("Variables match the assertions in %s\n", assertFile)
+		return nil
+	},
+	Example: `Run a JavaScript task script with input variables: ` + GetExample(32) + `
+Run a JavaScript task script and assert the values of variables: ` + GetExample(33),
+}
+
+func init() {
+	var scriptFile, inputsFile, assertFile string
+
+	RunJavaScriptCmd.Flags().StringVarP(&scriptFile, "file", "f",
+		"", "JavaScript task script file path")
+	RunJavaScriptCmd.Flags().StringVarP(&inputsFile, "inputs", "i",
+		"", "JSON file with the input variables, either a map of names and values or a test configuration")
+	RunJavaScriptCmd.Flags().StringVarP(&assertFile, "assert", "",
+		"", "JSON file with the expected values of variables; variables not in the file are not compared")
+
+	_ = RunJavaScriptCmd.MarkFlagRequired("file")
+}
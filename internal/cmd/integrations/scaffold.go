@@ -60,10 +60,9 @@ var ScaffoldCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
-		const jsonExt = ".json"
 		var fileSplitter string
-		var integrationBody, overridesBody, testCasesBody []byte
 		version := utils.GetStringParam(cmd.Flag("ver"))
 		userLabel := utils.GetStringParam(cmd.Flag("user-label"))
 		snapshot := utils.GetStringParam(cmd.Flag("snapshot"))
@@ -97,301 +96,24 @@ var ScaffoldCmd = &cobra.Command{
 		latest := ignoreLatest(version, userLabel, snapshot)
 
 		if latest {
-			if version, err = getLatestVersion(name); err != nil {
+			if version, err = getLatestVersion(client, name); err != nil {
 				return err
 			}
 		}
 
-		apiclient.DisableCmdPrintHttpResponse()
+		client = client.WithoutOutput()
 
-		// Get
-
-		if version != "" {
-			if integrationBody, err = integrations.Get(name, version, false, true, false); err != nil {
-				return err
-			}
-			if overridesBody, err = integrations.Get(name, version, false, false, true); err != nil {
-				return err
-			}
-			if !skipTestCases {
-				if testCasesBody, err = integrations.ListTestCases(name, version, false, "", -1, "", ""); err != nil {
-					return err
-				}
-			} else {
-				clilog.Info.Printf("Skipping scaffolding testcases\n")
-			}
-		} else if userLabel != "" {
-			if integrationBody, err = integrations.GetByUserlabel(name, userLabel, false, true, false); err != nil {
-				return err
-			}
-			if overridesBody, err = integrations.GetByUserlabel(name, userLabel, false, false, true); err != nil {
-				return err
-			}
-			if !skipTestCases {
-				if testCasesBody, err = integrations.ListTestCasesByUserlabel(name, userLabel, false, "", -1, "", ""); err != nil {
-					return err
-				}
-			} else {
-				clilog.Info.Printf("Skipping scaffolding testcases\n")
-			}
-
-		} else if snapshot != "" {
-			if integrationBody, err = integrations.GetBySnapshot(name, snapshot, false, true, false); err != nil {
-				return err
-			}
-			if overridesBody, err = integrations.GetBySnapshot(name, snapshot, false, false, true); err != nil {
-				return err
-			}
-			if !skipTestCases {
-				if testCasesBody, err = integrations.ListTestCasesBySnapshot(name, snapshot, false, "", -1, "", ""); err != nil {
-					return err
-				}
-			} else {
-				clilog.Info.Printf("Skipping scaffolding testcases\n")
-			}
-		} else {
-			return errors.New("latest version not found. 1) The integration may be in DRAFT state. Pass a snapshot number. 2) An invalid integration name was set. 3) Latest flag was combined with version, snapshot or user-label")
-		}
-
-		clilog.Info.Printf("Storing the Integration: %s\n", name)
-		if err = generateFolder(path.Join(baseFolder, "src")); err != nil {
-			return err
-		}
-
-		integrationBody, err = apiclient.PrettifyJson(integrationBody)
-		if err != nil {
-			return err
-		}
-
-		if err = apiclient.WriteByteArrayToFile(
-			path.Join(baseFolder, "src", name+jsonExt),
-			false,
-			integrationBody); err != nil {
-			return err
-		}
-
-		if len(testCasesBody) > 3 {
-			clilog.Info.Printf("Found test cases in the integration, storing the test cases file\n")
-			if err = generateFolder(path.Join(folder, "tests")); err != nil {
-				return err
-			}
-			if err = generateFolder(path.Join(folder, "test-configs")); err != nil {
-				return err
-			}
-			if err = generateTestcases(testCasesBody, integrationBody, folder); err != nil {
-				return err
-			}
-		}
-
-		// write integration overrides
-		if len(overridesBody) > 0 && string(overridesBody) != "{}" {
-			clilog.Info.Printf("Found overrides in the integration, storing the overrides file\n")
-			if err = generateFolder(path.Join(folder, "overrides")); err != nil {
-				return err
-			}
-			overridesBody, err = apiclient.PrettifyJson(overridesBody)
-			if err != nil {
-				return err
-			}
-			if err = apiclient.WriteByteArrayToFile(
-				path.Join(folder, "overrides", "overrides.json"),
-				false,
-				overridesBody); err != nil {
-				return err
-			}
-		}
-
-		// write integation config variables
-		configVariables, err := integrations.GetConfigVariables(integrationBody)
-		if err != nil {
-			return err
-		}
-		if len(configVariables) > 0 {
-			clilog.Info.Printf("Found config variables in the integration, storing the config file\n")
-			if err = generateFolder(path.Join(folder, "config-variables")); err != nil {
-				return err
-			}
-			configVariables, err = apiclient.PrettifyJson(configVariables)
-			if err = apiclient.WriteByteArrayToFile(
-				path.Join(folder, "config-variables", name+"-config.json"),
-				false,
-				configVariables); err != nil {
-				return err
-			}
-		}
-
-		// extract code
-		if extractCode {
-			codeMap, err := integrations.ExtractCode(integrationBody)
-			if err != nil {
-				return err
-			}
-			if len(codeMap["JavaScriptTask"]) > 0 {
-				javascriptFolder := path.Join(baseFolder, "src", "javascript")
-				if err = generateFolder(javascriptFolder); err != nil {
-					return err
-				}
-				clilog.Info.Printf("Found JavaScript files in the integration; generating separate files\n")
-				for taskId, taskContent := range codeMap["JavaScriptTask"] {
-					if err = apiclient.WriteByteArrayToFile(
-						path.Join(javascriptFolder, "javascript_"+string(taskId)+".js"),
-						false,
-						[]byte(taskContent)); err != nil {
-						return err
-					}
-				}
-			}
-			if len(codeMap["JsonnetMapperTask"]) > 0 {
-				jsonnetFolder := path.Join(baseFolder, "src", "datatransformer")
-				if err = generateFolder(jsonnetFolder); err != nil {
-					return err
-				}
-				clilog.Info.Printf("Found Jsonnet files in the integration; generating separate files\n")
-				for taskId, taskContent := range codeMap["JsonnetMapperTask"] {
-					if err = apiclient.WriteByteArrayToFile(
-						path.Join(jsonnetFolder, "datatransformer_"+string(taskId)+".jsonnet"),
-						false,
-						[]byte(taskContent)); err != nil {
-						return err
-					}
-				}
-			}
-		}
-
-		// auth config
-		authConfigUuids, err := integrations.GetAuthConfigs(integrationBody)
-		if err != nil {
-			return err
-		}
-
-		if !skipAuthconfigs {
-			if len(authConfigUuids) > 0 {
-				clilog.Info.Printf("Found authconfigs in the integration\n")
-				if err = generateFolder(path.Join(folder, "authconfigs")); err != nil {
-					return err
-				}
-				for _, authConfigUUIDs := range authConfigUuids {
-					authConfigResp, err := authconfigs.Get(authConfigUUIDs, true)
-					if err != nil {
-						return err
-					}
-					authConfigName := getName(authConfigResp)
-					clilog.Info.Printf("Storing authconfig %s\n", authConfigName)
-					authConfigResp, err = apiclient.PrettifyJson(authConfigResp)
-					if err != nil {
-						return err
-					}
-					if err = apiclient.WriteByteArrayToFile(
-						path.Join(folder, "authconfigs", authConfigName+jsonExt),
-						false,
-						authConfigResp); err != nil {
-						return err
-					}
-				}
-			}
-		} else {
-			clilog.Info.Printf("Skipping scaffold of authconfigs configuration\n")
-		}
-
-		if !skipConnectors {
-			connectors, err := integrations.GetConnectionsWithRegion(integrationBody)
-			if err != nil {
-				return err
-			}
-
-			if len(connectors) > 0 {
-				clilog.Info.Printf("Found connectors in the integration\n")
-				if err = generateFolder(path.Join(folder, "connectors")); err != nil {
-					return err
-				}
-				// check for custom connectors
-				for _, connector := range connectors {
-					if connector.CustomConnection {
-						if err = generateFolder(path.Join(folder, "custom-connectors")); err != nil {
-							return err
-						}
-						break
-					}
-				}
-				for _, connector := range connectors {
-					if connector.CustomConnection {
-						customConnectionResp, err := connections.GetCustomVersion(connector.Name, connector.Version, true)
-						if err != nil {
-							return err
-						}
-						clilog.Info.Printf("Storing custom connector %s\n", connector.Name)
-						customConnectionResp, err = apiclient.PrettifyJson(customConnectionResp)
-						if err != nil {
-							return err
-						}
-						if err = apiclient.WriteByteArrayToFile(
-							path.Join(folder, "custom-connectors", connector.Name+fileSplitter+connector.Version+jsonExt),
-							false,
-							customConnectionResp); err != nil {
-							return err
-						}
-					} else {
-						connectionResp, err := connections.GetConnectionDetailWithRegion(connector.Name, connector.Region, "", true, true)
-						if err != nil {
-							return err
-						}
-						clilog.Info.Printf("Storing connector %s\n", connector.Name)
-						connectionResp, err = apiclient.PrettifyJson(connectionResp)
-						if err != nil {
-							return err
-						}
-						if err = apiclient.WriteByteArrayToFile(
-							path.Join(folder, "connectors", connector.Name+jsonExt),
-							false,
-							connectionResp); err != nil {
-							return err
-						}
-					}
-				}
-			}
-		} else {
-			clilog.Info.Printf("Skipping scaffold of connector configuration\n")
-		}
-
-		instances, err := integrations.GetSfdcInstances(integrationBody)
+		integrationBody, err := scaffoldIntegration(client, name, version, userLabel, snapshot, true,
+			baseFolder, folder, fileSplitter)
 		if err != nil {
 			return err
 		}
 
-		if len(instances) > 0 {
-			clilog.Info.Printf("Found sfdc instances in the integration\n")
-			instancesContent, err := sfdc.GetInstancesAndChannels(instances)
-			if err != nil {
+		if recursive {
+			if err = scaffoldSubIntegrations(client, name, integrationBody, userLabel,
+				baseFolder, folder, fileSplitter); err != nil {
 				return err
 			}
-			if len(instancesContent) > 0 {
-				if err = generateFolder(path.Join(folder, "sfdcinstances")); err != nil {
-					return err
-				}
-				if err = generateFolder(path.Join(folder, "sfdcchannels")); err != nil {
-					return err
-				}
-				for instance, channel := range instancesContent {
-					instanceBytes, _ := apiclient.PrettifyJson([]byte(instance))
-					channelBytes, _ := apiclient.PrettifyJson([]byte(channel))
-					instanceName := getName([]byte(instance))
-					channelName := getName([]byte(channel))
-					clilog.Info.Printf("Storing sfdcinstance %s\n", instanceName)
-					if err = apiclient.WriteByteArrayToFile(
-						path.Join(folder, "sfdcinstances", instanceName+jsonExt),
-						false,
-						instanceBytes); err != nil {
-						return err
-					}
-					clilog.Info.Printf("Storing sfdcchannel %s\n", channelName)
-					if err = apiclient.WriteByteArrayToFile(
-						path.Join(folder, "sfdcchannels", instanceName+fileSplitter+channelName+jsonExt),
-						false,
-						channelBytes); err != nil {
-						return err
-					}
-				}
-			}
 		}
 
 		if cloudBuild {
@@ -435,11 +157,13 @@ var ScaffoldCmd = &cobra.Command{
 	Example: `Generate scaffold for dev env using snapshot: ` + GetExample(5) + `
 Generate scaffold for integration, but skip connectors: ` + GetExample(6) + `
 Generate scaffold for integration and produce cloud build config: ` + GetExample(7) + `
-Generate scaffold for integration and produce cloud deploy config: ` + GetExample(8) + `\n See samples/scaffold-sample for more details`,
+Generate scaffold for integration and produce cloud deploy config: ` + GetExample(8) + `
+Generate scaffold for integration and the sub-integrations it calls: ` + GetExample(22) + `\n See samples/scaffold-sample for more details`,
 }
 
 var (
 	cloudBuild, cloudDeploy, skipConnectors, skipAuthconfigs, skipTestCases, useUnderscore, extractCode bool
+	recursive                                                                                           bool
 	env                                                                                                 string
 )
 
@@ -480,12 +204,392 @@ func init() {
 		false, "Use underscore as a file splitter; default is __")
 	ScaffoldCmd.Flags().BoolVarP(&extractCode, "extract-code", "x",
 		false, "Extract JavaScript and Jsonnet code as separate files; default is false")
+	ScaffoldCmd.Flags().BoolVarP(&recursive, "recursive", "",
+		false, "Scaffold the sub-integrations called by the integration; default is false")
 	ScaffoldCmd.Flags().BoolVarP(&latest, "latest", "",
 		true, "Scaffolds the version with the highest snapshot number in SNAPSHOT state. If none found, selects the highest snapshot in DRAFT state; default is true")
 
 	_ = ScaffoldCmd.MarkFlagRequired("name")
 }
 
+// scaffoldIntegration stores an integration version and its dependencies in the scaffold folders.
+// Overrides, tests and code of sub-integrations are stored in files and folders named after the integration
+func scaffoldIntegration(client *apiclient.Client, name string, version string, userLabel string, snapshot string, main bool,
+	baseFolder string, folder string, fileSplitter string,
+) (integrationBody []byte, err error) {
+	var overridesBody, testCasesBody []byte
+
+	// Get
+
+	if version != "" {
+		if integrationBody, err = integrations.Get(client, name, version, false, true, false); err != nil {
+			return nil, err
+		}
+		if overridesBody, err = integrations.Get(client, name, version, false, false, true); err != nil {
+			return nil, err
+		}
+		if !skipTestCases {
+			if testCasesBody, err = integrations.ListTestCases(client, name, version, false, "", -1, "", ""); err != nil {
+				return nil, err
+			}
+		} else {
+			clilog.Info.Printf("Skipping scaffolding testcases\n")
+		}
+	} else if userLabel != "" {
+		if integrationBody, err = integrations.GetByUserlabel(client, name, userLabel, false, true, false); err != nil {
+			return nil, err
+		}
+		if overridesBody, err = integrations.GetByUserlabel(client, name, userLabel, false, false, true); err != nil {
+			return nil, err
+		}
+		if !skipTestCases {
+			if testCasesBody, err = integrations.ListTestCasesByUserlabel(client, name, userLabel, false, "", -1, "", ""); err != nil {
+				return nil, err
+			}
+		} else {
+			clilog.Info.Printf("Skipping scaffolding testcases\n")
+		}
+
+	} else if snapshot != "" {
+		if integrationBody, err = integrations.GetBySnapshot(client, name, snapshot, false, true, false); err != nil {
+			return nil, err
+		}
+		if overridesBody, err = integrations.GetBySnapshot(client, name, snapshot, false, false, true); err != nil {
+			return nil, err
+		}
+		if !skipTestCases {
+			if testCasesBody, err = integrations.ListTestCasesBySnapshot(client, name, snapshot, false, "", -1, "", ""); err != nil {
+				return nil, err
+			}
+		} else {
+			clilog.Info.Printf("Skipping scaffolding testcases\n")
+		}
+	} else {
+		return nil, errors.New("latest version not found. 1) The integration may be in DRAFT state. Pass a snapshot number. 2) An invalid integration name was set. 3) Latest flag was combined with version, snapshot or user-label")
+	}
+
+	clilog.Info.Printf("Storing the Integration: %s\n", name)
+	if err = generateFolder(path.Join(baseFolder, "src")); err != nil {
+		return nil, err
+	}
+
+	integrationBody, err = apiclient.PrettifyJson(integrationBody)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiclient.WriteByteArrayToFile(
+		path.Join(baseFolder, "src", name+jsonExt),
+		false,
+		integrationBody); err != nil {
+		return nil, err
+	}
+
+	if len(testCasesBody) > 3 {
+		clilog.Info.Printf("Found test cases in the integration, storing the test cases file\n")
+		testsFolder, testConfigsFolder := path.Join(folder, "tests"), path.Join(folder, "test-configs")
+		if err = generateFolder(testsFolder); err != nil {
+			return nil, err
+		}
+		if err = generateFolder(testConfigsFolder); err != nil {
+			return nil, err
+		}
+		// sub-integrations store test cases in folders named after the integration
+		if !main {
+			testsFolder, testConfigsFolder = path.Join(testsFolder, name), path.Join(testConfigsFolder, name)
+			if err = generateFolder(testsFolder); err != nil {
+				return nil, err
+			}
+			if err = generateFolder(testConfigsFolder); err != nil {
+				return nil, err
+			}
+		}
+		if err = generateTestcases(testCasesBody, integrationBody, testsFolder, testConfigsFolder); err != nil {
+			return nil, err
+		}
+	}
+
+	// write integration overrides
+	overridesFileName := "overrides.json"
+	if !main {
+		overridesFileName = name + "-overrides.json"
+	}
+	if len(overridesBody) > 0 && string(overridesBody) != "{}" {
+		clilog.Info.Printf("Found overrides in the integration, storing the overrides file\n")
+		if err = generateFolder(path.Join(folder, "overrides")); err != nil {
+			return nil, err
+		}
+		overridesBody, err = apiclient.PrettifyJson(overridesBody)
+		if err != nil {
+			return nil, err
+		}
+		if err = apiclient.WriteByteArrayToFile(
+			path.Join(folder, "overrides", overridesFileName),
+			false,
+			overridesBody); err != nil {
+			return nil, err
+		}
+	}
+
+	// write integation config variables
+	configVariables, err := integrations.GetConfigVariables(integrationBody)
+	if err != nil {
+		return nil, err
+	}
+	if len(configVariables) > 0 {
+		clilog.Info.Printf("Found config variables in the integration, storing the config file\n")
+		if err = generateFolder(path.Join(folder, "config-variables")); err != nil {
+			return nil, err
+		}
+		configVariables, err = apiclient.PrettifyJson(configVariables)
+		if err = apiclient.WriteByteArrayToFile(
+			path.Join(folder, "config-variables", name+"-config.json"),
+			false,
+			configVariables); err != nil {
+			return nil, err
+		}
+	}
+
+	// extract code
+	if extractCode {
+		codeMap, err := integrations.ExtractCode(integrationBody)
+		if err != nil {
+			return nil, err
+		}
+		if len(codeMap["JavaScriptTask"]) > 0 {
+			javascriptFolder := path.Join(baseFolder, "src", "javascript")
+			if err = generateFolder(javascriptFolder); err != nil {
+				return nil, err
+			}
+			if !main {
+				javascriptFolder = path.Join(javascriptFolder, name)
+				if err = generateFolder(javascriptFolder); err != nil {
+					return nil, err
+				}
+			}
+			clilog.Info.Printf("Found JavaScript files in the integration; generating separate files\n")
+			for taskId, taskContent := range codeMap["JavaScriptTask"] {
+				if err = apiclient.WriteByteArrayToFile(
+					path.Join(javascriptFolder, "javascript_"+string(taskId)+".js"),
+					false,
+					[]byte(taskContent)); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if len(codeMap["JsonnetMapperTask"]) > 0 {
+			jsonnetFolder := path.Join(baseFolder, "src", "datatransformer")
+			if err = generateFolder(jsonnetFolder); err != nil {
+				return nil, err
+			}
+			if !main {
+				jsonnetFolder = path.Join(jsonnetFolder, name)
+				if err = generateFolder(jsonnetFolder); err != nil {
+					return nil, err
+				}
+			}
+			clilog.Info.Printf("Found Jsonnet files in the integration; generating separate files\n")
+			for taskId, taskContent := range codeMap["JsonnetMapperTask"] {
+				if err = apiclient.WriteByteArrayToFile(
+					path.Join(jsonnetFolder, "datatransformer_"+string(taskId)+".jsonnet"),
+					false,
+					[]byte(taskContent)); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	// auth config
+	authConfigUuids, err := integrations.GetAuthConfigs(client, integrationBody)
+	if err != nil {
+		return nil, err
+	}
+
+	if !skipAuthconfigs {
+		if len(authConfigUuids) > 0 {
+			clilog.Info.Printf("Found authconfigs in the integration\n")
+			if err = generateFolder(path.Join(folder, "authconfigs")); err != nil {
+				return nil, err
+			}
+			for _, authConfigUUIDs := range authConfigUuids {
+				authConfigResp, err := authconfigs.Get(client, authConfigUUIDs, true)
+				if err != nil {
+					return nil, err
+				}
+				authConfigName := getName(authConfigResp)
+				clilog.Info.Printf("Storing authconfig %s\n", authConfigName)
+				authConfigResp, err = apiclient.PrettifyJson(authConfigResp)
+				if err != nil {
+					return nil, err
+				}
+				if err = apiclient.WriteByteArrayToFile(
+					path.Join(folder, "authconfigs", authConfigName+jsonExt),
+					false,
+					authConfigResp); err != nil {
+					return nil, err
+				}
+			}
+		}
+	} else {
+		clilog.Info.Printf("Skipping scaffold of authconfigs configuration\n")
+	}
+
+	if !skipConnectors {
+		connectors, err := integrations.GetConnectionsWithRegion(integrationBody)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(connectors) > 0 {
+			clilog.Info.Printf("Found connectors in the integration\n")
+			if err = generateFolder(path.Join(folder, "connectors")); err != nil {
+				return nil, err
+			}
+			// check for custom connectors
+			for _, connector := range connectors {
+				if connector.CustomConnection {
+					if err = generateFolder(path.Join(folder, "custom-connectors")); err != nil {
+						return nil, err
+					}
+					break
+				}
+			}
+			for _, connector := range connectors {
+				if connector.CustomConnection {
+					customConnectionResp, err := connections.GetCustomVersion(client, connector.Name, connector.Version, true)
+					if err != nil {
+						return nil, err
+					}
+					clilog.Info.Printf("Storing custom connector %s\n", connector.Name)
+					customConnectionResp, err = apiclient.PrettifyJson(customConnectionResp)
+					if err != nil {
+						return nil, err
+					}
+					if err = apiclient.WriteByteArrayToFile(
+						path.Join(folder, "custom-connectors", connector.Name+fileSplitter+connector.Version+jsonExt),
+						false,
+						customConnectionResp); err != nil {
+						return nil, err
+					}
+				} else {
+					connectionResp, err := connections.GetConnectionDetailWithRegion(client, connector.Name, connector.Region, "", true, true)
+					if err != nil {
+						return nil, err
+					}
+					clilog.Info.Printf("Storing connector %s\n", connector.Name)
+					connectionResp, err = apiclient.PrettifyJson(connectionResp)
+					if err != nil {
+						return nil, err
+					}
+					if err = apiclient.WriteByteArrayToFile(
+						path.Join(folder, "connectors", connector.Name+jsonExt),
+						false,
+						connectionResp); err != nil {
+						return nil, err
+					}
+				}
+			}
+		}
+	} else {
+		clilog.Info.Printf("Skipping scaffold of connector configuration\n")
+	}
+
+	instances, err := integrations.GetSfdcInstances(integrationBody)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(instances) > 0 {
+		clilog.Info.Printf("Found sfdc instances in the integration\n")
+		instancesContent, err := sfdc.GetInstancesAndChannels(client, instances)
+		if err != nil {
+			return nil, err
+		}
+		if len(instancesContent) > 0 {
+			if err = generateFolder(path.Join(folder, "sfdcinstances")); err != nil {
+				return nil, err
+			}
+			if err = generateFolder(path.Join(folder, "sfdcchannels")); err != nil {
+				return nil, err
+			}
+			for instance, channel := range instancesContent {
+				instanceBytes, _ := apiclient.PrettifyJson([]byte(instance))
+				channelBytes, _ := apiclient.PrettifyJson([]byte(channel))
+				instanceName := getName([]byte(instance))
+				channelName := getName([]byte(channel))
+				clilog.Info.Printf("Storing sfdcinstance %s\n", instanceName)
+				if err = apiclient.WriteByteArrayToFile(
+					path.Join(folder, "sfdcinstances", instanceName+jsonExt),
+					false,
+					instanceBytes); err != nil {
+					return nil, err
+				}
+				clilog.Info.Printf("Storing sfdcchannel %s\n", channelName)
+				if err = apiclient.WriteByteArrayToFile(
+					path.Join(folder, "sfdcchannels", instanceName+fileSplitter+channelName+jsonExt),
+					false,
+					channelBytes); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return integrationBody, nil
+}
+
+// scaffoldSubIntegrations stores every integration called directly or indirectly by the integration.
+// The user label is used when the sub-integration has a matching version, otherwise the published
+// version is used
+func scaffoldSubIntegrations(client *apiclient.Client, mainName string, integrationBody []byte, userLabel string,
+	baseFolder string, folder string, fileSplitter string,
+) (err error) {
+	visited := map[string]bool{mainName: true}
+	pending, err := integrations.GetSubIntegrations(integrationBody)
+	if err != nil {
+		return err
+	}
+
+	for len(pending) > 0 {
+		name := pending[0]
+		pending = pending[1:]
+		if visited[name] {
+			continue
+		}
+		visited[name] = true
+
+		version, err := getSubIntegrationVersion(client, name, userLabel)
+		if err != nil {
+			return err
+		}
+		clilog.Info.Printf("Found sub-integration %s, scaffolding version %s\n", name, version)
+		subIntegrationBody, err := scaffoldIntegration(client, name, version, "", "", false,
+			baseFolder, folder, fileSplitter)
+		if err != nil {
+			return err
+		}
+		subIntegrations, err := integrations.GetSubIntegrations(subIntegrationBody)
+		if err != nil {
+			return err
+		}
+		pending = append(pending, subIntegrations...)
+	}
+	return nil
+}
+
+// getSubIntegrationVersion returns the version of a sub-integration matching the user label, falling back
+// to the published version, which is the version a Call Integration task runs. Snapshot numbers are
+// not used, they are numbered per integration
+func getSubIntegrationVersion(client *apiclient.Client, name string, userLabel string) (version string, err error) {
+	if userLabel != "" {
+		if version, err = integrations.GetVersion(client, name, userLabel, ""); err == nil {
+			return version, nil
+		}
+		clilog.Warning.Printf("No version of %s matches the user label, using the published version\n", name)
+	}
+	return getLatestVersion(client, name)
+}
+
 func generateFolder(name string) (err error) {
 	if _, err = os.Stat(name); !os.IsNotExist(err) {
 		return nil
@@ -500,7 +604,7 @@ func getName(authConfigResp []byte) string {
 	return m["displayName"]
 }
 
-func generateTestcases(testcases []byte, integrationBody []byte, folder string) error {
+func generateTestcases(testcases []byte, integrationBody []byte, testsFolder string, testConfigsFolder string) error {
 
 	var data []map[string]interface{}
 	var testNames []string
@@ -532,14 +636,14 @@ func generateTestcases(testcases []byte, integrationBody []byte, folder string)
 			return err
 		}
 		if err = apiclient.WriteByteArrayToFile(
-			path.Join(folder, "tests", name+jsonExt),
+			path.Join(testsFolder, name+jsonExt),
 			false,
 			jsonData); err != nil {
 			return err
 		}
 		testConfig, _ := integrations.GetInputParameters(integrationBody)
 		if err = apiclient.WriteByteArrayToFile(
-			path.Join(folder, "test-configs", name+jsonExt),
+			path.Join(testConfigsFolder, name+jsonExt),
 			false,
 			testConfig); err != nil {
 			return err
@@ -0,0 +1,104 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"encoding/json"
+	"internal/client/clienttest"
+	"internal/client/integrations"
+	"os"
+	"path"
+	"testing"
+)
+
+// callIntegration returns an integration with a Call Integration task for the sub-integration
+func callIntegration(subIntegration string) string {
+	return `{
+	"triggerConfigs": [
+		{"label": "API Trigger", "triggerType": "API", "triggerNumber": "1", "triggerId": "api_trigger/call_API_1",
+			"startTasks": [{"taskId": "1"}]}
+	],
+	"taskConfigs": [
+		{"task": "SubWorkflowExecutorV2Task", "taskId": "1", "parameters": {"workflowName": {"key": "workflowName",
+			"value": {"stringValue": "` + subIntegration + `"}}}}
+	]
+}`
+}
+
+func TestScaffoldSubIntegrations(t *testing.T) {
+	_, client := clienttest.FakeSetup(t)
+
+	createVersion := func(name string, content string, userLabel string) string {
+		respBody, err := integrations.CreateVersion(client.WithoutOutput(), name, []byte(content), nil, "", userLabel, false, false)
+		if err != nil {
+			t.Fatalf("CreateVersion of %s failed: %v", name, err)
+		}
+		version := struct {
+			Name string `json:"name"`
+		}{}
+		if err = json.Unmarshal(respBody, &version); err != nil {
+			t.Fatalf("unable to parse the version of %s: %v", name, err)
+		}
+		return path.Base(version.Name)
+	}
+
+	// main calls sub, which calls leaf
+	mainVersion := createVersion("main", callIntegration("sub"), "")
+	createVersion("sub", callIntegration("leaf"), "")
+	publishedSub := createVersion("sub", callIntegration("leaf"), "")
+	if _, err := integrations.Publish(client.WithoutOutput(), "sub", publishedSub, nil); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	createVersion("sub", callIntegration("leaf"), "")
+	labeledLeaf := createVersion("leaf", javascriptIntegration, "prod")
+	createVersion("leaf", javascriptIntegration, "")
+
+	// the snapshot of the main integration does not select a version of sub
+	tests := []struct {
+		name      string
+		userLabel string
+		expected  string
+	}{
+		{"sub", "", publishedSub},
+		{"sub", "prod", publishedSub},
+		{"leaf", "prod", labeledLeaf},
+	}
+	for _, test := range tests {
+		version, err := getSubIntegrationVersion(client, test.name, test.userLabel)
+		if err != nil {
+			t.Fatalf("getSubIntegrationVersion of %s failed: %v", test.name, err)
+		}
+		if version != test.expected {
+			t.Errorf("getSubIntegrationVersion of %s with user label %q = %s, expected %s",
+				test.name, test.userLabel, version, test.expected)
+		}
+	}
+
+	defer func(s bool) { skipTestCases = s }(skipTestCases)
+	skipTestCases = true
+	folder := t.TempDir()
+	mainBody, err := integrations.Get(client.WithoutOutput(), "main", mainVersion, false, true, false)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if err = scaffoldSubIntegrations(client.WithoutOutput(), "main", mainBody, "prod", folder, folder, "__"); err != nil {
+		t.Fatalf("scaffoldSubIntegrations failed: %v", err)
+	}
+	for _, name := range []string{"sub", "leaf"} {
+		if _, err = os.Stat(path.Join(folder, "src", name+".json")); err != nil {
+			t.Errorf("expected the sub-integration %s to be scaffolded: %v", name, err)
+		}
+	}
+}
@@ -52,6 +52,7 @@ var UnPublishVerCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		version := utils.GetStringParam(cmd.Flag("ver"))
 		userLabel := utils.GetStringParam(cmd.Flag("user-label"))
@@ -63,15 +64,14 @@ var UnPublishVerCmd = &cobra.Command{
 		latest := ignoreLatest(version, userLabel, snapshot)
 
 		if latest {
-			apiclient.DisableCmdPrintHttpResponse()
 			// list integration versions, order by state=ACTIVE, page size = 1 and return basic info
-			respBody, err := integrations.ListVersions(name, 1, "", "state=ACTIVE",
+			respBody, err := integrations.ListVersions(client.WithoutOutput(), name, 1, "", "state=ACTIVE",
 				"snapshot_number", false, false, true)
 			if err != nil {
 				return fmt.Errorf("unable to list versions: %v", err)
 			}
 			if string(respBody) == "{}" {
-				if respBody, err = integrations.ListVersions(name, 1, "", "state=DRAFT",
+				if respBody, err = integrations.ListVersions(client.WithoutOutput(), name, 1, "", "state=DRAFT",
 					"snapshot_number", false, false, true); err != nil {
 					return fmt.Errorf("unable to list versions: %v", err)
 				}
@@ -80,15 +80,14 @@ var UnPublishVerCmd = &cobra.Command{
 			if err != nil {
 				return err
 			}
-			apiclient.EnableCmdPrintHttpResponse()
-			_, err = integrations.Unpublish(name, version)
+			_, err = integrations.Unpublish(client, name, version)
 			info = "version " + version
 		} else if version != "" {
-			_, err = integrations.Unpublish(name, version)
+			_, err = integrations.Unpublish(client, name, version)
 		} else if userLabel != "" {
-			_, err = integrations.UnpublishUserLabel(name, userLabel)
+			_, err = integrations.UnpublishUserLabel(client, name, userLabel)
 		} else if snapshot != "" {
-			_, err = integrations.UnpublishSnapshot(name, snapshot)
+			_, err = integrations.UnpublishSnapshot(client, name, snapshot)
 		}
 		if err == nil {
 			clilog.Debug.Printf("Integration %s %s unpublished successfully\n", name, info)
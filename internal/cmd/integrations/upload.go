@@ -44,6 +44,7 @@ var UploadCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		name := utils.GetStringParam(cmd.Flag("name"))
 
@@ -56,7 +57,7 @@ var UploadCmd = &cobra.Command{
 			return err
 		}
 
-		_, err = integrations.Upload(name, content)
+		_, err = integrations.Upload(client, name, content)
 
 		return err
 	},
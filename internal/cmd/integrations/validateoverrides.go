@@ -0,0 +1,81 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"fmt"
+	"internal/client/integrations"
+	"internal/clilog"
+	"internal/cmd/utils"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// ValidateOverridesCmd to validate an overrides file offline
+var ValidateOverridesCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate an overrides file against an integration flow",
+	Long: "Validate an overrides file against an integration flow without calling any APIs. " +
+		"Reports overrides that don't match the integration, missing mandatory fields and type mismatches",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			clilog.Debug.Printf("%s: %s\n", f.Name, f.Value)
+		})
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
+		content, err := utils.ReadFile(utils.GetStringParam(cmd.Flag("file")))
+		if err != nil {
+			return err
+		}
+		overridesContent, err := utils.ReadFile(utils.GetStringParam(cmd.Flag("overrides")))
+		if err != nil {
+			return err
+		}
+		return validateOverrides(content, overridesContent)
+	},
+	Example: `Validate an overrides file: ` + GetExample(19),
+}
+
+func init() {
+	var integrationFile, overridesFile string
+
+	ValidateOverridesCmd.Flags().StringVarP(&integrationFile, "file", "f",
+		"", "Integration flow JSON file path")
+	ValidateOverridesCmd.Flags().StringVarP(&overridesFile, "overrides", "o",
+		"", "Integration flow overrides file path")
+
+	_ = ValidateOverridesCmd.MarkFlagRequired("file")
+	_ = ValidateOverridesCmd.MarkFlagRequired("overrides")
+}
+
+// validateOverrides reports every problem found in the overrides and returns an error if there are any
+func validateOverrides(content []byte, overridesContent []byte) error {
+	problems, err := integrations.ValidateOverrides(content, overridesContent)
+	if err != nil {
+		return err
+	}
+	for _, problem := range problems {
+		clilog.Error.Println(problem)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("found %d problem(s) in the overrides", len(problems))
+	}
+	clilog.Info.Println("overrides are valid")
+	return nil
+}
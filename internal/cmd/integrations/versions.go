@@ -36,4 +36,5 @@ func init() {
 	VerCmd.AddCommand(DownloadVerCmd)
 	VerCmd.AddCommand(DelVerCmd)
 	VerCmd.AddCommand(TestCasesCmd)
+	VerCmd.AddCommand(DiffVerCmd)
 }
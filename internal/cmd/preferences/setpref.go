@@ -52,6 +52,25 @@ var SetCmd = &cobra.Command{
 			return err
 		}
 
+		// the TLS server name of an endpoint set is only verified for its endpoints
+		endpointsTLSServerName := ""
+		if endpointSet != "" {
+			endpointsTLSServerName, tlsServerName = tlsServerName, ""
+		}
+
+		if err = apiclient.SetTLSPref(caBundle, clientCert, clientKey, tlsServerName); err != nil {
+			return err
+		}
+
+		if err = apiclient.SetEndpointsPref(endpointSet, apiclient.Endpoints{
+			Integrations:     integrationsEndpoint,
+			Connectors:       connectorsEndpoint,
+			CustomConnectors: customConnectorsEndpoint,
+			TLSServerName:    endpointsTLSServerName,
+		}); err != nil {
+			return err
+		}
+
 		if nocheck {
 			if err = apiclient.SetNoCheck(nocheck); err != nil {
 				return err
@@ -64,11 +83,29 @@ var SetCmd = &cobra.Command{
 			}
 		}
 
+		if err = apiclient.SetRetryPref(maxAttempts, maxElapsedTime); err != nil {
+			return err
+		}
+
+		if err = apiclient.SetRateLimitPref(integrationRateLimit, connectorsRateLimit); err != nil {
+			return err
+		}
+
 		return nil
 	},
 }
 
-var nocheck bool
+var (
+	nocheck        bool
+	maxAttempts    int
+	maxElapsedTime string
+
+	integrationRateLimit, connectorsRateLimit int
+
+	caBundle, clientCert, clientKey, tlsServerName string
+
+	integrationsEndpoint, connectorsEndpoint, customConnectorsEndpoint, endpointSet string
+)
 
 func init() {
 	var project, region, proxyURL, basicInfo string
@@ -83,11 +120,47 @@ func init() {
 	SetCmd.Flags().StringVarP(&proxyURL, "proxy", "",
 		"", "Use http proxy before contacting the control plane")
 
+	SetCmd.Flags().StringVarP(&caBundle, "ca-bundle", "",
+		"", "File with PEM encoded CA certificates to trust in addition to the system roots")
+
+	SetCmd.Flags().StringVarP(&clientCert, "client-cert", "",
+		"", "File with the PEM encoded client certificate presented for mutual TLS")
+
+	SetCmd.Flags().StringVarP(&clientKey, "client-key", "",
+		"", "File with the PEM encoded private key of the client certificate")
+
+	SetCmd.Flags().StringVarP(&tlsServerName, "tls-server-name", "",
+		"", "Server name verified in the certificates of the custom endpoints, or of the endpoints of --endpoint-set")
+
+	SetCmd.Flags().StringVarP(&integrationsEndpoint, "integrations-endpoint", "",
+		"", "Endpoint of the integrations API, for example a Private Service Connect endpoint or an emulator")
+
+	SetCmd.Flags().StringVarP(&connectorsEndpoint, "connectors-endpoint", "",
+		"", "Endpoint of the connectors API")
+
+	SetCmd.Flags().StringVarP(&customConnectorsEndpoint, "custom-connectors-endpoint", "",
+		"", "Endpoint of the custom connectors API; default is the connectors endpoint")
+
+	SetCmd.Flags().StringVarP(&endpointSet, "endpoint-set", "",
+		"", "Name of the endpoint set the endpoints are saved to, selected with --api")
+
 	SetCmd.Flags().BoolVarP(&nocheck, "nocheck", "",
 		false, "Don't check for newer versions of cmd")
 
 	SetCmd.Flags().Var(&api, "api", "Sets the control plane API. Must be one of prod, "+
-		"staging or autopush; default is prod")
+		"staging, autopush or an endpoint set in the preferences; default is prod")
+
+	SetCmd.Flags().IntVarP(&maxAttempts, "max-attempts", "",
+		0, "Max attempts for requests that fail with transient errors")
+
+	SetCmd.Flags().StringVarP(&maxElapsedTime, "max-elapsed-time", "",
+		"", "Max time spent retrying a request, for example 2m")
+
+	SetCmd.Flags().IntVarP(&integrationRateLimit, "integration-rate-limit", "",
+		0, "Max requests per minute to the integrations API; default is 360")
+
+	SetCmd.Flags().IntVarP(&connectorsRateLimit, "connectors-rate-limit", "",
+		0, "Max requests per minute to the connectors API; default is 60")
 
 	SetCmd.Flags().StringVarP(&basicInfo, "basic", "",
 		"", "Retuens basic information for supported resources")
@@ -39,6 +39,7 @@ var Cmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		cloudKMS := utils.GetStringParam(cmd.Flag("cloudkms"))
 		serviceAccount := utils.GetStringParam(cmd.Flag("service-account"))
@@ -63,7 +64,7 @@ var Cmd = &cobra.Command{
 			}
 		}
 
-		_, err = provision.Provision(cloudKMS, samples, gmek, serviceAccount)
+		_, err = provision.Provision(client, cloudKMS, samples, gmek, serviceAccount)
 		return err
 	},
 }
@@ -31,7 +31,7 @@ var Cmd = &cobra.Command{
 
 		outputGCSPath := utils.GetStringParam(cmd.Flag("output-gcs-path"))
 		v, _, _ := apiclient.GetBuildParams()
-		err = apiclient.WriteManifest(outputGCSPath, v)
+		err = apiclient.DefaultClient().WriteManifest(outputGCSPath, v)
 		return
 	},
 }
@@ -37,6 +37,7 @@ import (
 	"net/http"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -47,6 +48,37 @@ var RootCmd = &cobra.Command{
 	Short: "Utility to work with GCP App Integration & Connectors",
 	Long:  "This command lets you interact with GCP Application Integration and Integration Connector APIs.",
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if logFileErr != nil {
+			return logFileErr
+		}
+		var resource string
+		if name := cmd.Flag("name"); name != nil {
+			resource = name.Value.String()
+		}
+		clilog.SetCommand(cmd.CommandPath())
+		clilog.SetResource(resource)
+
+		ctx := cmd.Context()
+		if timeout > 0 {
+			var cancel context.CancelCauseFunc
+			ctx, cancel = context.WithCancelCause(ctx)
+			time.AfterFunc(timeout, func() {
+				cancel(fmt.Errorf("command timed out after %s", timeout))
+			})
+		}
+		if trace != "" {
+			var err error
+			if stopTracing, err = apiclient.StartTracing(trace); err != nil {
+				return err
+			}
+			ctx, endCommandSpan = apiclient.StartSpan(ctx, cmd.CommandPath(), resource)
+		}
+		apiclient.SetContext(ctx)
+
+		if err := apiclient.SetQuery(query); err != nil {
+			return err
+		}
+
 		cmdServiceAccount := utils.GetStringParam(cmd.Flag("account"))
 		cmdToken := utils.GetStringParam(cmd.Flag("token"))
 
@@ -66,6 +98,33 @@ var RootCmd = &cobra.Command{
 			return fmt.Errorf("token and account flags cannot be used together")
 		}
 
+		if recordFolder != "" && replayFolder != "" {
+			return fmt.Errorf("record and replay cannot be used together")
+		}
+
+		if recordFolder != "" {
+			if err := apiclient.SetRecordFolder(recordFolder); err != nil {
+				return err
+			}
+		}
+
+		// the api in the preferences is used unless the flag is set
+		if api != "" {
+			apiclient.SetAPI(api)
+		}
+		if err := apiclient.CheckAPI(); err != nil {
+			return err
+		}
+
+		// replayed sessions need neither credentials nor the network
+		if replayFolder != "" {
+			if err := apiclient.SetReplayFolder(replayFolder); err != nil {
+				return err
+			}
+			apiclient.SetIntegrationToken("replay")
+			return nil
+		}
+
 		if !disableCheck {
 			if ok, _ := apiclient.TestAndUpdateLastCheck(); !ok {
 				latestVersion, _ := getLatestVersion()
@@ -78,8 +137,6 @@ var RootCmd = &cobra.Command{
 			}
 		}
 
-		apiclient.SetAPI(api)
-
 		if !metadataToken && !defaultToken {
 			apiclient.SetServiceAccount(cmdServiceAccount)
 			apiclient.SetIntegrationToken(cmdToken)
@@ -102,14 +159,43 @@ var RootCmd = &cobra.Command{
 }
 
 func Execute() {
-	if err := RootCmd.Execute(); err != nil {
+	if err := ExecuteContext(context.Background()); err != nil {
 		clilog.Error.Println(err)
 	}
 }
 
+// traceExportTimeout bounds the time spent exporting the trace when the command ends
+const traceExportTimeout = 5 * time.Second
+
+// ExecuteContext runs the command with the context and exports its trace when tracing is enabled
+func ExecuteContext(ctx context.Context) error {
+	err := RootCmd.ExecuteContext(ctx)
+	if endCommandSpan != nil {
+		endCommandSpan(err)
+	}
+	if stopTracing != nil {
+		// an unreachable collector must not keep the command from exiting
+		ctx, cancel := context.WithTimeout(context.Background(), traceExportTimeout)
+		defer cancel()
+		if traceErr := stopTracing(ctx); traceErr != nil {
+			clilog.Warning.Printf("unable to export the trace: %v\n", traceErr)
+		}
+	}
+	return err
+}
+
 var (
 	disableCheck, printOutput, noOutput, suppressWarnings, verbose, metadataToken, defaultToken bool
 	api                                                                                         apiclient.API
+	output                                                                                      apiclient.Output
+	logFormat                                                                                   clilog.Format
+	logFileErr                                                                                  error
+	maxAttempts                                                                                 int
+	maxElapsedTime, timeout                                                                     time.Duration
+	recordFolder, replayFolder, query, logFile, trace                                           string
+	caBundle, clientCert, clientKey, tlsServerName                                              string
+	endCommandSpan                                                                              func(error)
+	stopTracing                                                                                 func(context.Context) error
 )
 
 const ENABLED = "true"
@@ -146,8 +232,54 @@ func init() {
 	RootCmd.PersistentFlags().BoolVarP(&defaultToken, "default-token", "",
 		false, "Use Google default application credentials access token")
 
+	RootCmd.PersistentFlags().IntVarP(&maxAttempts, "max-attempts", "",
+		apiclient.DefaultMaxAttempts, "Max attempts for requests that fail with transient errors")
+
+	RootCmd.PersistentFlags().DurationVarP(&maxElapsedTime, "max-elapsed-time", "",
+		apiclient.DefaultMaxElapsedTime, "Max time spent retrying a request that fails with transient errors")
+
+	RootCmd.PersistentFlags().DurationVarP(&timeout, "timeout", "",
+		0, "Cancel the command and in-flight requests after the duration, for example 10m; default is no timeout")
+
+	RootCmd.PersistentFlags().StringVarP(&recordFolder, "record", "",
+		"", "Record requests and responses as cassette files in the folder; credentials and secrets are removed")
+
+	RootCmd.PersistentFlags().StringVarP(&replayFolder, "replay", "",
+		"", "Replay the responses recorded in the folder instead of calling the APIs")
+
 	RootCmd.PersistentFlags().Var(&api, "api", "Sets the control plane API. Must be one of prod, "+
-		"staging or autopush; default is prod")
+		"staging, autopush or an endpoint set in the preferences; default is prod")
+
+	RootCmd.PersistentFlags().Var(&output, "output", "Format of the printed responses. Must be one of json, "+
+		"yaml, table or csv; default is json")
+
+	RootCmd.PersistentFlags().StringVarP(&query, "query", "",
+		"", "JMESPath expression applied to the responses before they are printed, "+
+			"for example integrationVersions[?state=='ACTIVE'].name")
+
+	RootCmd.PersistentFlags().Var(&logFormat, "log-format", "Format of the log statements. Must be one of "+
+		"text or json; default is text")
+
+	RootCmd.PersistentFlags().StringVarP(&logFile, "log-file", "",
+		"", "Write the debug logs and a copy of warnings and errors to the file; secrets are redacted")
+
+	RootCmd.PersistentFlags().StringVarP(&caBundle, "ca-bundle", "",
+		"", "File with PEM encoded CA certificates to trust in addition to the system roots, "+
+			"for example the CA of a TLS inspecting proxy")
+
+	RootCmd.PersistentFlags().StringVarP(&clientCert, "client-cert", "",
+		"", "File with the PEM encoded client certificate presented for mutual TLS")
+
+	RootCmd.PersistentFlags().StringVarP(&clientKey, "client-key", "",
+		"", "File with the PEM encoded private key of the client certificate")
+
+	RootCmd.PersistentFlags().StringVarP(&tlsServerName, "tls-server-name", "",
+		"", "Server name verified in the certificates of the integrations and connectors APIs, when they are "+
+			"reached through a Private Service Connect or custom endpoint hostname")
+
+	RootCmd.PersistentFlags().StringVarP(&trace, "trace", "",
+		"", "Trace the command and its requests. Set to otlp to export the spans to the endpoint in "+
+			"OTEL_EXPORTER_OTLP_ENDPOINT, or to a file name to write them as json")
 
 	RootCmd.AddCommand(integrations.Cmd)
 	RootCmd.AddCommand(preferences.Cmd)
@@ -181,9 +313,12 @@ func initConfig() {
 		clilog.Debug.Println("integrationcli ratelimit is disabled")
 		apiclient.SetRate(apiclient.None)
 	} else {
-		apiclient.SetRate(apiclient.IntegrationAPI)
+		apiclient.SetRate(apiclient.Automatic)
 	}
 
+	clilog.SetFormat(logFormat)
+	logFileErr = clilog.SetLogFile(logFile)
+
 	apiclient.NewIntegrationClient(apiclient.IntegrationClientOptions{
 		TokenCheck:    true,
 		PrintOutput:   printOutput,
@@ -191,7 +326,20 @@ func initConfig() {
 		DebugLog:      debug,
 		SkipCache:     skipCache,
 		MetadataToken: metadataToken,
+		Output:        output,
+		CABundle:      caBundle,
+		ClientCert:    clientCert,
+		ClientKey:     clientKey,
+		TLSServerName: tlsServerName,
 	})
+
+	// flags override the retry policy in preferences
+	if RootCmd.PersistentFlags().Changed("max-attempts") {
+		apiclient.SetMaxAttempts(maxAttempts)
+	}
+	if RootCmd.PersistentFlags().Changed("max-elapsed-time") {
+		apiclient.SetMaxElapsedTime(maxElapsedTime)
+	}
 }
 
 // GetRootCmd returns the root of the cobra command-tree.
@@ -207,7 +355,7 @@ func getLatestVersion() (version string, err error) {
 	client := &http.Client{}
 	contentType := "application/json"
 
-	ctx := context.Background()
+	ctx := apiclient.GetContext()
 
 	req, err = http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
 	if err != nil {
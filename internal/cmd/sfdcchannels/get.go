@@ -44,6 +44,7 @@ var GetCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		instance := utils.GetStringParam(cmd.Flag("instance"))
 		name := utils.GetStringParam(cmd.Flag("name"))
@@ -51,15 +52,13 @@ var GetCmd = &cobra.Command{
 		minimal, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("minimal")))
 
 		if name != "" {
-			apiclient.DisableCmdPrintHttpResponse()
-			_, respBody, err := sfdc.FindChannel(name, instance)
+			_, respBody, err := sfdc.FindChannel(client.WithoutOutput(), name, instance)
 			if err != nil {
 				return err
 			}
-			apiclient.EnableCmdPrintHttpResponse()
-			apiclient.PrettyPrint(respBody)
+			return client.PrettyPrint(respBody)
 		} else {
-			_, err = sfdc.GetChannel(id, instance, minimal)
+			_, err = sfdc.GetChannel(client, id, instance, minimal)
 		}
 		return err
 	},
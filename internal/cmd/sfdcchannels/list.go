@@ -43,9 +43,10 @@ var ListCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		instance := utils.GetStringParam(cmd.Flag("instance"))
-		_, err = sfdc.ListChannels(instance)
+		_, err = sfdc.ListChannels(client, instance)
 		return
 	},
 }
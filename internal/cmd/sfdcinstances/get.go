@@ -53,21 +53,20 @@ var GetCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
 		name := utils.GetStringParam(cmd.Flag("name"))
 		id := utils.GetStringParam(cmd.Flag("id"))
 		minimal, _ := strconv.ParseBool(utils.GetStringParam(cmd.Flag("minimal")))
 
 		if name != "" {
-			apiclient.DisableCmdPrintHttpResponse()
-			_, respBody, err := sfdc.FindInstance(name)
+			_, respBody, err := sfdc.FindInstance(client.WithoutOutput(), name)
 			if err != nil {
 				return err
 			}
-			apiclient.EnableCmdPrintHttpResponse()
-			apiclient.PrettyPrint(respBody)
+			return client.PrettyPrint(respBody)
 		} else {
-			_, err = sfdc.GetInstance(id, minimal)
+			_, err = sfdc.GetInstance(client, id, minimal)
 		}
 		return err
 	},
@@ -43,8 +43,9 @@ var ListCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
+		client := apiclient.DefaultClient()
 
-		_, err = sfdc.ListInstances()
+		_, err = sfdc.ListInstances(client)
 		return
 	},
 }
@@ -15,10 +15,13 @@
 package utils
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"internal/apiclient"
 	"io"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -259,6 +262,74 @@ func ReadFile(filePath string) (byteValue []byte, err error) {
 	return byteValue, err
 }
 
+// variableRegex matches ${VAR} references; $${VAR} is an escaped reference
+var variableRegex = regexp.MustCompile(`\$?\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// Interpolate replaces ${VAR} references in the json content with the value returned by lookup.
+// Inside a json string the value is escaped. Elsewhere a value that is a json value, for example
+// a number or an object, is written as is and any other value is written as a json string.
+// $${VAR} is written as a literal ${VAR}. All unresolved variables are returned as an error.
+func Interpolate(content []byte, lookup func(string) (string, bool)) ([]byte, error) {
+	var unresolved []string
+	var result bytes.Buffer
+	inString, escaped := false, false
+	last := 0
+	for _, match := range variableRegex.FindAllSubmatchIndex(content, -1) {
+		// track whether the reference is inside a json string
+		for _, b := range content[last:match[0]] {
+			switch {
+			case escaped:
+				escaped = false
+			case inString && b == '\\':
+				escaped = true
+			case b == '"':
+				inString = !inString
+			}
+		}
+		result.Write(content[last:match[0]])
+		last = match[1]
+
+		if content[match[0]+1] == '$' {
+			result.Write(content[match[0]+1 : match[1]])
+			continue
+		}
+		name := string(content[match[2]:match[3]])
+		value, ok := lookup(name)
+		if !ok {
+			unresolved = append(unresolved, name)
+			continue
+		}
+		if !inString && json.Valid([]byte(value)) {
+			result.WriteString(value)
+			continue
+		}
+		quoted, err := marshalString(value)
+		if err != nil {
+			return nil, err
+		}
+		if inString {
+			quoted = quoted[1 : len(quoted)-1]
+		}
+		result.Write(quoted)
+	}
+	result.Write(content[last:])
+	if len(unresolved) > 0 {
+		return nil, fmt.Errorf("unresolved variables: %s", strings.Join(unresolved, ", "))
+	}
+	return result.Bytes(), nil
+}
+
+// marshalString returns the value as a json string without escaping html characters
+func marshalString(value string) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSpace(buf.Bytes()), nil
+}
+
 func GetStringParam(flag *pflag.Flag) (param string) {
 	param = ""
 	if flag != nil {
@@ -292,3 +363,23 @@ func GetBasicInfo(cmd *cobra.Command, flag string) bool {
 
 	return pref
 }
+
+// AddListAllFlags adds the flags to request every page of a list command
+func AddListAllFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("all", false, "Request every page and return the merged result")
+	cmd.Flags().Int("limit", 0, "The maximum number of items returned with --all; default is no limit")
+	cmd.MarkFlagsMutuallyExclusive("all", "pageToken")
+}
+
+// GetListAllFlags returns whether every page is requested and the max number of items
+func GetListAllFlags(cmd *cobra.Command) (all bool, limit int, err error) {
+	all, _ = cmd.Flags().GetBool("all")
+	limit, _ = cmd.Flags().GetInt("limit")
+	if limit < 0 {
+		return false, 0, fmt.Errorf("limit must be greater than 0")
+	}
+	if limit > 0 && !all {
+		return false, 0, fmt.Errorf("limit can only be used with --all")
+	}
+	return all, limit, nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestInterpolate(t *testing.T) {
+	vars := map[string]string{"PROJECT_ID": "my-project", "URL": "https://example.com"}
+	lookup := func(name string) (string, bool) {
+		value, ok := vars[name]
+		return value, ok
+	}
+
+	got, err := Interpolate([]byte(`{"project":"${PROJECT_ID}","url":"${URL}/v1","literal":"$${URL}","js":"$URL"}`), lookup)
+	if err != nil {
+		t.Fatalf("Interpolate failed: %v", err)
+	}
+	want := `{"project":"my-project","url":"https://example.com/v1","literal":"${URL}","js":"$URL"}`
+	if string(got) != want {
+		t.Errorf("unexpected result %s", string(got))
+	}
+
+	// values are escaped inside json strings and cannot add fields
+	vars["QUOTED"] = `say "hi" \ bye` + "\n"
+	vars["INJECTED"] = `1, "admin": true`
+	vars["PORT"] = "8080"
+	vars["LABELS"] = `{"team":"a"}`
+	got, err = Interpolate([]byte(`{"text":"${QUOTED}","escaped":"\"${URL}\"","size":${INJECTED},"port":${PORT},"labels":${LABELS}}`), lookup)
+	if err != nil {
+		t.Fatalf("Interpolate failed: %v", err)
+	}
+	want = `{"text":"say \"hi\" \\ bye\n","escaped":"\"https://example.com\"","size":"1, \"admin\": true","port":8080,"labels":{"team":"a"}}`
+	if string(got) != want {
+		t.Errorf("unexpected result %s", string(got))
+	}
+	parsed := map[string]interface{}{}
+	if err = json.Unmarshal(got, &parsed); err != nil || parsed["text"] != vars["QUOTED"] {
+		t.Errorf("expected valid json with the value of QUOTED, got %v: %v", parsed, err)
+	}
+
+	if _, err = Interpolate([]byte(`${MISSING_A} ${URL} ${MISSING_B}`), lookup); err == nil ||
+		err.Error() != "unresolved variables: MISSING_A, MISSING_B" {
+		t.Errorf("unexpected error %v", err)
+	}
+}
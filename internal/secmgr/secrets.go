@@ -15,7 +15,6 @@
 package secmgr
 
 import (
-	"context"
 	"fmt"
 	"internal/apiclient"
 
@@ -24,14 +23,14 @@ import (
 )
 
 // secretExists the latest secret version
-func secretExists(project string, name string) (version string, err error) {
+func secretExists(client *apiclient.Client, project string, name string) (version string, err error) {
 	// Create the client.
-	ctx := context.Background()
-	client, err := secretmanager.NewClient(ctx)
+	ctx := client.GetContext()
+	c, err := secretmanager.NewClient(ctx)
 	if err != nil {
 		return "", err
 	}
-	defer client.Close()
+	defer c.Close()
 
 	// Build the request.
 	req := &secretmanagerpb.GetSecretVersionRequest{
@@ -39,7 +38,7 @@ func secretExists(project string, name string) (version string, err error) {
 	}
 
 	// Call the API.
-	secretVersion, err := client.GetSecretVersion(ctx, req)
+	secretVersion, err := c.GetSecretVersion(ctx, req)
 	if err != nil {
 		return "", err
 	}
@@ -48,12 +47,12 @@ func secretExists(project string, name string) (version string, err error) {
 }
 
 // Create a new secret in secret manager
-func Create(project string, secretId string, payload []byte) (version string, err error) {
-	if version, err = secretExists(project, secretId); err == nil {
+func Create(client *apiclient.Client, project string, secretId string, payload []byte) (version string, err error) {
+	if version, err = secretExists(client, project, secretId); err == nil {
 		return version, nil // secret exists, return
 	}
 
-	ctx := context.Background()
+	ctx := client.GetContext()
 
 	c, err := secretmanager.NewClient(ctx)
 	if err != nil {
@@ -63,7 +62,7 @@ func Create(project string, secretId string, payload []byte) (version string, er
 
 	// secret manager location
 	replica := &secretmanagerpb.Replication_UserManaged_Replica{}
-	replica.Location = apiclient.GetRegion()
+	replica.Location = client.GetRegion()
 
 	replicas := []*secretmanagerpb.Replication_UserManaged_Replica{}
 	replicas = append(replicas, replica)
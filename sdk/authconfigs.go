@@ -0,0 +1,127 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"path"
+)
+
+// AuthConfig holds the credentials used by integration tasks
+type AuthConfig struct {
+	Name                string               `json:"name,omitempty"`
+	DisplayName         string               `json:"displayName,omitempty"`
+	Description         string               `json:"description,omitempty"`
+	EncryptedCredential *string              `json:"encryptedCredential,omitempty"`
+	DecryptedCredential *DecryptedCredential `json:"decryptedCredential,omitempty"`
+	CreatorEmail        string               `json:"creatorEmail,omitempty"`
+	CreateTime          string               `json:"createTime,omitempty"`
+	LastModifierEmail   string               `json:"lastModifierEmail,omitempty"`
+	Visibility          string               `json:"visibility,omitempty"`
+	State               string               `json:"state,omitempty"`
+	Reason              string               `json:"reason,omitempty"`
+	ValidTime           string               `json:"validTime,omitempty"`
+}
+
+// AuthConfigList is a page of auth configs
+type AuthConfigList struct {
+	AuthConfigs   []AuthConfig `json:"authConfigs,omitempty"`
+	NextPageToken string       `json:"nextPageToken,omitempty"`
+}
+
+// DecryptedCredential holds one of the credential types of an auth config
+type DecryptedCredential struct {
+	CredentialType                 string                          `json:"credentialType,omitempty"`
+	UsernameAndPassword            *UsernameAndPassword            `json:"usernameAndPassword,omitempty"`
+	OidcToken                      *OidcToken                      `json:"oidcToken,omitempty"`
+	Jwt                            *Jwt                            `json:"jwt,omitempty"`
+	ServiceAccountCredentials      *ServiceAccountCredentials      `json:"serviceAccountCredentials,omitempty"`
+	AuthToken                      *AuthToken                      `json:"authToken,omitempty"`
+	OAuth2ResourceOwnerCredentials *OAuth2ResourceOwnerCredentials `json:"oauth2ResourceOwnerCredentials,omitempty"`
+}
+
+type UsernameAndPassword struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+type OidcToken struct {
+	ServiceAccountEmail string `json:"serviceAccountEmail,omitempty"`
+	Audience            string `json:"audience,omitempty"`
+}
+
+type Jwt struct {
+	JwtHeader  string `json:"jwtHeader,omitempty"`
+	JwtPayload string `json:"jwtPayload,omitempty"`
+	Secret     string `json:"secret,omitempty"`
+}
+
+type ServiceAccountCredentials struct {
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+	Scope          string `json:"scope,omitempty"`
+}
+
+type AuthToken struct {
+	Type  string `json:"type,omitempty"`
+	Token string `json:"token,omitempty"`
+}
+
+type OAuth2ResourceOwnerCredentials struct {
+	ClientId      string `json:"clientId,omitempty"`
+	ClientSecret  string `json:"clientSecret,omitempty"`
+	Username      string `json:"username,omitempty"`
+	Password      string `json:"password,omitempty"`
+	TokenEndpoint string `json:"tokenEndpoint,omitempty"`
+	RequestType   string `json:"requestType,omitempty"`
+	Scope         string `json:"scope,omitempty"`
+}
+
+// ID returns the auth config id from the resource name of the auth config
+func (a AuthConfig) ID() string {
+	return path.Base(a.Name)
+}
+
+// ListAuthConfigs returns a page of the auth configs in the region
+func (c *Client) ListAuthConfigs(ctx context.Context, opts ListOptions) (*AuthConfigList, error) {
+	l := &AuthConfigList{}
+	if err := c.do(ctx, http.MethodGet, withListOptions(c.integrationsURL("authConfigs"), opts), nil, l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// GetAuthConfig returns an auth config by id
+func (c *Client) GetAuthConfig(ctx context.Context, id string) (*AuthConfig, error) {
+	a := &AuthConfig{}
+	if err := c.do(ctx, http.MethodGet, c.integrationsURL("authConfigs", id), nil, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// CreateAuthConfig creates an auth config, the response contains the id in the name
+func (c *Client) CreateAuthConfig(ctx context.Context, authConfig *AuthConfig) (*AuthConfig, error) {
+	a := &AuthConfig{}
+	if err := c.do(ctx, http.MethodPost, c.integrationsURL("authConfigs"), authConfig, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// DeleteAuthConfig deletes an auth config by id
+func (c *Client) DeleteAuthConfig(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, c.integrationsURL("authConfigs", id), nil, nil)
+}
@@ -0,0 +1,255 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sdk is a typed Go client for the Application Integration and
+// Integration Connectors APIs. It offers the operations of integrationcli
+// to Go programs without shelling out to the binary.
+//
+//	client, err := sdk.NewClient(ctx, sdk.Options{ProjectID: "my-project", Region: "us-west1"})
+//	if err != nil {
+//		return err
+//	}
+//	versions, err := client.ListVersions(ctx, "my-integration", sdk.ListOptions{})
+//
+// Unlike integrationcli, the client does not retry, back off or rate limit the requests:
+// each method sends one request and returns an APIError for a 429 or 5xx response.
+// Programs that need retries or a rate limit set Options.HTTPClient to a client whose
+// transport implements them. List methods return one page, request the next one with
+// the NextPageToken of the response. Connection changes are long-running operations,
+// WaitOperation polls them until they are done; there is no other helper for them.
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	integrationsEndpoint = "https://%s-integrations.googleapis.com"
+	connectorsEndpoint   = "https://connectors.googleapis.com"
+	cloudPlatformScope   = "https://www.googleapis.com/auth/cloud-platform"
+)
+
+// Options configure a Client
+type Options struct {
+	// ProjectID is the GCP project that hosts the integrations
+	ProjectID string
+	// Region is the integration region, for example us-west1
+	Region string
+	// Token is a static access token. Token is ignored when TokenSource is set
+	Token string
+	// TokenSource supplies access tokens. When neither Token nor TokenSource is set,
+	// the application default credentials are used
+	TokenSource oauth2.TokenSource
+	// HTTPClient sends the requests, http.DefaultClient is used when nil. Its transport
+	// may add the retries and rate limiting that the client does not do
+	HTTPClient *http.Client
+	// IntegrationsEndpoint overrides the scheme and host of the Application Integration API
+	IntegrationsEndpoint string
+	// ConnectorsEndpoint overrides the scheme and host of the Integration Connectors API
+	ConnectorsEndpoint string
+}
+
+// Client calls the Application Integration and Integration Connectors APIs
+type Client struct {
+	projectID            string
+	region               string
+	tokenSource          oauth2.TokenSource
+	httpClient           *http.Client
+	integrationsEndpoint string
+	connectorsEndpoint   string
+}
+
+// ListOptions page and filter list operations
+type ListOptions struct {
+	PageSize  int
+	PageToken string
+	Filter    string
+	OrderBy   string
+}
+
+// APIError is returned when the API responds with an error status code
+type APIError struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+	Status  string `json:"status,omitempty"`
+}
+
+func (e *APIError) Error() string {
+	if e.Status != "" {
+		return fmt.Sprintf("%d %s: %s", e.Code, e.Status, e.Message)
+	}
+	return fmt.Sprintf("%d: %s", e.Code, e.Message)
+}
+
+// IsNotFound returns true if err is an APIError with status code 404
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound
+}
+
+// NewClient returns a Client for the project and region in the options
+func NewClient(ctx context.Context, opts Options) (*Client, error) {
+	if opts.ProjectID == "" {
+		return nil, errors.New("project id is mandatory")
+	}
+	if opts.Region == "" {
+		return nil, errors.New("region is mandatory")
+	}
+
+	c := &Client{
+		projectID:            opts.ProjectID,
+		region:               opts.Region,
+		tokenSource:          opts.TokenSource,
+		httpClient:           opts.HTTPClient,
+		integrationsEndpoint: opts.IntegrationsEndpoint,
+		connectorsEndpoint:   opts.ConnectorsEndpoint,
+	}
+	if c.tokenSource == nil {
+		if opts.Token != "" {
+			c.tokenSource = oauth2.StaticTokenSource(&oauth2.Token{AccessToken: opts.Token})
+		} else {
+			tokenSource, err := google.DefaultTokenSource(ctx, cloudPlatformScope)
+			if err != nil {
+				return nil, err
+			}
+			c.tokenSource = tokenSource
+		}
+	}
+	if c.httpClient == nil {
+		c.httpClient = http.DefaultClient
+	}
+	if c.integrationsEndpoint == "" {
+		c.integrationsEndpoint = fmt.Sprintf(integrationsEndpoint, opts.Region)
+	}
+	if c.connectorsEndpoint == "" {
+		c.connectorsEndpoint = connectorsEndpoint
+	}
+	return c, nil
+}
+
+// ProjectID returns the project of the client
+func (c *Client) ProjectID() string {
+	return c.projectID
+}
+
+// Region returns the region of the client
+func (c *Client) Region() string {
+	return c.region
+}
+
+// integrationsURL returns the url of a resource in the integrations API
+func (c *Client) integrationsURL(elem ...string) string {
+	return c.endpointURL(c.integrationsEndpoint, c.region, elem...)
+}
+
+// connectorsURL returns the url of a resource in the connectors API
+func (c *Client) connectorsURL(elem ...string) string {
+	return c.endpointURL(c.connectorsEndpoint, c.region, elem...)
+}
+
+func (c *Client) endpointURL(endpoint string, region string, elem ...string) string {
+	p := path.Join(append([]string{"/v1/projects", c.projectID, "locations", region}, elem...)...)
+	return endpoint + p
+}
+
+// withListOptions adds the paging and filter query parameters to the url
+func withListOptions(u string, opts ListOptions) string {
+	q := url.Values{}
+	if opts.PageSize > 0 {
+		q.Set("pageSize", strconv.Itoa(opts.PageSize))
+	}
+	if opts.PageToken != "" {
+		q.Set("pageToken", opts.PageToken)
+	}
+	if opts.Filter != "" {
+		q.Set("filter", opts.Filter)
+	}
+	if opts.OrderBy != "" {
+		q.Set("orderBy", opts.OrderBy)
+	}
+	if len(q) == 0 {
+		return u
+	}
+	return u + "?" + q.Encode()
+}
+
+// do sends the request with the json encoding of in as payload and decodes the response into out.
+// in and out may be nil. The request is sent once, an error status is not retried
+func (c *Client) do(ctx context.Context, method string, u string, in interface{}, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return err
+	}
+	token, err := c.tokenSource.Token()
+	if err != nil {
+		return err
+	}
+	token.SetAuthHeader(req)
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode > 399 {
+		return newAPIError(resp.StatusCode, respBody)
+	}
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+	return json.Unmarshal(respBody, out)
+}
+
+// newAPIError parses the google error payload, the raw body is the message if it cannot be parsed
+func newAPIError(statusCode int, body []byte) error {
+	payload := struct {
+		Error *APIError `json:"error,omitempty"`
+	}{}
+	if err := json.Unmarshal(body, &payload); err == nil && payload.Error != nil {
+		if payload.Error.Code == 0 {
+			payload.Error.Code = statusCode
+		}
+		return payload.Error
+	}
+	return &APIError{Code: statusCode, Message: string(body)}
+}
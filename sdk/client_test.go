@@ -0,0 +1,223 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"context"
+	"internal/client/clienttest"
+	"testing"
+	"time"
+)
+
+func newFakeClient(t *testing.T) (*Client, *clienttest.FakeServer) {
+	f := clienttest.NewFakeServer()
+	t.Cleanup(f.Server.Close)
+	c, err := NewClient(context.Background(), Options{
+		ProjectID:            clienttest.FakeProjectID,
+		Region:               clienttest.FakeRegion,
+		Token:                "fake-token",
+		IntegrationsEndpoint: f.URL,
+		ConnectorsEndpoint:   f.URL,
+	})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	return c, f
+}
+
+func TestNewClient(t *testing.T) {
+	if _, err := NewClient(context.Background(), Options{Region: "us-west1", Token: "t"}); err == nil {
+		t.Errorf("expected an error without a project id")
+	}
+	if _, err := NewClient(context.Background(), Options{ProjectID: "p", Token: "t"}); err == nil {
+		t.Errorf("expected an error without a region")
+	}
+	c, err := NewClient(context.Background(), Options{ProjectID: "p", Region: "us-west1", Token: "t"})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	want := "https://us-west1-integrations.googleapis.com/v1/projects/p/locations/us-west1/integrations/i"
+	if got := c.integrationsURL("integrations", "i"); got != want {
+		t.Errorf("integrationsURL() = %s, want %s", got, want)
+	}
+	want = "https://connectors.googleapis.com/v1/projects/p/locations/us-west1/connections"
+	if got := c.connectorsURL("connections"); got != want {
+		t.Errorf("connectorsURL() = %s, want %s", got, want)
+	}
+}
+
+func TestVersions(t *testing.T) {
+	c, _ := newFakeClient(t)
+	ctx := context.Background()
+
+	v, err := c.CreateVersion(ctx, "sample", &IntegrationVersion{
+		Description: "first",
+		TriggerConfigs: []TriggerConfig{{
+			TriggerType: "API",
+			TriggerId:   "api_trigger/sample",
+		}},
+	})
+	if err != nil {
+		t.Fatalf("CreateVersion failed: %v", err)
+	}
+	if v.SnapshotNumber != "1" || v.ID() == "" {
+		t.Fatalf("unexpected version %+v", v)
+	}
+	if _, err = c.CreateVersion(ctx, "sample", &IntegrationVersion{Description: "second"}); err != nil {
+		t.Fatalf("CreateVersion failed: %v", err)
+	}
+
+	if err = c.PublishVersion(ctx, "sample", v.ID(), nil); err != nil {
+		t.Fatalf("PublishVersion failed: %v", err)
+	}
+	published, err := c.GetVersion(ctx, "sample", v.ID())
+	if err != nil {
+		t.Fatalf("GetVersion failed: %v", err)
+	}
+	if published.State != "ACTIVE" || published.TriggerConfigs[0].TriggerId != "api_trigger/sample" {
+		t.Errorf("unexpected published version %+v", published)
+	}
+
+	l, err := c.ListVersions(ctx, "sample", ListOptions{PageSize: 1})
+	if err != nil {
+		t.Fatalf("ListVersions failed: %v", err)
+	}
+	if len(l.IntegrationVersions) != 1 || l.IntegrationVersions[0].SnapshotNumber != "2" || l.NextPageToken == "" {
+		t.Errorf("unexpected first page %+v", l)
+	}
+
+	s, err := c.GetVersionBySnapshot(ctx, "sample", "1")
+	if err != nil {
+		t.Fatalf("GetVersionBySnapshot failed: %v", err)
+	}
+	if s.ID() != v.ID() {
+		t.Errorf("GetVersionBySnapshot() = %s, want %s", s.ID(), v.ID())
+	}
+	if _, err = c.GetVersionBySnapshot(ctx, "sample", "5"); !IsNotFound(err) {
+		t.Errorf("expected not found, got %v", err)
+	}
+
+	integrations, err := c.ListIntegrations(ctx, ListOptions{})
+	if err != nil {
+		t.Fatalf("ListIntegrations failed: %v", err)
+	}
+	if len(integrations.Integrations) != 1 {
+		t.Errorf("expected one integration, got %+v", integrations)
+	}
+
+	if err = c.DeleteIntegration(ctx, "sample"); err != nil {
+		t.Fatalf("DeleteIntegration failed: %v", err)
+	}
+	if _, err = c.GetVersion(ctx, "sample", v.ID()); !IsNotFound(err) {
+		t.Errorf("expected not found after delete, got %v", err)
+	}
+}
+
+func TestTestCases(t *testing.T) {
+	c, _ := newFakeClient(t)
+	ctx := context.Background()
+
+	v, err := c.CreateVersion(ctx, "sample", &IntegrationVersion{})
+	if err != nil {
+		t.Fatalf("CreateVersion failed: %v", err)
+	}
+	tc, err := c.CreateTestCase(ctx, "sample", v.ID(), &TestCase{DisplayName: "happy path", TriggerId: "api_trigger/sample"})
+	if err != nil {
+		t.Fatalf("CreateTestCase failed: %v", err)
+	}
+	l, err := c.ListTestCases(ctx, "sample", v.ID(), ListOptions{})
+	if err != nil {
+		t.Fatalf("ListTestCases failed: %v", err)
+	}
+	if len(l.TestCases) != 1 || l.TestCases[0].DisplayName != "happy path" {
+		t.Errorf("unexpected test cases %+v", l)
+	}
+	r, err := c.ExecuteTestCase(ctx, "sample", v.ID(), tc.ID(), nil)
+	if err != nil {
+		t.Fatalf("ExecuteTestCase failed: %v", err)
+	}
+	if r.TestExecutionState != "PASSED" {
+		t.Errorf("unexpected result %+v", r)
+	}
+	if err = c.DeleteTestCase(ctx, "sample", v.ID(), tc.ID()); err != nil {
+		t.Fatalf("DeleteTestCase failed: %v", err)
+	}
+}
+
+func TestAuthConfigs(t *testing.T) {
+	c, _ := newFakeClient(t)
+	ctx := context.Background()
+
+	a, err := c.CreateAuthConfig(ctx, &AuthConfig{
+		DisplayName: "token",
+		DecryptedCredential: &DecryptedCredential{
+			CredentialType: "AUTH_TOKEN",
+			AuthToken:      &AuthToken{Type: "Bearer", Token: "secret"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateAuthConfig failed: %v", err)
+	}
+	got, err := c.GetAuthConfig(ctx, a.ID())
+	if err != nil {
+		t.Fatalf("GetAuthConfig failed: %v", err)
+	}
+	if got.DisplayName != "token" || got.DecryptedCredential.AuthToken.Type != "Bearer" {
+		t.Errorf("unexpected auth config %+v", got)
+	}
+	if err = c.DeleteAuthConfig(ctx, a.ID()); err != nil {
+		t.Fatalf("DeleteAuthConfig failed: %v", err)
+	}
+	l, err := c.ListAuthConfigs(ctx, ListOptions{})
+	if err != nil {
+		t.Fatalf("ListAuthConfigs failed: %v", err)
+	}
+	if len(l.AuthConfigs) != 0 {
+		t.Errorf("expected no auth configs, got %+v", l)
+	}
+}
+
+func TestConnections(t *testing.T) {
+	c, _ := newFakeClient(t)
+	ctx := context.Background()
+
+	o, err := c.CreateConnection(ctx, "pubsub", &Connection{
+		ConnectorVersion: "projects/p/locations/global/providers/gcp/connectors/pubsub/versions/1",
+		ConfigVariables:  []ConfigVariable{{Key: "project_id", StringValue: strPtr("p")}},
+	})
+	if err != nil {
+		t.Fatalf("CreateConnection failed: %v", err)
+	}
+	if _, err = c.WaitOperation(ctx, o.Name, 10*time.Millisecond); err != nil {
+		t.Fatalf("WaitOperation failed: %v", err)
+	}
+	conn, err := c.GetConnection(ctx, "pubsub")
+	if err != nil {
+		t.Fatalf("GetConnection failed: %v", err)
+	}
+	if conn.ID() != "pubsub" || *conn.ConfigVariables[0].StringValue != "p" {
+		t.Errorf("unexpected connection %+v", conn)
+	}
+	if _, err = c.DeleteConnection(ctx, "pubsub"); err != nil {
+		t.Fatalf("DeleteConnection failed: %v", err)
+	}
+	if _, err = c.GetConnection(ctx, "pubsub"); !IsNotFound(err) {
+		t.Errorf("expected not found after delete, got %v", err)
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,213 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+// Connection is an Integration Connectors connection
+type Connection struct {
+	Name               string              `json:"name,omitempty"`
+	Labels             map[string]string   `json:"labels,omitempty"`
+	Description        string              `json:"description,omitempty"`
+	ConnectorVersion   string              `json:"connectorVersion,omitempty"`
+	ConfigVariables    []ConfigVariable    `json:"configVariables,omitempty"`
+	AuthConfig         *ConnectionAuth     `json:"authConfig,omitempty"`
+	NodeConfig         *NodeConfig         `json:"nodeConfig,omitempty"`
+	DestinationConfigs []DestinationConfig `json:"destinationConfigs,omitempty"`
+	ServiceAccount     string              `json:"serviceAccount,omitempty"`
+	Suspended          bool                `json:"suspended,omitempty"`
+	LogConfig          *LogConfig          `json:"logConfig,omitempty"`
+	Status             *ConnectionStatus   `json:"status,omitempty"`
+	CreateTime         string              `json:"createTime,omitempty"`
+	UpdateTime         string              `json:"updateTime,omitempty"`
+	// SslConfig and EventingConfig are passed through unchanged
+	SslConfig              json.RawMessage `json:"sslConfig,omitempty"`
+	EventingEnablementType string          `json:"eventingEnablementType,omitempty"`
+	EventingConfig         json.RawMessage `json:"eventingConfig,omitempty"`
+}
+
+// ConnectionList is a page of connections
+type ConnectionList struct {
+	Connections   []Connection `json:"connections,omitempty"`
+	NextPageToken string       `json:"nextPageToken,omitempty"`
+}
+
+type ConnectionStatus struct {
+	State       string `json:"state,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// ConfigVariable is a connector setting
+type ConfigVariable struct {
+	Key         string  `json:"key,omitempty"`
+	IntValue    *string `json:"intValue,omitempty"`
+	BoolValue   *bool   `json:"boolValue,omitempty"`
+	StringValue *string `json:"stringValue,omitempty"`
+	SecretValue *Secret `json:"secretValue,omitempty"`
+}
+
+// ConnectionAuth is the authentication of a connection to the backend
+type ConnectionAuth struct {
+	AuthType                string                   `json:"authType,omitempty"`
+	UserPassword            *UserPassword            `json:"userPassword,omitempty"`
+	Oauth2JwtBearer         *Oauth2JwtBearer         `json:"oauth2JwtBearer,omitempty"`
+	Oauth2ClientCredentials *Oauth2ClientCredentials `json:"oauth2ClientCredentials,omitempty"`
+	SshPublicKey            *SshPublicKey            `json:"sshPublicKey,omitempty"`
+	AdditionalVariables     []ConfigVariable         `json:"additionalVariables,omitempty"`
+	AuthKey                 string                   `json:"authKey,omitempty"`
+}
+
+// Secret is a Secret Manager secret version
+type Secret struct {
+	SecretVersion string `json:"secretVersion,omitempty"`
+}
+
+type UserPassword struct {
+	Username string  `json:"username,omitempty"`
+	Password *Secret `json:"password,omitempty"`
+}
+
+type Oauth2JwtBearer struct {
+	ClientKey *Secret   `json:"clientKey,omitempty"`
+	JwtClaims JwtClaims `json:"jwtClaims,omitempty"`
+}
+
+type JwtClaims struct {
+	Issuer   string `json:"issuer,omitempty"`
+	Subject  string `json:"subject,omitempty"`
+	Audience string `json:"audience,omitempty"`
+}
+
+type Oauth2ClientCredentials struct {
+	ClientId     string  `json:"clientId,omitempty"`
+	ClientSecret *Secret `json:"clientSecret,omitempty"`
+}
+
+type SshPublicKey struct {
+	Username          string  `json:"username,omitempty"`
+	Password          *Secret `json:"password,omitempty"`
+	SshClientCert     *Secret `json:"sshClientCert,omitempty"`
+	CertType          string  `json:"certType,omitempty"`
+	SslClientCertPass *Secret `json:"sslClientCertPass,omitempty"`
+}
+
+type NodeConfig struct {
+	MinNodeCount int `json:"minNodeCount,omitempty"`
+	MaxNodeCount int `json:"maxNodeCount,omitempty"`
+}
+
+type LogConfig struct {
+	Enabled bool   `json:"enabled,omitempty"`
+	Level   string `json:"level,omitempty"`
+}
+
+type DestinationConfig struct {
+	Key          string        `json:"key,omitempty"`
+	Destinations []Destination `json:"destinations,omitempty"`
+}
+
+type Destination struct {
+	Port              int    `json:"port,omitempty"`
+	ServiceAttachment string `json:"serviceAttachment,omitempty"`
+	Host              string `json:"host,omitempty"`
+}
+
+// ID returns the connection id from the resource name of the connection
+func (c Connection) ID() string {
+	return path.Base(c.Name)
+}
+
+// ListConnections returns a page of the connections in the region
+func (c *Client) ListConnections(ctx context.Context, opts ListOptions) (*ConnectionList, error) {
+	l := &ConnectionList{}
+	if err := c.do(ctx, http.MethodGet, withListOptions(c.connectorsURL("connections"), opts), nil, l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// GetConnection returns a connection by name
+func (c *Client) GetConnection(ctx context.Context, name string) (*Connection, error) {
+	conn := &Connection{}
+	if err := c.do(ctx, http.MethodGet, c.connectorsURL("connections", name), nil, conn); err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
+
+// CreateConnection starts the creation of a connection. Use WaitOperation to wait for the connection
+func (c *Client) CreateConnection(ctx context.Context, name string, connection *Connection) (*Operation, error) {
+	u := c.connectorsURL("connections") + "?" + url.Values{"connectionId": []string{name}}.Encode()
+	o := &Operation{}
+	if err := c.do(ctx, http.MethodPost, u, connection, o); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// DeleteConnection starts the deletion of a connection. Use WaitOperation to wait for the deletion
+func (c *Client) DeleteConnection(ctx context.Context, name string) (*Operation, error) {
+	o := &Operation{}
+	if err := c.do(ctx, http.MethodDelete, c.connectorsURL("connections", name), nil, o); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// GetOperation returns a connectors operation by its resource name or id
+func (c *Client) GetOperation(ctx context.Context, name string) (*Operation, error) {
+	u := c.connectorsURL("operations", name)
+	if strings.HasPrefix(name, "projects/") {
+		u = c.connectorsEndpoint + path.Join("/v1", name)
+	}
+	o := &Operation{}
+	if err := c.do(ctx, http.MethodGet, u, nil, o); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// WaitOperation polls a connectors operation every interval until it is done or the context
+// is cancelled. The error of a failed operation is returned as an APIError
+func (c *Client) WaitOperation(ctx context.Context, name string, interval time.Duration) (*Operation, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		o, err := c.GetOperation(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if o.Done {
+			if o.Error != nil {
+				return o, o.Error
+			}
+			return o, nil
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// ExecuteRequest runs the published version of an integration from an api trigger
+type ExecuteRequest struct {
+	// TriggerId is the api trigger, for example api_trigger/my-trigger
+	TriggerId           string           `json:"triggerId,omitempty"`
+	DoNotPropagateError bool             `json:"doNotPropagateError,omitempty"`
+	RequestId           string           `json:"requestId,omitempty"`
+	InputParameters     map[string]Value `json:"inputParameters,omitempty"`
+}
+
+// ExecuteResponse is the result of a synchronous execution
+type ExecuteResponse struct {
+	ExecutionId      string                 `json:"executionId,omitempty"`
+	OutputParameters map[string]interface{} `json:"outputParameters,omitempty"`
+}
+
+// Execution is the record of an integration execution
+type Execution struct {
+	Name                        string                    `json:"name,omitempty"`
+	Trigger                     string                    `json:"trigger,omitempty"`
+	ExecutionMethod             string                    `json:"executionMethod,omitempty"`
+	CreateTime                  string                    `json:"createTime,omitempty"`
+	UpdateTime                  string                    `json:"updateTime,omitempty"`
+	RequestParams               map[string]EventParameter `json:"requestParams,omitempty"`
+	ResponseParams              map[string]EventParameter `json:"responseParams,omitempty"`
+	IntegrationVersionUserLabel string                    `json:"integrationVersionUserLabel,omitempty"`
+	ExecutionDetails            *ExecutionDetails         `json:"executionDetails,omitempty"`
+}
+
+// ExecutionDetails is the state of an execution
+type ExecutionDetails struct {
+	State        string         `json:"state,omitempty"`
+	AttemptStats []AttemptStats `json:"attemptStats,omitempty"`
+}
+
+type AttemptStats struct {
+	StartTime string `json:"startTime,omitempty"`
+	EndTime   string `json:"endTime,omitempty"`
+}
+
+// ExecutionList is a page of executions
+type ExecutionList struct {
+	Executions    []Execution `json:"executions,omitempty"`
+	NextPageToken string      `json:"nextPageToken,omitempty"`
+}
+
+// Execute runs the published version of an integration and waits for the result
+func (c *Client) Execute(ctx context.Context, name string, request *ExecuteRequest) (*ExecuteResponse, error) {
+	if !strings.HasPrefix(request.TriggerId, "api_trigger/") {
+		return nil, errors.New("triggerId must match the format api_trigger/*")
+	}
+	r := &ExecuteResponse{}
+	if err := c.do(ctx, http.MethodPost, c.integrationsURL("integrations", name+":execute"), request, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ListExecutions returns a page of the executions of an integration
+func (c *Client) ListExecutions(ctx context.Context, name string, opts ListOptions) (*ExecutionList, error) {
+	l := &ExecutionList{}
+	u := withListOptions(c.integrationsURL("integrations", name, "executions"), opts)
+	if err := c.do(ctx, http.MethodGet, u, nil, l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
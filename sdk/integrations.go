@@ -0,0 +1,124 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+)
+
+// ID returns the version id from the resource name of the version
+func (v IntegrationVersion) ID() string {
+	return path.Base(v.Name)
+}
+
+// ListIntegrations returns a page of the integrations in the region
+func (c *Client) ListIntegrations(ctx context.Context, opts ListOptions) (*IntegrationList, error) {
+	l := &IntegrationList{}
+	if err := c.do(ctx, http.MethodGet, withListOptions(c.integrationsURL("integrations"), opts), nil, l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// DeleteIntegration deletes an integration and all its versions
+func (c *Client) DeleteIntegration(ctx context.Context, name string) error {
+	return c.do(ctx, http.MethodDelete, c.integrationsURL("integrations", name), nil, nil)
+}
+
+// ListVersions returns a page of the versions of an integration
+func (c *Client) ListVersions(ctx context.Context, name string, opts ListOptions) (*IntegrationVersionList, error) {
+	l := &IntegrationVersionList{}
+	u := withListOptions(c.integrationsURL("integrations", name, "versions"), opts)
+	if err := c.do(ctx, http.MethodGet, u, nil, l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// GetVersion returns a version of an integration
+func (c *Client) GetVersion(ctx context.Context, name string, version string) (*IntegrationVersion, error) {
+	v := &IntegrationVersion{}
+	if err := c.do(ctx, http.MethodGet, c.integrationsURL("integrations", name, "versions", version), nil, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// GetVersionBySnapshot returns the version of an integration with the snapshot number
+func (c *Client) GetVersionBySnapshot(ctx context.Context, name string, snapshot string) (*IntegrationVersion, error) {
+	l, err := c.ListVersions(ctx, name, ListOptions{Filter: "snapshotNumber=" + snapshot})
+	if err != nil {
+		return nil, err
+	}
+	if len(l.IntegrationVersions) == 0 {
+		return nil, &APIError{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("snapshot %s of integration %s not found", snapshot, name),
+		}
+	}
+	return &l.IntegrationVersions[0], nil
+}
+
+// CreateVersion creates a new draft version of an integration. The integration is created
+// if it does not exist
+func (c *Client) CreateVersion(ctx context.Context, name string, version *IntegrationVersion) (*IntegrationVersion, error) {
+	v := &IntegrationVersion{}
+	if err := c.do(ctx, http.MethodPost, c.integrationsURL("integrations", name, "versions"), version, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// PatchVersion updates the fields in the update mask of a draft version
+func (c *Client) PatchVersion(ctx context.Context, name string, version string, updateMask string,
+	content *IntegrationVersion,
+) (*IntegrationVersion, error) {
+	u := c.integrationsURL("integrations", name, "versions", version)
+	if updateMask != "" {
+		u += "?" + url.Values{"updateMask": []string{updateMask}}.Encode()
+	}
+	v := &IntegrationVersion{}
+	if err := c.do(ctx, http.MethodPatch, u, content, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// DeleteVersion deletes a version of an integration
+func (c *Client) DeleteVersion(ctx context.Context, name string, version string) error {
+	return c.do(ctx, http.MethodDelete, c.integrationsURL("integrations", name, "versions", version), nil, nil)
+}
+
+// PublishVersion publishes a version of an integration. configParameters sets the
+// values of the integration config variables and may be nil
+func (c *Client) PublishVersion(ctx context.Context, name string, version string, configParameters map[string]Value) error {
+	var payload interface{}
+	if configParameters != nil {
+		payload = struct {
+			ConfigParameters map[string]Value `json:"configParameters"`
+		}{configParameters}
+	}
+	return c.do(ctx, http.MethodPost, c.integrationsURL("integrations", name, "versions", version+":publish"), payload, nil)
+}
+
+// UnpublishVersion unpublishes a version of an integration
+func (c *Client) UnpublishVersion(ctx context.Context, name string, version string) error {
+	return c.do(ctx, http.MethodPost, c.integrationsURL("integrations", name, "versions", version+":unpublish"),
+		struct{}{}, nil)
+}
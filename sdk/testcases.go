@@ -0,0 +1,133 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"path"
+)
+
+// TestCase is a functional test of an integration version
+type TestCase struct {
+	Name                      string           `json:"name,omitempty"`
+	Description               string           `json:"description,omitempty"`
+	DisplayName               string           `json:"displayName,omitempty"`
+	TriggerId                 string           `json:"triggerId,omitempty"`
+	TestTaskConfigs           []TestTaskConfig `json:"testTaskConfigs,omitempty"`
+	DatabasePersistencePolicy string           `json:"databasePersistencePolicy,omitempty"`
+	TriggerConfig             *TriggerConfig   `json:"triggerConfig,omitempty"`
+	TestInputParameters       []Parameter      `json:"testInputParameters,omitempty"`
+}
+
+// TestCaseList is a page of test cases
+type TestCaseList struct {
+	TestCases     []TestCase `json:"testCases,omitempty"`
+	NextPageToken string     `json:"nextPageToken,omitempty"`
+}
+
+// TestTaskConfig configures the mocks and assertions of a task in a test case
+type TestTaskConfig struct {
+	TaskNumber string      `json:"taskNumber,omitempty"`
+	Task       string      `json:"task,omitempty"`
+	TaskConfig *TaskConfig `json:"taskConfig,omitempty"`
+	Assertions []Assertion `json:"assertions,omitempty"`
+	MockConfig *MockConfig `json:"mockConfig,omitempty"`
+}
+
+type Assertion struct {
+	AssertionStrategy string          `json:"assertionStrategy,omitempty"`
+	Parameter         *EventParameter `json:"parameter,omitempty"`
+	Condition         string          `json:"condition,omitempty"`
+	RetryCount        int             `json:"retryCount,omitempty"`
+}
+
+type MockConfig struct {
+	MockStrategy     string           `json:"mockStrategy,omitempty"`
+	Parameters       []EventParameter `json:"parameters,omitempty"`
+	FailedExecutions string           `json:"failedExecutions,omitempty"`
+}
+
+// TestCaseResult is the result of a test case execution
+type TestCaseResult struct {
+	ExecutionId        string                 `json:"executionId,omitempty"`
+	OutputParameters   map[string]interface{} `json:"outputParameters,omitempty"`
+	AssertionResults   []AssertionResult      `json:"assertionResults,omitempty"`
+	TestExecutionState string                 `json:"testExecutionState,omitempty"`
+}
+
+type AssertionResult struct {
+	TaskNumber     string     `json:"taskNumber,omitempty"`
+	Assertion      *Assertion `json:"assertion,omitempty"`
+	TaskName       string     `json:"taskName,omitempty"`
+	Status         string     `json:"status,omitempty"`
+	FailureMessage string     `json:"failureMessage,omitempty"`
+}
+
+// ID returns the test case id from the resource name of the test case
+func (t TestCase) ID() string {
+	return path.Base(t.Name)
+}
+
+// ListTestCases returns a page of the test cases of an integration version
+func (c *Client) ListTestCases(ctx context.Context, name string, version string, opts ListOptions) (*TestCaseList, error) {
+	l := &TestCaseList{}
+	u := withListOptions(c.integrationsURL("integrations", name, "versions", version, "testCases"), opts)
+	if err := c.do(ctx, http.MethodGet, u, nil, l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// GetTestCase returns a test case of an integration version
+func (c *Client) GetTestCase(ctx context.Context, name string, version string, testCaseID string) (*TestCase, error) {
+	t := &TestCase{}
+	u := c.integrationsURL("integrations", name, "versions", version, "testCases", testCaseID)
+	if err := c.do(ctx, http.MethodGet, u, nil, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// CreateTestCase creates a test case in an integration version
+func (c *Client) CreateTestCase(ctx context.Context, name string, version string, testCase *TestCase) (*TestCase, error) {
+	t := &TestCase{}
+	u := c.integrationsURL("integrations", name, "versions", version, "testCases")
+	if err := c.do(ctx, http.MethodPost, u, testCase, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// DeleteTestCase deletes a test case from an integration version
+func (c *Client) DeleteTestCase(ctx context.Context, name string, version string, testCaseID string) error {
+	u := c.integrationsURL("integrations", name, "versions", version, "testCases", testCaseID)
+	return c.do(ctx, http.MethodDelete, u, nil, nil)
+}
+
+// ExecuteTestCase runs a test case and returns the assertion results
+func (c *Client) ExecuteTestCase(ctx context.Context, name string, version string, testCaseID string,
+	inputParameters map[string]Value,
+) (*TestCaseResult, error) {
+	payload := struct {
+		TestInputParameters map[string]Value `json:"testInputParameters,omitempty"`
+	}{inputParameters}
+	r := &TestCaseResult{}
+	u := c.integrationsURL("integrations", name, "versions", version, "testCases", testCaseID+":executeTest")
+	if err := c.do(ctx, http.MethodPost, u, payload, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
@@ -0,0 +1,223 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+// Integration is an integration and the state of its versions
+type Integration struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	UpdateTime  string `json:"updateTime,omitempty"`
+	Active      bool   `json:"active,omitempty"`
+}
+
+// IntegrationList is a page of integrations
+type IntegrationList struct {
+	Integrations  []Integration `json:"integrations,omitempty"`
+	NextPageToken string        `json:"nextPageToken,omitempty"`
+}
+
+// IntegrationVersion is a version of an integration
+type IntegrationVersion struct {
+	Name                        string               `json:"name,omitempty"`
+	Description                 string               `json:"description,omitempty"`
+	Origin                      string               `json:"origin,omitempty"`
+	Status                      string               `json:"status,omitempty"`
+	SnapshotNumber              string               `json:"snapshotNumber,omitempty"`
+	UpdateTime                  string               `json:"updateTime,omitempty"`
+	LockHolder                  string               `json:"lockHolder,omitempty"`
+	CreateTime                  string               `json:"createTime,omitempty"`
+	LastModifierEmail           string               `json:"lastModifierEmail,omitempty"`
+	State                       string               `json:"state,omitempty"`
+	TriggerConfigs              []TriggerConfig      `json:"triggerConfigs,omitempty"`
+	TaskConfigs                 []TaskConfig         `json:"taskConfigs,omitempty"`
+	IntegrationParameters       []Parameter          `json:"integrationParameters,omitempty"`
+	IntegrationConfigParameters []ParameterConfig    `json:"integrationConfigParameters,omitempty"`
+	UserLabel                   *string              `json:"userLabel,omitempty"`
+	DatabasePersistencePolicy   string               `json:"databasePersistencePolicy,omitempty"`
+	ErrorCatcherConfigs         []ErrorCatcherConfig `json:"errorCatcherConfigs,omitempty"`
+	RunAsServiceAccount         string               `json:"runAsServiceAccount,omitempty"`
+	ParentTemplateId            string               `json:"parentTemplateId,omitempty"`
+	CloudLoggingDetails         *CloudLoggingDetails `json:"cloudLoggingDetails,omitempty"`
+	EnableVariableMasking       bool                 `json:"enableVariableMasking,omitempty"`
+}
+
+// IntegrationVersionList is a page of integration versions
+type IntegrationVersionList struct {
+	IntegrationVersions []IntegrationVersion `json:"integrationVersions,omitempty"`
+	NextPageToken       string               `json:"nextPageToken,omitempty"`
+}
+
+// CloudLoggingDetails configures cloud logging for an integration version
+type CloudLoggingDetails struct {
+	CloudLoggingSeverity string `json:"cloudLoggingSeverity,omitempty"`
+	EnableCloudLogging   bool   `json:"enableCloudLogging"`
+}
+
+// Parameter is an integration variable
+type Parameter struct {
+	Key               string `json:"key,omitempty"`
+	DataType          string `json:"dataType,omitempty"`
+	DefaultValue      *Value `json:"defaultValue,omitempty"`
+	Name              string `json:"name,omitempty"`
+	IsTransient       bool   `json:"isTransient,omitempty"`
+	InputOutputType   string `json:"inputOutputType,omitempty"`
+	Producer          string `json:"producer,omitempty"`
+	Searchable        bool   `json:"searchable,omitempty"`
+	JsonSchema        string `json:"jsonSchema,omitempty"`
+	Masked            bool   `json:"masked,omitempty"`
+	ContainsLargeData bool   `json:"containsLargeData,omitempty"`
+	Description       string `json:"description,omitempty"`
+}
+
+// ParameterConfig is an integration config variable and its value
+type ParameterConfig struct {
+	Parameter ConfigParameter `json:"parameter,omitempty"`
+	Value     *Value          `json:"value,omitempty"`
+}
+
+// ConfigParameter is the definition of an integration config variable
+type ConfigParameter struct {
+	Key          string `json:"key,omitempty"`
+	DataType     string `json:"dataType,omitempty"`
+	DefaultValue *Value `json:"defaultValue,omitempty"`
+	DisplayName  string `json:"displayName,omitempty"`
+}
+
+// EventParameter is a key and value passed to a task or an execution
+type EventParameter struct {
+	Key    string `json:"key,omitempty"`
+	Value  Value  `json:"value,omitempty"`
+	Masked bool   `json:"masked,omitempty"`
+}
+
+// Value holds one of the value types of a parameter
+type Value struct {
+	StringValue  *string       `json:"stringValue,omitempty"`
+	IntValue     *string       `json:"intValue,omitempty"`
+	BooleanValue *bool         `json:"booleanValue,omitempty"`
+	StringArray  *StringArray  `json:"stringArray,omitempty"`
+	JsonValue    *string       `json:"jsonValue,omitempty"`
+	DoubleValue  float64       `json:"doubleValue,omitempty"`
+	IntArray     *IntArray     `json:"intArray,omitempty"`
+	DoubleArray  *DoubleArray  `json:"doubleArray,omitempty"`
+	BooleanArray *BooleanArray `json:"booleanArray,omitempty"`
+}
+
+type StringArray struct {
+	StringValues []string `json:"stringValues,omitempty"`
+}
+
+type IntArray struct {
+	IntValues []string `json:"intValues,omitempty"`
+}
+
+type DoubleArray struct {
+	DoubleValues []float64 `json:"doubleValues,omitempty"`
+}
+
+type BooleanArray struct {
+	BooleanValues []bool `json:"booleanValues,omitempty"`
+}
+
+// TriggerConfig is a trigger of an integration version
+type TriggerConfig struct {
+	Label                    string                    `json:"label,omitempty"`
+	TriggerType              string                    `json:"triggerType,omitempty"`
+	TriggerNumber            string                    `json:"triggerNumber,omitempty"`
+	TriggerId                string                    `json:"triggerId,omitempty"`
+	Description              string                    `json:"description,omitempty"`
+	StartTasks               []NextTask                `json:"startTasks,omitempty"`
+	NextTasksExecutionPolicy string                    `json:"nextTasksExecutionPolicy,omitempty"`
+	Properties               map[string]string         `json:"properties,omitempty"`
+	CloudSchedulerConfig     *CloudSchedulerConfig     `json:"cloudSchedulerConfig,omitempty"`
+	ErrorCatcherId           string                    `json:"errorCatcherId,omitempty"`
+	InputVariables           *InputOutputVariableNames `json:"inputVariables,omitempty"`
+	OutputVariables          *InputOutputVariableNames `json:"outputVariables,omitempty"`
+}
+
+// TaskConfig is a task of an integration version
+type TaskConfig struct {
+	Task                         string                    `json:"task,omitempty"`
+	TaskId                       string                    `json:"taskId,omitempty"`
+	Parameters                   map[string]EventParameter `json:"parameters,omitempty"`
+	DisplayName                  string                    `json:"displayName,omitempty"`
+	NextTasks                    []NextTask                `json:"nextTasks,omitempty"`
+	NextTasksExecutionPolicy     string                    `json:"nextTasksExecutionPolicy,omitempty"`
+	TaskExecutionStrategy        string                    `json:"taskExecutionStrategy,omitempty"`
+	JsonValidationOption         string                    `json:"jsonValidationOption,omitempty"`
+	SuccessPolicy                *SuccessPolicy            `json:"successPolicy,omitempty"`
+	TaskTemplate                 string                    `json:"taskTemplate,omitempty"`
+	FailurePolicy                *FailurePolicy            `json:"failurePolicy,omitempty"`
+	ConditionalFailurePolicies   *ConditionalFailurePolicy `json:"conditionalFailurePolicies,omitempty"`
+	SynchronousCallFailurePolicy *FailurePolicy            `json:"synchronousCallFailurePolicy,omitempty"`
+	ErrorCatcherId               string                    `json:"errorCatcherId,omitempty"`
+	ExternalTaskType             string                    `json:"externalTaskType,omitempty"`
+}
+
+// ErrorCatcherConfig is an error catcher of an integration version
+type ErrorCatcherConfig struct {
+	Label              string      `json:"label,omitempty"`
+	ErrorCatcherNumber string      `json:"errorCatcherNumber,omitempty"`
+	ErrorCatcherId     string      `json:"errorCatcherId,omitempty"`
+	StartErrorTasks    []StartTask `json:"startErrorTasks,omitempty"`
+}
+
+type StartTask struct {
+	TaskId string `json:"taskId,omitempty"`
+}
+
+type InputOutputVariableNames struct {
+	Names []string `json:"names,omitempty"`
+}
+
+type NextTask struct {
+	TaskConfigId string `json:"taskConfigId,omitempty"`
+	TaskId       string `json:"taskId,omitempty"`
+	Condition    string `json:"condition,omitempty"`
+	DisplayName  string `json:"displayName,omitempty"`
+	Description  string `json:"description,omitempty"`
+}
+
+type SuccessPolicy struct {
+	FinalState string `json:"finalState,omitempty"`
+}
+
+type FailurePolicy struct {
+	RetryStrategy string `json:"retryStrategy,omitempty"`
+	MaxRetries    int    `json:"maxRetries,omitempty"`
+	IntervalTime  string `json:"intervalTime,omitempty"`
+	Condition     string `json:"condition,omitempty"`
+}
+
+type ConditionalFailurePolicy struct {
+	FailurePolicies      []FailurePolicy `json:"failurePolicies,omitempty"`
+	DefaultFailurePolicy *FailurePolicy  `json:"defaultFailurePolicy,omitempty"`
+}
+
+type CloudSchedulerConfig struct {
+	ServiceAccountEmail string `json:"serviceAccountEmail,omitempty"`
+	CronTab             string `json:"cronTab,omitempty"`
+	Location            string `json:"location,omitempty"`
+	ErrorMessage        string `json:"errorMessage,omitempty"`
+}
+
+// Operation is a long running operation
+type Operation struct {
+	Name     string                 `json:"name,omitempty"`
+	Done     bool                   `json:"done,omitempty"`
+	Error    *APIError              `json:"error,omitempty"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	Response map[string]interface{} `json:"response,omitempty"`
+}